@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Audit struct
+type Audit struct{}
+
+// NewAudit return pointer to audit struct
+// with all methods
+func NewAudit() *Audit {
+	return &Audit{}
+}
+
+var auditService = services.NewAuditService()
+
+// Get returns audit events
+// @Summary Returns page of audit events of catering or client from the newest one, catering sees events of its clients
+// @Tags audit
+// @Produce json
+// @Param id path string true "Catering or Client ID"
+// @Param entity query string false "Entity type" Enums(order, catering, catering_user, catering_schedule, client, client_user, client_schedule, category, dish, meal, invoice)
+// @Param entityId query string false "Entity ID"
+// @Param actorId query string false "User ID of actor"
+// @Param from query string false "From date in YYYY-MM-DDT00:00:00Z format"
+// @Param to query string false "To date in YYYY-MM-DDT00:00:00Z format, inclusive"
+// @Param limit query int false "used for pagination"
+// @Param page query int false "used for pagination"
+// @Success 200 {object} swagger.AuditEvents false "Audit events"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/audit [get]
+// @Router /clients/{id}/audit [get]
+func (a Audit) Get(c *gin.Context) {
+	var path url.PathID
+	var query url.AuditQuery
+	var paginationQuery url.PaginationQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&paginationQuery, c); err != nil {
+		return
+	}
+
+	result, code, err := auditService.Get(pathCompanyType(c), path, query, paginationQuery)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
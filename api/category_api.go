@@ -56,7 +56,9 @@ func (dc Category) Add(c *gin.Context) {
 		ClientID:   clientID,
 	}
 
-	err := categoryRepo.Add(&category)
+	ctxUser, _ := c.Get("user")
+
+	err := categoryRepo.Add(&category, ctxUser.(domain.User))
 
 	if err != nil {
 		utils.CreateError(http.StatusBadRequest, err, c)
@@ -83,7 +85,9 @@ func (dc Category) Delete(c *gin.Context) {
 		return
 	}
 
-	if code, err := categoryRepo.Delete(path); err != nil {
+	ctxUser, _ := c.Get("user")
+
+	if code, err := categoryRepo.Delete(path, ctxUser.(domain.User)); err != nil {
 		utils.CreateError(code, err, c)
 		return
 	}
@@ -152,7 +156,9 @@ func (dc Category) Update(c *gin.Context) {
 		return
 	}
 
-	code, err := categoryRepo.Update(path, &category)
+	ctxUser, _ := c.Get("user")
+
+	code, err := categoryRepo.Update(path, &category, ctxUser.(domain.User))
 
 	if err != nil {
 		utils.CreateError(code, err, c)
@@ -103,7 +103,9 @@ func (ca Catering) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := cateringRepo.Delete(path.ID); err != nil {
+	ctxUser, _ := c.Get("user")
+
+	if err := cateringRepo.Delete(path.ID, ctxUser.(domain.User)); err != nil {
 		utils.CreateError(http.StatusNotFound, err, c)
 		return
 	}
@@ -160,7 +162,9 @@ func (ca Catering) Update(c *gin.Context) {
 		return
 	}
 
-	if code, err := cateringRepo.Update(path.ID, cateringModel); err != nil {
+	ctxUser, _ := c.Get("user")
+
+	if code, err := cateringRepo.Update(path.ID, cateringModel, ctxUser.(domain.User)); err != nil {
 		utils.CreateError(code, err, c)
 		return
 	}
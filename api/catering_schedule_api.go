@@ -78,7 +78,9 @@ func (s CateringSchedule) Update(c *gin.Context) {
 		End:   body.End,
 	}
 
-	code, err := cateringScheduleRepo.Update(path.ID, path.ScheduleID, body.IsWorking, schedule)
+	ctxUser, _ := c.Get("user")
+
+	code, err := cateringScheduleRepo.Update(path.ID, path.ScheduleID, body.IsWorking, schedule, ctxUser.(domain.User))
 
 	if err != nil {
 		utils.CreateError(code, err, c)
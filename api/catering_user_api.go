@@ -63,7 +63,8 @@ func (cu *CateringUser) Add(c *gin.Context) {
 		return
 	}
 
-	userClientCatering, userCreated, password, err, userErr := cateringUserService.Add(path, user)
+	ctxUser, _ := c.Get("user")
+	userClientCatering, userCreated, password, err, userErr := cateringUserService.Add(path, user, ctxUser)
 
 	if err != nil {
 		utils.CreateError(http.StatusBadRequest, err, c)
@@ -155,14 +156,13 @@ func (cu *CateringUser) Delete(c *gin.Context) {
 	user.DeletedAt = &deletedAt
 
 	ctxUser, _ := c.Get("user")
-	ctxUserRole := ctxUser.(domain.User).Role
 
 	if user.ID == ctxUser.(domain.User).ID {
 		utils.CreateError(http.StatusBadRequest, errors.New("can't delete yourself"), c)
 		return
 	}
 
-	code, err := cateringUserRepo.Delete(path.ID, ctxUserRole, user)
+	code, err := cateringUserRepo.Delete(path.ID, user, ctxUser.(domain.User))
 
 	if err != nil {
 		utils.CreateError(code, err, c)
@@ -213,7 +213,9 @@ func (cu *CateringUser) Update(c *gin.Context) { //nolint:dupl
 	user.CompanyType = &enums.CompanyTypesEnum.Catering
 	user.ID = parsedUserID
 
-	code, err := cateringUserRepo.Update(&user)
+	ctxUser, _ := c.Get("user")
+
+	code, err := cateringUserRepo.Update(path.ID, &user, ctxUser.(domain.User))
 	if err != nil {
 		utils.CreateError(code, err, c)
 		return
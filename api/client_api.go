@@ -224,7 +224,9 @@ func (cl Client) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := clientRepo.Delete(path.ID); err != nil {
+	ctxUser, _ := c.Get("user")
+
+	if err := clientRepo.Delete(path.ID, ctxUser.(domain.User)); err != nil {
 		utils.CreateError(http.StatusNotFound, err, c)
 		return
 	}
@@ -255,7 +257,9 @@ func (cl Client) Update(c *gin.Context) {
 		return
 	}
 
-	if code, err := clientRepo.Update(path.ID, clientModal); err != nil {
+	ctxUser, _ := c.Get("user")
+
+	if code, err := clientRepo.Update(path.ID, clientModal, ctxUser.(domain.User)); err != nil {
 		utils.CreateError(code, err, c)
 		return
 	}
@@ -286,10 +290,131 @@ func (cl Client) UpdateAutoApprove(c *gin.Context) {
 		return
 	}
 
-	if code, err := clientRepo.UpdateAutoApproveOrders(path.ID, *body.Status); err != nil {
+	ctxUser, _ := c.Get("user")
+
+	if code, err := clientRepo.UpdateAutoApproveOrders(path.ID, *body.Status, ctxUser.(domain.User)); err != nil {
 		utils.CreateError(code, err, c)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// GetSubsidy returns subsidy of client
+// @Summary Returns part of order total paid by client
+// @Produce json
+// @Tags clients
+// @Param id path string true "Client ID"
+// @Success 200 {object} swagger.ClientSubsidy "Client subsidy"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /clients/{id}/subsidy [get]
+func (cl Client) GetSubsidy(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := clientService.GetSubsidy(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateSubsidy replaces subsidy of client,
+// it's applied to orders created or updated after that
+// @Summary Returns updated client subsidy
+// @Produce json
+// @Accept json
+// @Tags clients
+// @Param id path string true "Client ID"
+// @Param body body swagger.ClientSubsidy false "Client subsidy"
+// @Success 200 {object} swagger.ClientSubsidy "Client subsidy"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /clients/{id}/subsidy [put]
+func (cl Client) UpdateSubsidy(c *gin.Context) {
+	var path url.PathID
+	var body models.UpdateSubsidy
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := clientService.UpdateSubsidy(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetBudget returns budget of client
+// @Summary Returns budget of client and its consumption in current month
+// @Produce json
+// @Tags clients
+// @Param id path string true "Client ID"
+// @Success 200 {object} swagger.ClientBudget "Client budget"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /clients/{id}/budget [get]
+func (cl Client) GetBudget(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := clientService.GetBudget(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateBudget replaces budget of client
+// @Summary Returns updated client budget
+// @Produce json
+// @Accept json
+// @Tags clients
+// @Param id path string true "Client ID"
+// @Param body body swagger.UpdateBudget false "Client budget"
+// @Success 200 {object} swagger.ClientBudget "Client budget"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /clients/{id}/budget [put]
+func (cl Client) UpdateBudget(c *gin.Context) {
+	var path url.PathID
+	var body models.UpdateBudget
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := clientService.UpdateBudget(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
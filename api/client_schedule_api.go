@@ -80,7 +80,9 @@ func (cs ClientSchedule) Update(c *gin.Context) {
 		End:   body.End,
 	}
 
-	updatedSchedule, code, err := clientScheduleRepo.Update(path.ID, path.ScheduleID, body.IsWorking, schedule)
+	ctxUser, _ := c.Get("user")
+
+	updatedSchedule, code, err := clientScheduleRepo.Update(path.ID, path.ScheduleID, body.IsWorking, schedule, ctxUser.(domain.User))
 	if err != nil {
 		utils.CreateError(code, err, c)
 		return
@@ -61,7 +61,8 @@ func (cu ClientUser) Add(c *gin.Context) {
 		return
 	}
 
-	userClientCatering, code, password, err, userErr := clientUserService.Add(path, body, user)
+	ctxUser, _ := c.Get("user")
+	userClientCatering, code, password, err, userErr := clientUserService.Add(path, body, user, ctxUser)
 
 	if err != nil {
 		utils.CreateError(code, err, c)
@@ -151,9 +152,7 @@ func (cu ClientUser) Delete(c *gin.Context) {
 	}
 
 	delUser, _ := c.Get("user")
-	userRole := delUser.(domain.User).Role
-	userID := delUser.(domain.User).ID.String()
-	code, err := clientUserService.Delete(path, user, userRole, userID)
+	code, err := clientUserService.Delete(path, user, delUser)
 
 	if err != nil {
 		utils.CreateError(code, err, c)
@@ -188,7 +187,8 @@ func (cu ClientUser) Update(c *gin.Context) {
 		return
 	}
 
-	code, err := clientUserService.Update(path, body, user)
+	ctxUser, _ := c.Get("user")
+	code, err := clientUserService.Update(path, body, user, ctxUser)
 
 	if err != nil {
 		utils.CreateError(code, err, c)
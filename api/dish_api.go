@@ -45,7 +45,9 @@ func (d Dish) Add(c *gin.Context) {
 
 	dish.CateringID, _ = uuid.FromString(path.ID)
 
-	err := dishRepo.Add(path.ID, &dish)
+	ctxUser, _ := c.Get("user")
+
+	err := dishRepo.Add(path.ID, &dish, ctxUser.(domain.User))
 
 	if err != nil {
 		utils.CreateError(http.StatusBadRequest, err, c)
@@ -73,7 +75,9 @@ func (d Dish) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := dishRepo.Delete(path); err != nil {
+	ctxUser, _ := c.Get("user")
+
+	if err := dishRepo.Delete(path, ctxUser.(domain.User)); err != nil {
 		utils.CreateError(http.StatusNotFound, err, c)
 		return
 	}
@@ -87,12 +91,15 @@ func (d Dish) Delete(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Catering ID"
 // @Param categoryID query string true "Category ID"
+// @Param exclude_allergens query string false "Comma separated allergens, dishes with them aren't returned"
+// @Param tags query string false "Comma separated dietary tags, only dishes with all of them are returned"
 // @Success 200 {array} domain.Dish "List of dishes"
 // @Failure 400 {object} Error "Error"
 // @Router /caterings/{id}/dishes [get]
 func (d Dish) Get(c *gin.Context) {
 	var path url.PathID
 	var query url.CategoryIDQuery
+	var filter url.LabelFilterQuery
 
 	if err := utils.RequestBinderURI(&path, c); err != nil {
 		return
@@ -100,8 +107,11 @@ func (d Dish) Get(c *gin.Context) {
 	if err := utils.RequestBinderQuery(&query, c); err != nil {
 		return
 	}
+	if err := utils.RequestBinderQuery(&filter, c); err != nil {
+		return
+	}
 
-	dishes, code, err := dishRepo.Get(path.ID, query.CategoryID)
+	dishes, code, err := dishRepo.Get(path.ID, query.CategoryID, filter)
 
 	if err != nil {
 		utils.CreateError(code, err, c)
@@ -166,7 +176,9 @@ func (d Dish) Update(c *gin.Context) {
 		return
 	}
 
-	if code, err := dishRepo.Update(path, dish); err != nil {
+	ctxUser, _ := c.Get("user")
+
+	if code, err := dishRepo.Update(path, dish, ctxUser.(domain.User)); err != nil {
 		utils.CreateError(code, err, c)
 		return
 	}
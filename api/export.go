@@ -0,0 +1,22 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/gin-gonic/gin"
+)
+
+// sendFile streams export file to response as attachment,
+// status is already sent when file is written, so error
+// of writing is only attached to context
+func sendFile(c *gin.Context, file services.ExportFile) {
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	c.Header("Content-Type", file.ContentType)
+	c.Status(http.StatusOK)
+
+	if err := file.Write(c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditAPI is audit interface for API
+type AuditAPI interface {
+	Get(c *gin.Context)
+}
+
+// AuditService is audit interface for service
+type AuditService interface {
+	Get(companyType string, path url.PathID, query url.AuditQuery, pagination url.PaginationQuery) (models.AuditEvents, int, error)
+}
+
+// AuditRepository is audit interface for repository
+type AuditRepository interface {
+	Get(companyType, ownerID string, query url.AuditQuery, from, to time.Time, pagination url.PaginationQuery) (models.AuditEvents, int, error)
+}
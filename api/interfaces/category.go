@@ -16,9 +16,9 @@ type CategoryAPI interface {
 
 // CategoryRepository is category interface for repository
 type CategoryRepository interface {
-	Add(category *domain.Category) error
+	Add(category *domain.Category, actor domain.User) error
 	Get(cateringID, clientID, date string) ([]domain.Category, int, error)
 	GetByKey(id, value, cateringID string) (domain.Category, error)
-	Delete(path url.PathCategory) (int, error)
-	Update(path url.PathCategory, category *domain.Category) (int, error)
+	Delete(path url.PathCategory, actor domain.User) (int, error)
+	Update(path url.PathCategory, category *domain.Category, actor domain.User) (int, error)
 }
@@ -19,8 +19,8 @@ type CateringAPI interface {
 type CateringRepository interface {
 	Get(cateringID string, query url.PaginationQuery) ([]domain.Catering, int, error)
 	Add(catering *domain.Catering) error
-	Update(id string, catering domain.Catering) (int, error)
-	Delete(id string) error
+	Update(id string, catering domain.Catering, actor domain.User) (int, error)
+	Delete(id string, actor domain.User) error
 	GetByKey(key, value string) (domain.Catering, error)
 }
 
@@ -9,7 +9,7 @@ import (
 // for catering schedule
 type CateringScheduleRepository interface {
 	Get(cateringID string) ([]domain.CateringSchedule, int, error)
-	Update(cateringID, scheduleID string, isWorking *bool, newSchedule *domain.CateringSchedule) (int, error)
+	Update(cateringID, scheduleID string, isWorking *bool, newSchedule *domain.CateringSchedule, actor domain.User) (int, error)
 }
 
 // CateringScheduleAPI is API interface
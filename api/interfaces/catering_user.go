@@ -18,13 +18,13 @@ type CateringUserAPI interface {
 // CateringUserRepository is CateringUser interface for repository
 type CateringUserRepository interface {
 	GetByKey(key, value string) (domain.CateringUser, error)
-	Add(cateringUser domain.CateringUser) error
+	Add(cateringUser domain.CateringUser, actor domain.User) error
 	Get(cateringID string, pagination url.PaginationQuery, filters url.UserFilterQuery) ([]models.GetCateringUser, int, int, error)
-	Delete(cateringID, ctxUserRole string, user domain.User) (int, error)
-	Update(user *domain.User) (int, error)
+	Delete(cateringID string, user domain.User, actor domain.User) (int, error)
+	Update(cateringID string, user *domain.User, actor domain.User) (int, error)
 }
 
 // CateringUserService is CateringUser interface for service
 type CateringUserService interface {
-	Add(path url.PathID, user domain.User) (models.UserClientCatering, domain.User, string, error, error)
+	Add(path url.PathID, user domain.User, ctxUser interface{}) (models.UserClientCatering, domain.User, string, error, error)
 }
@@ -14,6 +14,10 @@ type ClientAPI interface {
 	Add(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
+	GetSubsidy(c *gin.Context)
+	UpdateSubsidy(c *gin.Context)
+	GetBudget(c *gin.Context)
+	UpdateBudget(c *gin.Context)
 }
 
 // ClientRepository is client interface for repository
@@ -21,9 +25,9 @@ type ClientRepository interface {
 	Add(cateringID string, client *domain.Client) error
 	GetCateringClientsOrders(cateringID string, query url.PaginationWithDateQuery) ([]models.ClientOrder, int, error)
 	Get(query url.PaginationQuery, cateringID, role string) ([]models.Client, int, error)
-	Delete(id string) error
-	Update(id string, client domain.Client) (int, error)
-	UpdateAutoApproveOrders(id string, status bool) (int, error)
+	Delete(id string, actor domain.User) error
+	Update(id string, client domain.Client, actor domain.User) (int, error)
+	UpdateAutoApproveOrders(id string, status bool, actor domain.User) (int, error)
 	InitAutoApprove(id string) (int, error)
 	UpdateAutoApproveSchedules(id string)
 	GetByKey(key, value string) (domain.Client, error)
@@ -33,4 +37,20 @@ type ClientRepository interface {
 // ClientService is client interface for service
 type ClientService interface {
 	Get(query url.PaginationQuery, claims jwt.MapClaims) ([]models.Client, int, url.PaginationQuery, int, error)
+	GetSubsidy(path url.PathID) (models.ClientSubsidy, int, error)
+	UpdateSubsidy(path url.PathID, body models.UpdateSubsidy) (models.ClientSubsidy, int, error)
+	GetBudget(path url.PathID) (models.ClientBudget, int, error)
+	UpdateBudget(path url.PathID, body models.UpdateBudget) (models.ClientBudget, int, error)
+}
+
+// ClientSubsidyRepository is client subsidy interface for repository
+type ClientSubsidyRepository interface {
+	Get(clientID string) (models.ClientSubsidy, int, error)
+	Update(clientID string, subsidy domain.ClientSubsidy, categories []domain.ClientSubsidyCategory) (models.ClientSubsidy, int, error)
+}
+
+// ClientBudgetRepository is client budget interface for repository
+type ClientBudgetRepository interface {
+	Get(clientID string) (models.ClientBudget, int, error)
+	Update(clientID string, budget domain.ClientBudget) (models.ClientBudget, int, error)
 }
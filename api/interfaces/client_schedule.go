@@ -10,7 +10,8 @@ import (
 // for client schedule
 type ClientScheduleRepository interface {
 	Get(clientID string) ([]models.ClientSchedulesCatering, int, error)
-	Update(clientID, scheduleID string, isWorking *bool, newSchedule domain.ClientSchedule) (models.ClientSchedulesCatering, int, error)
+	Update(clientID, scheduleID string, isWorking *bool, newSchedule domain.ClientSchedule, actor domain.User) (models.ClientSchedulesCatering, int, error)
+	GetWithCatering(clientID string) ([]models.ClientSchedulesCatering, int, error)
 }
 
 // ClientScheduleAPI is API interface
@@ -16,10 +16,10 @@ type DishAPI interface {
 
 // DishRepository is dish interface for repository
 type DishRepository interface {
-	Add(cateringID string, dish *domain.Dish) error
-	Delete(path url.PathDish) error
-	Get(cateringID, categoryID string) ([]domain.Dish, int, error)
+	Add(cateringID string, dish *domain.Dish, actor domain.User) error
+	Delete(path url.PathDish, actor domain.User) error
+	Get(cateringID, categoryID string, filter url.LabelFilterQuery) ([]domain.Dish, int, error)
 	FindByID(cateringID, id string) (domain.Dish, int, error)
 	GetByKey(key, value, cateringID, categoryID string) (domain.Dish, int, error)
-	Update(path url.PathDish, dish domain.Dish) (int, error)
+	Update(path url.PathDish, dish domain.Dish, actor domain.User) (int, error)
 }
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/gin-gonic/gin"
+)
+
+// InvoiceAPI is invoice interface for API
+type InvoiceAPI interface {
+	Add(c *gin.Context)
+	Get(c *gin.Context)
+	GetByID(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	GetFile(c *gin.Context)
+}
+
+// InvoiceService is invoice interface for service
+type InvoiceService interface {
+	Add(path url.PathClient, body models.CreateInvoice, user interface{}) (models.Invoice, int, error)
+	Get(path url.PathClient, query url.PaginationQuery) ([]models.Invoice, int, url.PaginationQuery, int, error)
+	GetByID(path url.PathInvoice) (models.Invoice, int, error)
+	Update(path url.PathInvoice, body models.UpdateInvoice, user interface{}) (models.Invoice, int, error)
+	Delete(path url.PathInvoice, user interface{}) (int, error)
+	GetFile(path url.PathInvoice, query url.FileFormatQuery) (services.ExportFile, int, error)
+}
+
+// InvoiceRepository is invoice interface for repository
+type InvoiceRepository interface {
+	Add(cateringID, clientID string, from, to time.Time, discount float32, actor domain.User) (models.Invoice, int, error)
+	Get(cateringID, clientID string, query url.PaginationQuery) ([]models.Invoice, int, error)
+	GetByID(cateringID, clientID, id string) (models.Invoice, int, error)
+	Update(cateringID, clientID, id string, body models.UpdateInvoice, actor domain.User) (models.Invoice, int, error)
+	Delete(cateringID, clientID, id string, actor domain.User) (int, error)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+)
+
+// LabelAPI is label interface for API
+type LabelAPI interface {
+	Add(c *gin.Context)
+	Get(c *gin.Context)
+	Delete(c *gin.Context)
+	GetProfile(c *gin.Context)
+	UpdateProfile(c *gin.Context)
+}
+
+// LabelService is label interface for service
+type LabelService interface {
+	Add(path url.PathID, label domain.Label, user interface{}) (domain.Label, int, error)
+	Get(path url.PathID, query url.LabelTypeQuery) ([]domain.Label, int, error)
+	Delete(path url.PathLabel, user interface{}) (int, error)
+	GetProfile(path url.PathID) (models.DietaryProfile, int, error)
+	UpdateProfile(path url.PathID, body models.UpdateDietaryProfile) (models.DietaryProfile, int, error)
+}
+
+// LabelRepository is label interface for repository
+type LabelRepository interface {
+	Add(label *domain.Label, actor domain.User) (int, error)
+	Get(cateringID, labelType string) ([]domain.Label, int, error)
+	Delete(path url.PathLabel, actor domain.User) (int, error)
+	GetDietaryProfile(userID string) (models.DietaryProfile, int, error)
+	UpdateDietaryProfile(userID, cateringID uuid.UUID, labelIDs []uuid.UUID) (models.DietaryProfile, int, error)
+	GetDietaryWarnings(userID string, dishIDs []uuid.UUID) ([]models.DietaryWarning, error)
+}
@@ -13,18 +13,28 @@ import (
 // MealAPI is meal interface for API
 type MealAPI interface {
 	Add(c *gin.Context)
+	Copy(c *gin.Context)
 	Get(c *gin.Context)
+	UpdatePortions(c *gin.Context)
+	Diff(c *gin.Context)
+	Rollback(c *gin.Context)
 }
 
 // MealRepository is meal interface for repository
 type MealRepository interface {
 	Find(meal *domain.Meal) error
-	Add(meal *domain.Meal) error
-	Get(mealDate time.Time, id, clientID string) ([]models.GetMeal, int, error)
+	Add(meal *domain.Meal, actor domain.User) error
+	Get(mealDate time.Time, id, clientID string, filter url.LabelFilterQuery) ([]models.GetMeal, int, error)
+	GetLatest(cateringID, clientID string, mealDate time.Time) (domain.Meal, int, error)
+	GetVersion(cateringID, clientID, mealID, version string) (domain.Meal, int, error)
 	GetByKey(key, value string) (domain.Meal, int, error)
 }
 
 // MealService is meal interface for service
 type MealService interface {
 	Add(path url.PathClient, body models.AddMeal, user interface{}) ([]models.GetMeal, int, error)
+	Copy(path url.PathClient, body models.CopyMeal, user interface{}) ([]models.CopiedMeal, int, error)
+	UpdatePortions(path url.PathMealDish, body models.UpdatePortions) (domain.MealDishPortion, int, error)
+	Diff(path url.PathMealVersionDiff) (models.MealDiff, int, error)
+	Rollback(path url.PathClient, body models.RollbackMeal, user interface{}) ([]models.GetMeal, int, error)
 }
@@ -1,11 +1,17 @@
 package domain
 
 import (
+	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	uuid "github.com/satori/go.uuid"
 )
 
 // MealDishRepository is mealDish interface for repository
 type MealDishRepository interface {
 	Add(mealDish domain.MealDish) error
+	GetForCopy(mealID uuid.UUID) ([]models.CopyMealDish, error)
+	GetByMeal(mealID uuid.UUID) ([]models.MealVersionDish, error)
 	Delete(mealID string) error
+	SetPortions(path url.PathMealDish, portions *int) (domain.MealDishPortion, int, error)
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplateAPI is menu template interface for API
+type MenuTemplateAPI interface {
+	Add(c *gin.Context)
+	Get(c *gin.Context)
+	GetByID(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Preview(c *gin.Context)
+	AddOverride(c *gin.Context)
+	DeleteOverride(c *gin.Context)
+}
+
+// MenuTemplateService is menu template interface for service
+type MenuTemplateService interface {
+	Add(path url.PathID, body models.MenuTemplateRequest, user interface{}) (models.MenuTemplate, int, error)
+	Get(path url.PathID) ([]models.MenuTemplate, int, error)
+	GetByID(path url.PathMenuTemplate) (models.MenuTemplate, int, error)
+	Update(path url.PathMenuTemplate, body models.MenuTemplateRequest, user interface{}) (models.MenuTemplate, int, error)
+	Delete(path url.PathMenuTemplate, user interface{}) (int, error)
+	AddOverride(path url.PathMenuTemplate, body models.MenuTemplateOverride) (domain.MenuTemplateOverride, int, error)
+	DeleteOverride(path url.PathMenuTemplate, query url.MenuTemplateOverrideQuery) (int, error)
+	Preview(path url.PathMenuTemplate, query url.DateRangeQuery) ([]models.MenuTemplateDay, int, error)
+	PublishMeals()
+}
+
+// MenuTemplateRepository is menu template interface for repository
+type MenuTemplateRepository interface {
+	Add(menuTemplate domain.MenuTemplate, items []domain.MenuTemplateItem, actor domain.User) (models.MenuTemplate, int, error)
+	Get(cateringID string) ([]models.MenuTemplate, int, error)
+	GetByID(cateringID, id string) (models.MenuTemplate, int, error)
+	GetEnabled() ([]models.MenuTemplate, error)
+	Update(cateringID, id string, menuTemplate domain.MenuTemplate, items []domain.MenuTemplateItem, actor domain.User) (models.MenuTemplate, int, error)
+	Delete(cateringID, id string, actor domain.User) (int, error)
+	AddOverride(override domain.MenuTemplateOverride) (domain.MenuTemplateOverride, int, error)
+	DeleteOverride(menuTemplateID, clientID string, date time.Time) (int, error)
+	GetDayDishes(menuTemplateID, clientID uuid.UUID, day int, date time.Time) ([]models.MenuTemplateDish, error)
+	GetDishesByIDs(dishIDs []string, date time.Time) ([]models.MenuTemplateDish, error)
+	GetPlacements(menuTemplateID uuid.UUID, from, to time.Time) ([]domain.MenuTemplatePlacement, error)
+	ClaimPlacement(menuTemplateID, clientID uuid.UUID, date time.Time) (bool, error)
+	SetPlacementMeal(menuTemplateID, clientID uuid.UUID, date time.Time, mealID uuid.UUID) error
+	ReleasePlacement(menuTemplateID, clientID uuid.UUID, date time.Time) error
+}
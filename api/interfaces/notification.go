@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+)
+
+// NotificationAPI is notification interface for API
+type NotificationAPI interface {
+	Get(c *gin.Context)
+	Read(c *gin.Context)
+}
+
+// NotificationService is notification interface for service
+type NotificationService interface {
+	Get(path url.PathID, query url.NotificationQuery, pagination url.PaginationQuery) (models.Notifications, int, error)
+	Read(path url.PathID, body models.ReadNotifications) (int, error)
+	SendEmails()
+}
+
+// NotificationRepository is notification interface for repository
+type NotificationRepository interface {
+	Get(userID string, unread bool, query url.PaginationQuery) (models.Notifications, int, error)
+	Read(userID string, ids []uuid.UUID) (int, error)
+	TakeToEmail(limit int) ([]models.NotificationEmail, error)
+	MarkEmailed(id uuid.UUID) error
+	MarkEmailFailed(id uuid.UUID) error
+}
@@ -1,7 +1,12 @@
 package domain
 
 import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/events"
 	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
 )
@@ -9,17 +14,42 @@ import (
 // OrderAPI is order interface for API
 type OrderAPI interface {
 	Add(c *gin.Context)
+	Update(c *gin.Context)
 	CancelOrder(c *gin.Context)
+	AddBulk(c *gin.Context)
+	CancelBulk(c *gin.Context)
 	GetUserOrder(c *gin.Context)
+	GetHistory(c *gin.Context)
+	Repeat(c *gin.Context)
 	GetClientOrders(c *gin.Context)
+	ExportClientOrders(c *gin.Context)
 	GetCateringClientOrders(c *gin.Context)
 	ApproveOrders(c *gin.Context)
+	UpdateOrdersStatus(c *gin.Context)
+	UpdateDeliveryStatus(c *gin.Context)
 	GetOrderStatus(c *gin.Context)
+	GetProduction(c *gin.Context)
+	ExportProduction(c *gin.Context)
+	StreamOrders(c *gin.Context)
 }
 
 // OrderService is order interface for service
 type OrderService interface {
 	Add(query string, order models.OrderRequest, claims jwt.MapClaims) (models.UserOrder, int, error)
+	AddForUser(userID string, date time.Time, order models.OrderRequest) (models.UserOrder, int, error)
+	Update(path url.PathOrder, order models.OrderRequest) (models.UpdatedUserOrder, int, error)
+	CancelOrder(path url.PathOrder, user interface{}) (int, error)
+	AddBulk(path url.PathID, orders map[string]models.OrderRequest) ([]models.BulkOrderResult, int, error)
+	CancelBulk(path url.PathID, query url.DateRangeQuery, user interface{}) ([]models.BulkOrderResult, int, error)
+	GetHistory(path url.PathID, query url.OrderHistoryQuery, pagination url.PaginationQuery) (models.OrderHistory, int, error)
+	Repeat(path url.PathOrder, query url.DateQuery) (models.UserOrder, int, error)
+	UpdateOrdersStatus(path url.PathID, body models.UpdateOrdersStatus, user interface{}) (int, error)
+	UpdateDeliveryStatus(path url.PathClient, query url.DateQuery, body models.UpdateDeliveryStatus, user interface{}) (int, error)
+	OrderCutoff(clientID string, date time.Time) (time.Time, int, error)
+	GetProduction(path url.PathID, query url.DateQuery) (models.ProductionSheet, int, error)
+	ExportClientOrders(path url.PathID, query url.OrderExportQuery) (services.ExportFile, int, error)
+	ExportProduction(path url.PathID, query url.DateQuery, file url.FileFormatQuery) (services.ExportFile, int, error)
+	SubscribeOrders(companyType string, path url.PathID) (*events.Subscription, int, error)
 }
 
 // OrderRepository is order interface for repository
@@ -1,12 +1,20 @@
 package domain
 
-import "github.com/Aiscom-LLC/meals-api/repository/models"
+import (
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	uuid "github.com/satori/go.uuid"
+)
 
 // OrderDishRepository is order interface for repository
 type OrderDishRepository interface {
-	CancelOrder(userID, orderID string) (int, error)
+	CancelOrder(userID, orderID string, actor domain.User) (int, error)
 	GetUserOrder(userID, date string) (models.UserOrder, int, error)
 	GetOrders(cateringID, clientID, date, companyType string) (models.SummaryOrderResult, int, error)
-	ApproveOrders(clientID, date string) error
+	ApproveOrders(clientID, date string, actor domain.User) error
 	GetOrdersStatus(clientID, date string) *string
+	GetOrdersStatusCounts(clientID, date string) map[string]int
+	UpdateOrdersStatus(clientID string, orderIDs []uuid.UUID, status, reason string, actor domain.User) (int, error)
+	UpdateDeliveryStatus(cateringID, clientID, date string, fromStatuses []string, status, note string, actor domain.User) (int, error)
+	GetDeliveryChanges(clientID, date string) ([]models.OrderStatusChange, error)
 }
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReminderAPI is reminder interface for API
+type ReminderAPI interface {
+	Get(c *gin.Context)
+	Update(c *gin.Context)
+	Preview(c *gin.Context)
+	GetUser(c *gin.Context)
+	UpdateUser(c *gin.Context)
+}
+
+// ReminderService is reminder interface for service
+type ReminderService interface {
+	Get(path url.PathID) (models.ClientReminder, int, error)
+	Update(path url.PathID, body models.UpdateReminder) (models.ClientReminder, int, error)
+	Preview(path url.PathID) (models.ReminderPreview, int, error)
+	GetUser(path url.PathID) (models.UserReminder, int, error)
+	UpdateUser(path url.PathID, body models.UpdateUserReminder) (models.UserReminder, int, error)
+	SendReminders()
+}
+
+// ReminderRepository is reminder interface for repository
+type ReminderRepository interface {
+	Get(clientID string) (models.ClientReminder, int, error)
+	Update(clientID string, enabled bool, offset int) (models.ClientReminder, int, error)
+	GetEnabled() ([]domain.ClientReminder, error)
+	GetRecipients(clientID string, date time.Time) ([]models.ReminderRecipient, int, error)
+	Claim(userID uuid.UUID, date time.Time) (bool, error)
+	Release(userID uuid.UUID, date time.Time) error
+	SetLastDate(clientID uuid.UUID, date time.Time) error
+	GetUserReminder(userID string) (models.UserReminder, int, error)
+	UpdateUserReminder(userID string, enabled bool) (models.UserReminder, int, error)
+}
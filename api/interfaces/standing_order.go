@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrderAPI is standing order interface for API
+type StandingOrderAPI interface {
+	Add(c *gin.Context)
+	Get(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	AddSkip(c *gin.Context)
+	DeleteSkip(c *gin.Context)
+}
+
+// StandingOrderService is standing order interface for service
+type StandingOrderService interface {
+	Add(path url.PathID, body models.StandingOrderRequest) (models.StandingOrder, int, error)
+	Get(path url.PathID) ([]models.StandingOrder, int, error)
+	Update(path url.PathStandingOrder, body models.StandingOrderRequest) (models.StandingOrder, int, error)
+	Delete(path url.PathStandingOrder) (int, error)
+	AddSkip(path url.PathStandingOrder, query url.DateQuery) (int, error)
+	DeleteSkip(path url.PathStandingOrder, query url.DateQuery) (int, error)
+	PlaceOrders()
+}
+
+// StandingOrderRepository is standing order interface for repository
+type StandingOrderRepository interface {
+	Add(standingOrder domain.StandingOrder, items []domain.StandingOrderItem) (models.StandingOrder, int, error)
+	Get(userID string) ([]models.StandingOrder, int, error)
+	GetByID(userID, id string) (models.StandingOrder, int, error)
+	GetToPlace(date time.Time) ([]models.StandingOrder, error)
+	Update(userID, id string, standingOrder domain.StandingOrder, items []domain.StandingOrderItem) (models.StandingOrder, int, error)
+	Delete(userID, id string) (int, error)
+	AddSkip(userID, id string, date time.Time) (int, error)
+	DeleteSkip(userID, id string, date time.Time) (int, error)
+	AddPlacement(standingOrderID uuid.UUID, date time.Time, orderID *uuid.UUID) error
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+)
+
+// WebhookAPI is webhook interface for API
+type WebhookAPI interface {
+	Add(c *gin.Context)
+	Get(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	GetDeliveries(c *gin.Context)
+	Redeliver(c *gin.Context)
+}
+
+// WebhookService is webhook interface for service
+type WebhookService interface {
+	Add(companyType string, path url.PathID, body models.WebhookRequest) (models.Webhook, int, error)
+	Get(companyType string, path url.PathID) ([]models.Webhook, int, error)
+	Update(companyType string, path url.PathWebhook, body models.WebhookRequest) (models.Webhook, int, error)
+	Delete(companyType string, path url.PathWebhook) (int, error)
+	GetDeliveries(companyType string, path url.PathWebhook, query url.PaginationQuery) (models.WebhookDeliveries, int, error)
+	Redeliver(companyType string, path url.PathWebhookDelivery) (models.WebhookDelivery, int, error)
+	SendDeliveries()
+}
+
+// WebhookRepository is webhook interface for repository
+type WebhookRepository interface {
+	Add(companyType, ownerID string, webhook domain.Webhook) (models.Webhook, int, error)
+	Get(companyType, ownerID string) ([]models.Webhook, int, error)
+	Update(companyType, ownerID, id string, body domain.Webhook) (models.Webhook, int, error)
+	Delete(companyType, ownerID, id string) (int, error)
+	GetDeliveries(companyType, ownerID, webhookID string, query url.PaginationQuery) (models.WebhookDeliveries, int, error)
+	GetDelivery(webhookID, id string) (models.WebhookDelivery, int, error)
+	Redeliver(companyType, ownerID, webhookID, id string) (models.WebhookDeliveryTarget, int, error)
+	TakeDue(limit int) ([]models.WebhookDeliveryTarget, error)
+	SaveAttempt(id uuid.UUID, status string, attempts int, responseCode *int, errMessage *string, nextAttemptAt *time.Time) error
+	AddEvent(event string, cateringID, clientID uuid.UUID, data interface{}) error
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Invoice struct
+type Invoice struct{}
+
+// NewInvoice return pointer to invoice struct
+// with all methods
+func NewInvoice() *Invoice {
+	return &Invoice{}
+}
+
+var invoiceService = services.NewInvoiceService()
+
+// Add creates draft invoice of client for period
+// @Summary Returns created invoice with items built from approved orders
+// @Produce json
+// @Accept json
+// @Tags caterings clients invoices
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param body body swagger.CreateInvoice false "Invoice period"
+// @Success 201 {object} swagger.Invoice false "Invoice"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/invoices [post]
+func (i Invoice) Add(c *gin.Context) {
+	var path url.PathClient
+	var body models.CreateInvoice
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+	result, code, err := invoiceService.Add(path, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Get returns invoices of client
+// @Summary Returns list of invoices without items
+// @Produce json
+// @Tags caterings clients invoices
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param limit query int false "used for pagination"
+// @Param page query int false "used for pagination"
+// @Success 200 {object} swagger.GetInvoices false "Invoices"
+// @Failure 400 {object} Error "Error"
+// @Router /caterings/{id}/clients/{clientId}/invoices [get]
+func (i Invoice) Get(c *gin.Context) {
+	var path url.PathClient
+	var query url.PaginationQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	result, total, query, code, err := invoiceService.Get(path, query)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": result,
+		"page":  query.Page,
+		"total": total,
+	})
+}
+
+// GetByID returns invoice of client
+// @Summary Returns invoice with items
+// @Produce json
+// @Tags caterings clients invoices
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param invoiceId path string true "Invoice ID"
+// @Success 200 {object} swagger.Invoice false "Invoice"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/invoices/{invoiceId} [get]
+func (i Invoice) GetByID(c *gin.Context) {
+	var path url.PathInvoice
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := invoiceService.GetByID(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Update changes status or discount of invoice
+// @Summary Returns updated invoice, draft can be issued and issued can be paid
+// @Produce json
+// @Accept json
+// @Tags caterings clients invoices
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param invoiceId path string true "Invoice ID"
+// @Param body body swagger.UpdateInvoice false "Invoice status or discount"
+// @Success 200 {object} swagger.Invoice false "Invoice"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/invoices/{invoiceId} [put]
+func (i Invoice) Update(c *gin.Context) {
+	var path url.PathInvoice
+	var body models.UpdateInvoice
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+	result, code, err := invoiceService.Update(path, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete removes draft invoice
+// @Summary Returns error or 204 status code if success
+// @Produce json
+// @Tags caterings clients invoices
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param invoiceId path string true "Invoice ID"
+// @Success 204 "Successfully deleted"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/invoices/{invoiceId} [delete]
+func (i Invoice) Delete(c *gin.Context) {
+	var path url.PathInvoice
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+
+	if code, err := invoiceService.Delete(path, user); err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetFile returns invoice file
+// @Summary Returns invoice rendered to xlsx or pdf file
+// @Tags caterings clients invoices
+// @Produce octet-stream
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param invoiceId path string true "Invoice ID"
+// @Param format query string false "xlsx or pdf, xlsx by default"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/invoices/{invoiceId}/file [get]
+func (i Invoice) GetFile(c *gin.Context) {
+	var path url.PathInvoice
+	var query url.FileFormatQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	file, code, err := invoiceService.GetFile(path, query)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	sendFile(c, file)
+}
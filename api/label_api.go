@@ -0,0 +1,175 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Label struct
+type Label struct{}
+
+// NewLabel return pointer to label struct
+// with all methods
+func NewLabel() *Label {
+	return &Label{}
+}
+
+var labelService = services.NewLabelService()
+
+// Add adds allergen or dietary tag for catering
+// @Summary Add allergen or dietary tag which can be attached to dishes of catering
+// @Produce json
+// @Accept json
+// @Tags catering labels
+// @Param id path string true "Catering ID"
+// @Param body body swagger.AddLabel false "Label, type is allergen or dietary"
+// @Success 201 {object} swagger.Label "Label"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/labels [post]
+func (l Label) Add(c *gin.Context) {
+	var path url.PathID
+	var body domain.Label
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+	result, code, err := labelService.Add(path, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Get returns allergens and dietary tags of catering
+// @Summary Returns allergens and dietary tags of catering
+// @Produce json
+// @Tags catering labels
+// @Param id path string true "Catering ID"
+// @Param type query string false "allergen or dietary"
+// @Success 200 {array} swagger.Label "List of labels"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/labels [get]
+func (l Label) Get(c *gin.Context) {
+	var path url.PathID
+	var query url.LabelTypeQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	result, code, err := labelService.Get(path, query)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete removes label of catering
+// @Summary Removes label from catering, its dishes and dietary profiles of users
+// @Produce json
+// @Tags catering labels
+// @Param id path string true "Catering ID"
+// @Param labelId path string true "Label ID"
+// @Success 204 "Successfully deleted"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/labels/{labelId} [delete]
+func (l Label) Delete(c *gin.Context) {
+	var path url.PathLabel
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+
+	if code, err := labelService.Delete(path, user); err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetProfile returns dietary profile of user
+// @Summary Returns allergens which user avoids and dietary tags which user requires
+// @Produce json
+// @Tags users dietary profile
+// @Param id path string true "User ID"
+// @Success 200 {object} swagger.DietaryProfile "Dietary profile"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/dietary-profile [get]
+func (l Label) GetProfile(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := labelService.GetProfile(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateProfile replaces dietary profile of user
+// @Summary Returns updated dietary profile, order placement warns about dishes conflicting with it
+// @Produce json
+// @Accept json
+// @Tags users dietary profile
+// @Param id path string true "User ID"
+// @Param body body swagger.UpdateDietaryProfile false "Labels of catering of user"
+// @Success 200 {object} swagger.DietaryProfile "Dietary profile"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/dietary-profile [put]
+func (l Label) UpdateProfile(c *gin.Context) {
+	var path url.PathID
+	var body models.UpdateDietaryProfile
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := labelService.UpdateProfile(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -55,6 +55,42 @@ func (m Meal) Add(c *gin.Context) {
 	c.JSON(http.StatusCreated, result)
 }
 
+// Copy copies meal to other dates and clients
+// @Summary Copies latest version of meal for date to provided dates and clients of catering
+// @Tags catering meals
+// @Produce json
+// @Accept json
+// @Param id path string false "Catering ID"
+// @Param clientId path string false "Client ID of source meal"
+// @Param payload body swagger.CopyMeal false "source date, target dates and clients, client from path is used if clientIds are empty"
+// @Success 201 {array} swagger.CopiedMeal "created meals with skipped dishes, meal which wasn't copied has error"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/meals/copy [post]
+func (m Meal) Copy(c *gin.Context) {
+	var path url.PathClient
+	var body models.CopyMeal
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+
+	result, code, err := mealService().Copy(path, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
 // Get returns array of meals
 // @Summary GetByRange list of categories with dishes for passed meal ID
 // @Tags catering meals
@@ -62,12 +98,15 @@ func (m Meal) Add(c *gin.Context) {
 // @Param date query string false "Meal Date in 2020-01-01T00:00:00Z format"
 // @Param id path string false "Catering ID"
 // @Param clientId path string false "Client ID"
+// @Param exclude_allergens query string false "Comma separated allergens, dishes with them aren't returned"
+// @Param tags query string false "Comma separated dietary tags, only dishes with all of them are returned"
 // @Success 200 {array} swagger.GetMeal "dishes for passed day"
 // @Failure 400 {object} Error "Error"
 // @Failure 404 {object} Error "Not Found"
 // @Router /caterings/{id}/clients/{clientId}/meals [get]
 func (m Meal) Get(c *gin.Context) {
 	var query url.DateQuery
+	var filter url.LabelFilterQuery
 	var path url.PathClient
 
 	if err := utils.RequestBinderURI(&path, c); err != nil {
@@ -80,7 +119,12 @@ func (m Meal) Get(c *gin.Context) {
 		return
 	}
 
-	result, code, err := mealService().Get(query, path)
+	if err := utils.RequestBinderQuery(&filter, c); err != nil {
+		utils.CreateError(http.StatusBadRequest, err, c)
+		return
+	}
+
+	result, code, err := mealService().Get(query, filter, path)
 
 	if err != nil {
 		utils.CreateError(code, err, c)
@@ -89,3 +133,105 @@ func (m Meal) Get(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// UpdatePortions sets portions limit of dish in meal
+// @Summary Sets or removes (if portions is null) portions limit of dish for all versions of meal
+// @Tags catering meals
+// @Produce json
+// @Accept json
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param mealId path string true "Meal ID"
+// @Param dishId path string true "Dish ID"
+// @Param payload body swagger.UpdatePortions false "portions limit"
+// @Success 200 {object} domain.MealDishPortion "portions limit"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/meals/{mealId}/dishes/{dishId}/portions [put]
+func (m Meal) UpdatePortions(c *gin.Context) {
+	var path url.PathMealDish
+	var body models.UpdatePortions
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := mealService().UpdatePortions(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Diff returns changes between versions of meal
+// @Summary Returns dishes added, removed and with changed price from version a to version b
+// @Tags catering meals
+// @Produce json
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param mealId path string true "Meal ID"
+// @Param a path string true "Version to compare from, V.1 or 1"
+// @Param b path string true "Version to compare to, V.2 or 2"
+// @Success 200 {object} swagger.MealDiff "changes of meal"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/meals/{mealId}/versions/{a}/diff/{b} [get]
+func (m Meal) Diff(c *gin.Context) {
+	var path url.PathMealVersionDiff
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := mealService().Diff(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Rollback publishes older version of meal again
+// @Summary Creates new version of meal with the same dishes as provided version
+// @Tags catering meals
+// @Produce json
+// @Accept json
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param payload body swagger.RollbackMeal false "meal and version to roll back to"
+// @Success 201 {array} swagger.GetMeal "versions of meal"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/meals/rollback [post]
+func (m Meal) Rollback(c *gin.Context) {
+	var path url.PathClient
+	var body models.RollbackMeal
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+
+	result, code, err := mealService().Rollback(path, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MenuTemplate struct
+type MenuTemplate struct{}
+
+// NewMenuTemplate return pointer to menu template struct
+// with all methods
+func NewMenuTemplate() *MenuTemplate {
+	return &MenuTemplate{}
+}
+
+var menuTemplateService = services.NewMenuTemplateService()
+
+// Add creates menu template for catering
+// @Summary Returns created menu template, meals of enabled template are published automatically
+// @Produce json
+// @Accept json
+// @Tags catering menu templates
+// @Param id path string true "Catering ID"
+// @Param body body swagger.MenuTemplateRequest false "Menu template, day of item is day of cycle starting from 0"
+// @Success 201 {object} swagger.MenuTemplate "Menu template"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/menu-templates [post]
+func (m MenuTemplate) Add(c *gin.Context) {
+	var path url.PathID
+	var body models.MenuTemplateRequest
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+	result, code, err := menuTemplateService.Add(path, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Get returns menu templates of catering
+// @Summary Returns list of menu templates
+// @Produce json
+// @Tags catering menu templates
+// @Param id path string true "Catering ID"
+// @Success 200 {array} swagger.MenuTemplate "Menu templates"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/menu-templates [get]
+func (m MenuTemplate) Get(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := menuTemplateService.Get(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetByID returns menu template of catering
+// @Summary Returns menu template with items and upcoming overrides
+// @Produce json
+// @Tags catering menu templates
+// @Param id path string true "Catering ID"
+// @Param menuTemplateId path string true "Menu template ID"
+// @Success 200 {object} swagger.MenuTemplate "Menu template"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/menu-templates/{menuTemplateId} [get]
+func (m MenuTemplate) GetByID(c *gin.Context) {
+	var path url.PathMenuTemplate
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := menuTemplateService.GetByID(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Update updates menu template of catering
+// @Summary Returns updated menu template, template is enabled or disabled with enabled field
+// @Produce json
+// @Accept json
+// @Tags catering menu templates
+// @Param id path string true "Catering ID"
+// @Param menuTemplateId path string true "Menu template ID"
+// @Param body body swagger.MenuTemplateRequest false "Menu template"
+// @Success 200 {object} swagger.MenuTemplate "Menu template"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/menu-templates/{menuTemplateId} [put]
+func (m MenuTemplate) Update(c *gin.Context) {
+	var path url.PathMenuTemplate
+	var body models.MenuTemplateRequest
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+	result, code, err := menuTemplateService.Update(path, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete removes menu template of catering
+// @Summary Removes menu template, already published meals aren't changed
+// @Produce json
+// @Tags catering menu templates
+// @Param id path string true "Catering ID"
+// @Param menuTemplateId path string true "Menu template ID"
+// @Success 204 "Successfully deleted"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/menu-templates/{menuTemplateId} [delete]
+func (m MenuTemplate) Delete(c *gin.Context) {
+	var path url.PathMenuTemplate
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+
+	if code, err := menuTemplateService.Delete(path, user); err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Preview returns calendar generated by menu template
+// @Summary Returns meals which menu template generates for working days of catering
+// @Produce json
+// @Tags catering menu templates
+// @Param id path string true "Catering ID"
+// @Param menuTemplateId path string true "Menu template ID"
+// @Param from query string true "From date in 2020-01-01T00:00:00Z format"
+// @Param to query string true "To date in 2020-01-01T00:00:00Z format, range is limited to 62 days"
+// @Success 200 {array} swagger.MenuTemplateDay "Generated meals"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/menu-templates/{menuTemplateId}/preview [get]
+func (m MenuTemplate) Preview(c *gin.Context) {
+	var path url.PathMenuTemplate
+	var query url.DateRangeQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	result, code, err := menuTemplateService.Preview(path, query)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AddOverride overrides single date of menu template
+// @Summary Replaces dishes of client for single date without changing cycle, empty dishes skip the date
+// @Produce json
+// @Accept json
+// @Tags catering menu templates
+// @Param id path string true "Catering ID"
+// @Param menuTemplateId path string true "Menu template ID"
+// @Param body body swagger.MenuTemplateOverride false "Override"
+// @Success 200 {object} swagger.MenuTemplateOverride "Override"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/menu-templates/{menuTemplateId}/overrides [put]
+func (m MenuTemplate) AddOverride(c *gin.Context) {
+	var path url.PathMenuTemplate
+	var body models.MenuTemplateOverride
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := menuTemplateService.AddOverride(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteOverride cancels override of single date of menu template
+// @Summary Date of client gets dishes of its day of cycle again
+// @Produce json
+// @Tags catering menu templates
+// @Param id path string true "Catering ID"
+// @Param menuTemplateId path string true "Menu template ID"
+// @Param date query string true "Date in 2020-01-01T00:00:00Z format"
+// @Param clientId query string true "Client ID"
+// @Success 204 "Successfully deleted"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/menu-templates/{menuTemplateId}/overrides [delete]
+func (m MenuTemplate) DeleteOverride(c *gin.Context) {
+	var path url.PathMenuTemplate
+	var query url.MenuTemplateOverrideQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	if code, err := menuTemplateService.DeleteOverride(path, query); err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Notification struct
+type Notification struct{}
+
+// NewNotification return pointer to notification struct
+// with all methods
+func NewNotification() *Notification {
+	return &Notification{}
+}
+
+var notificationService = services.NewNotificationService()
+
+// Get returns notifications of user
+// @Summary Returns page of notifications of user from the newest one
+// @Tags users notifications
+// @Produce json
+// @Param id path string true "User ID"
+// @Param unread query bool false "returns only unread notifications"
+// @Param limit query int false "used for pagination"
+// @Param page query int false "used for pagination"
+// @Success 200 {object} swagger.Notifications false "Notifications"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/notifications [get]
+func (n Notification) Get(c *gin.Context) {
+	var path url.PathID
+	var query url.NotificationQuery
+	var paginationQuery url.PaginationQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&paginationQuery, c); err != nil {
+		return
+	}
+
+	result, code, err := notificationService.Get(path, query, paginationQuery)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Read marks notifications of user as read
+// @Summary Marks provided notifications as read, all notifications if ids are empty
+// @Tags users notifications
+// @Accept json
+// @Param id path string true "User ID"
+// @Param body body swagger.ReadNotifications false "Notification IDs"
+// @Success 204 "Successfully marked"
+// @Failure 400 {object} Error "Error"
+// @Router /users/{id}/notifications [put]
+func (n Notification) Read(c *gin.Context) {
+	var path url.PathID
+	var body models.ReadNotifications
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	code, err := notificationService.Read(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,12 +1,13 @@
 package api
 
 import (
+	"errors"
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/Aiscom-LLC/meals-api/api/middleware"
 	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository"
 	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/Aiscom-LLC/meals-api/repository/models"
@@ -37,7 +38,7 @@ var orderRepo = repository.NewOrderRepo()
 // @Param date query string true "Date query in YYYY-MM-DDT00:00:00Z format"
 // @Param body body swagger.OrderRequest false "User order"
 // @Success 201 {object} swagger.UserOrder false "Order for user"
-// @Failure 400 {object} Error "Error"
+// @Failure 400 {object} swagger.OrderError "Error"
 // @Router /users/{id}/orders [post]
 func (o Order) Add(c *gin.Context) {
 	var query url.DateQuery
@@ -65,16 +66,45 @@ func (o Order) Add(c *gin.Context) {
 	userOrder, code, err := orderService.Add(query.Date, order, jwt.MapClaims(claims))
 
 	if err != nil {
-		utils.CreateError(code, err, c)
+		createOrderError(code, err, c)
 		return
 	}
 
-	c.JSON(http.StatusCreated, models.UserOrder{
-		Items:   userOrder.Items,
-		Status:  userOrder.Status,
-		Total:   userOrder.Total,
-		OrderID: userOrder.OrderID,
-	})
+	c.JSON(http.StatusCreated, userOrder)
+}
+
+// Update replaces dishes and comment of pending order
+// @Summary Returns updated order with list of changes
+// @Produce json
+// @Accept json
+// @Tags users orders
+// @Param id path string false "User ID"
+// @Param orderId path string false "Order ID"
+// @Param body body swagger.OrderRequest false "User order"
+// @Success 200 {object} swagger.UpdatedUserOrder false "Updated order"
+// @Failure 400 {object} swagger.OrderError "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/orders/{orderId} [put]
+func (o Order) Update(c *gin.Context) {
+	var path url.PathOrder
+	var order models.OrderRequest
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&order, c); err != nil {
+		return
+	}
+
+	result, code, err := orderService.Update(path, order)
+
+	if err != nil {
+		createOrderError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
 }
 
 // CancelOrder changes status of order to canceled
@@ -95,7 +125,8 @@ func (o Order) CancelOrder(c *gin.Context) {
 		return
 	}
 
-	code, err := orderRepo.CancelOrder(path.ID, path.OrderID)
+	user, _ := c.Get("user")
+	code, err := orderService.CancelOrder(path, user)
 
 	if err != nil {
 		utils.CreateError(code, err, c)
@@ -105,6 +136,91 @@ func (o Order) CancelOrder(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// AddBulk creates orders of user for several days at once
+// @Summary Returns result for every day, orders are created only if all days are valid
+// @Produce json
+// @Accept json
+// @Tags users orders
+// @Param id path string true "User ID"
+// @Param body body swagger.BulkOrderRequest false "Orders by date"
+// @Success 201 {object} swagger.BulkOrderResponse false "Created orders"
+// @Failure 400 {object} swagger.BulkOrderResponse "Error"
+// @Router /users/{id}/orders-bulk [post]
+func (o Order) AddBulk(c *gin.Context) {
+	var path url.PathID
+	var orders map[string]models.OrderRequest
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&orders, c); err != nil {
+		return
+	}
+
+	results, code, err := orderService.AddBulk(path, orders)
+
+	if err != nil {
+		createBulkOrderError(code, err, results, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"results": results,
+	})
+}
+
+// CancelBulk cancels orders of user for range of days at once
+// @Summary Returns result for every canceled day, orders are canceled only if all of them can be canceled
+// @Produce json
+// @Tags users orders
+// @Param id path string true "User ID"
+// @Param from query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Param to query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Success 200 {object} swagger.BulkOrderResponse false "Canceled orders"
+// @Failure 400 {object} swagger.BulkOrderResponse "Error"
+// @Router /users/{id}/orders-bulk [delete]
+func (o Order) CancelBulk(c *gin.Context) {
+	var path url.PathID
+	var query url.DateRangeQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+	results, code, err := orderService.CancelBulk(path, query, user)
+
+	if err != nil {
+		createBulkOrderError(code, err, results, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+	})
+}
+
+// createBulkOrderError creates error response with result
+// of every day if error is related to certain days
+func createBulkOrderError(code int, err error, results []models.BulkOrderResult, c *gin.Context) {
+	if results == nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(code, gin.H{
+		"code":    code,
+		"error":   err.Error(),
+		"results": results,
+	})
+	_ = c.AbortWithError(code, err)
+}
+
 // GetUserOrder returns orders of provided user
 // @Summary returns orders of provided user
 // @Tags users orders
@@ -144,6 +260,80 @@ func (o Order) GetUserOrder(c *gin.Context) {
 	c.JSON(http.StatusOK, userOrder)
 }
 
+// GetHistory returns orders of provided user for range of dates
+// @Summary Returns page of orders with totals grouped by period
+// @Tags users orders
+// @Produce json
+// @Param id path string true "User ID"
+// @Param from query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Param to query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Param status query []string false "Order statuses"
+// @Param groupBy query string false "day, week or month, month by default"
+// @Param limit query int false "used for pagination"
+// @Param page query int false "used for pagination"
+// @Success 200 {object} swagger.OrderHistory false "Orders history"
+// @Failure 400 {object} Error "Error"
+// @Router /users/{id}/orders-history [get]
+func (o Order) GetHistory(c *gin.Context) {
+	var path url.PathID
+	var query url.OrderHistoryQuery
+	var paginationQuery url.PaginationQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&paginationQuery, c); err != nil {
+		return
+	}
+
+	result, code, err := orderService.GetHistory(path, query, paginationQuery)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Repeat creates order for provided date with dishes of past order
+// @Summary Returns created order
+// @Produce json
+// @Tags users orders
+// @Param id path string true "User ID"
+// @Param orderId path string true "Order ID"
+// @Param date query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Success 201 {object} swagger.UserOrder false "Created order"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/orders/{orderId}/repeat [post]
+func (o Order) Repeat(c *gin.Context) {
+	var path url.PathOrder
+	var query url.DateQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	result, code, err := orderService.Repeat(path, query)
+
+	if err != nil {
+		createOrderError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
 // GetClientOrders returns orders of provided client
 // @Summary returns orders of provided client
 // @Tags clients orders
@@ -227,7 +417,49 @@ func (o Order) GetCateringClientOrders(c *gin.Context) {
 	c.JSON(code, result)
 }
 
-// ApproveOrders changes status of orders for provided day
+// UpdateDeliveryStatus changes delivery status of approved orders for provided day
+// @Summary move client orders through preparing, out_for_delivery and delivered or failed statuses
+// @Tags caterings orders
+// @Produce json
+// @Accept json
+// @Param id path string true "Catering ID"
+// @Param clientId path string true "Client ID"
+// @Param date query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Param body body swagger.UpdateDeliveryStatus false "New status"
+// @Success 204 "Successfully updated"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/clients/{clientId}/orders [put]
+func (o Order) UpdateDeliveryStatus(c *gin.Context) {
+	var path url.PathClient
+	var query url.DateQuery
+	var body models.UpdateDeliveryStatus
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+
+	code, err := orderService.UpdateDeliveryStatus(path, query, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ApproveOrders changes status of pending orders for provided day
 // to approved
 // @Summary approve user orders
 // @Tags clients orders
@@ -250,7 +482,9 @@ func (o Order) ApproveOrders(c *gin.Context) {
 		return
 	}
 
-	if err := orderRepo.ApproveOrders(path.ID, query.Date); err != nil {
+	user, _ := c.Get("user")
+
+	if err := orderRepo.ApproveOrders(path.ID, query.Date, user.(domain.User)); err != nil {
 		utils.CreateError(http.StatusBadRequest, err, c)
 		return
 	}
@@ -258,8 +492,43 @@ func (o Order) ApproveOrders(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// UpdateOrdersStatus approves or rejects selected orders
+// @Summary approve or reject selected user orders, reason is required for rejection
+// @Tags clients orders
+// @Produce json
+// @Accept json
+// @Param id path string true "Client ID"
+// @Param body body swagger.UpdateOrdersStatus false "Orders and new status"
+// @Success 204 "Successfully updated"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /clients/{id}/orders/status [put]
+func (o Order) UpdateOrdersStatus(c *gin.Context) {
+	var path url.PathID
+	var body models.UpdateOrdersStatus
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	user, _ := c.Get("user")
+	code, err := orderService.UpdateOrdersStatus(path, body, user)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // GetOrderStatus returns status of order
-// @Summary returns status of order
+// and time until which orders for that date can be changed
+// @Summary returns status of order and ordering cutoff
 // @Tags clients orders
 // @Produce json
 // @Param id path string true "Client ID"
@@ -280,23 +549,74 @@ func (o Order) GetOrderStatus(c *gin.Context) {
 		return
 	}
 
+	date, err := time.Parse(time.RFC3339, query.Date)
+
+	if err != nil {
+		utils.CreateError(http.StatusBadRequest, err, c)
+		return
+	}
+
 	status := orderRepo.GetOrdersStatus(path.ID, query.Date)
+	counts := orderRepo.GetOrdersStatusCounts(path.ID, query.Date)
+
+	var cutoff *time.Time
+
+	if result, _, err := orderService.OrderCutoff(path.ID, date); err == nil {
+		cutoff = &result
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"status": status,
+		"counts": counts,
+		"cutoff": cutoff,
 	})
 }
 
-// GetClientOrdersExcel returns excel file of provided client
-// @Summary returns excel file of orders of provided client
+// ExportClientOrders returns file of orders of provided client
+// @Summary returns file with orders, summary and totals of provided client for date or range of dates
 // @Tags clients orders
-// @Produce json
+// @Produce octet-stream
 // @Param id path string true "Client ID"
-// @Param date query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Param date query string false "Date query in YYYY-MM-DDT00:00:00Z format, used instead of from and to"
+// @Param from query string false "Start date in YYYY-MM-DDT00:00:00Z format"
+// @Param to query string false "End date in YYYY-MM-DDT00:00:00Z format"
+// @Param format query string false "csv, json or xlsx, xlsx by default"
 // @Failure 400 {object} Error "Error"
 // @Failure 404 {object} Error "Not Found"
 // @Router /clients/{id}/orders-file [get]
-func (o Order) GetClientOrdersExcel(c *gin.Context) {
+func (o Order) ExportClientOrders(c *gin.Context) {
+	var path url.PathID
+	var query url.OrderExportQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	file, code, err := orderService.ExportClientOrders(path, query)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	sendFile(c, file)
+}
+
+// GetProduction returns production sheet of catering
+// @Summary Returns portions of dishes of all clients of catering for provided date
+// @Tags caterings orders
+// @Produce json
+// @Param id path string true "Catering ID"
+// @Param date query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Success 200 {object} swagger.ProductionSheet false "Production sheet"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/production [get]
+func (o Order) GetProduction(c *gin.Context) {
 	var path url.PathID
 	var query url.DateQuery
 
@@ -308,25 +628,94 @@ func (o Order) GetClientOrdersExcel(c *gin.Context) {
 		return
 	}
 
-	_, err := time.Parse(time.RFC3339, query.Date)
+	result, code, err := orderService.GetProduction(path, query)
 
 	if err != nil {
-		utils.CreateError(http.StatusBadRequest, err, c)
+		utils.CreateError(code, err, c)
 		return
 	}
 
-	pathDir, code, err := orderService.GetClientOrdersExcel(path, query)
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportProduction returns file of production sheet of catering
+// @Summary Returns file with summary, per client and delivery sheets
+// @Tags caterings orders
+// @Produce octet-stream
+// @Param id path string true "Catering ID"
+// @Param date query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Param format query string false "csv, json or xlsx, xlsx by default"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/production-file [get]
+func (o Order) ExportProduction(c *gin.Context) {
+	var path url.PathID
+	var query url.DateQuery
+	var format url.FileFormatQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&format, c); err != nil {
+		return
+	}
+
+	file, code, err := orderService.ExportProduction(path, query, format)
 
 	if err != nil {
 		utils.CreateError(code, err, c)
 		return
 	}
 
-	c.File(pathDir)
+	sendFile(c, file)
+}
+
+// createOrderError creates an error, if order contains dishes
+// which are not in the menu, adds their ids to response
+func createOrderError(code int, err error, c *gin.Context) {
+	var dishesErr *services.DishesNotInMealError
+
+	if !errors.As(err, &dishesErr) {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(code, gin.H{
+		"code":    code,
+		"error":   err.Error(),
+		"dishIds": dishesErr.DishIDs,
+	})
+	_ = c.AbortWithError(code, err)
+}
+
+// StreamOrders streams live order board of catering or client
+// @Summary Streams order created, updated, canceled, approved, rejected, delivery updated and delivered events with amounts of dishes as server-sent events
+// @Tags caterings orders
+// @Produce text/event-stream
+// @Param id path string true "Catering or Client ID"
+// @Success 200 {object} swagger.OrderBoardEvent false "Order board event"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/orders/stream [get]
+// @Router /clients/{id}/orders/stream [get]
+func (o Order) StreamOrders(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	subscription, code, err := orderService.SubscribeOrders(pathCompanyType(c), path)
 
-	err = os.Remove(pathDir)
 	if err != nil {
 		utils.CreateError(code, err, c)
 		return
 	}
+
+	streamEvents(c, subscription)
 }
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Reminder struct
+type Reminder struct{}
+
+// NewReminder return pointer to reminder struct
+// with all methods
+func NewReminder() *Reminder {
+	return &Reminder{}
+}
+
+var reminderService = services.NewReminderService()
+
+// Get returns reminder settings of client
+// @Summary Returns reminder settings of client
+// @Produce json
+// @Tags clients reminders
+// @Param id path string true "Client ID"
+// @Success 200 {object} swagger.ClientReminder "Reminder settings"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /clients/{id}/reminders [get]
+func (r Reminder) Get(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := reminderService.Get(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Update enables or disables reminders of client
+// @Summary Returns updated reminder settings of client
+// @Produce json
+// @Accept json
+// @Tags clients reminders
+// @Param id path string true "Client ID"
+// @Param body body swagger.UpdateReminder false "Reminder settings, offset in minutes before cutoff"
+// @Success 200 {object} swagger.ClientReminder "Reminder settings"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /clients/{id}/reminders [put]
+func (r Reminder) Update(c *gin.Context) {
+	var path url.PathID
+	var body models.UpdateReminder
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := reminderService.Update(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Preview returns users who would be reminded
+// @Summary Returns users without order for the next working day of client, nothing is sent
+// @Produce json
+// @Tags clients reminders
+// @Param id path string true "Client ID"
+// @Success 200 {object} swagger.ReminderPreview "Reminder preview"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /clients/{id}/reminders/preview [get]
+func (r Reminder) Preview(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := reminderService.Preview(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetUser returns whether user receives reminders
+// @Summary Returns reminder settings of user
+// @Produce json
+// @Tags users reminders
+// @Param id path string true "User ID"
+// @Success 200 {object} swagger.UserReminder "Reminder settings"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/reminders [get]
+func (r Reminder) GetUser(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := reminderService.GetUser(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateUser opts user in or out of reminders
+// @Summary Returns updated reminder settings of user
+// @Produce json
+// @Accept json
+// @Tags users reminders
+// @Param id path string true "User ID"
+// @Param body body swagger.UpdateUserReminder false "Reminder settings"
+// @Success 200 {object} swagger.UserReminder "Reminder settings"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/reminders [put]
+func (r Reminder) UpdateUser(c *gin.Context) {
+	var path url.PathID
+	var body models.UpdateUserReminder
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := reminderService.UpdateUser(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
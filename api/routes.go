@@ -40,6 +40,14 @@ func SetupRouter() *gin.Engine {
 	image := NewImage()
 	order := NewOrder()
 	address := NewAddress()
+	standingOrder := NewStandingOrder()
+	invoice := NewInvoice()
+	reminder := NewReminder()
+	notification := NewNotification()
+	webhook := NewWebhook()
+	audit := NewAudit()
+	label := NewLabel()
+	menuTemplate := NewMenuTemplate()
 
 	validator := middleware.NewValidator()
 
@@ -111,6 +119,7 @@ func SetupRouter() *gin.Engine {
 			// catering clients
 			caAdminSuAdmin.POST("/caterings/:id/clients", client.Add)
 			caAdminSuAdmin.GET("/caterings/:id/clients-orders", client.GetCateringClientsOrders)
+			caAdminSuAdmin.GET("/caterings/:id/orders/stream", order.StreamOrders)
 
 			// clients
 			caAdminSuAdmin.PUT("/clients/:id", client.Update)
@@ -118,12 +127,29 @@ func SetupRouter() *gin.Engine {
 
 			// catering client orders
 			caAdminSuAdmin.GET("/caterings/:id/clients/:clientId/orders", order.GetCateringClientOrders)
+			caAdminSuAdmin.PUT("/caterings/:id/clients/:clientId/orders", order.UpdateDeliveryStatus)
+
+			// catering production
+			caAdminSuAdmin.GET("/caterings/:id/production", order.GetProduction)
+			caAdminSuAdmin.GET("/caterings/:id/production-file", order.ExportProduction)
+
+			// catering client invoices
+			caAdminSuAdmin.POST("/caterings/:id/clients/:clientId/invoices", invoice.Add)
+			caAdminSuAdmin.GET("/caterings/:id/clients/:clientId/invoices", invoice.Get)
+			caAdminSuAdmin.GET("/caterings/:id/clients/:clientId/invoices/:invoiceId", invoice.GetByID)
+			caAdminSuAdmin.PUT("/caterings/:id/clients/:clientId/invoices/:invoiceId", invoice.Update)
+			caAdminSuAdmin.DELETE("/caterings/:id/clients/:clientId/invoices/:invoiceId", invoice.Delete)
+			caAdminSuAdmin.GET("/caterings/:id/clients/:clientId/invoices/:invoiceId/file", invoice.GetFile)
 
 			// catering dishes
 			caAdminSuAdmin.POST("/caterings/:id/dishes", dish.Add)
 			caAdminSuAdmin.DELETE("/caterings/:id/dishes/:dishId", dish.Delete)
 			caAdminSuAdmin.PUT("/caterings/:id/dishes/:dishId", dish.Update)
 
+			// catering labels
+			caAdminSuAdmin.POST("/caterings/:id/labels", label.Add)
+			caAdminSuAdmin.DELETE("/caterings/:id/labels/:labelId", label.Delete)
+
 			// catering images
 			caAdminSuAdmin.GET("/images", image.Get)
 			caAdminSuAdmin.POST("/caterings/:id/dishes/:dishId/images", image.Add)
@@ -132,10 +158,34 @@ func SetupRouter() *gin.Engine {
 
 			// catering meals
 			caAdminSuAdmin.POST("/caterings/:id/clients/:clientId/meals", meal.Add)
+			caAdminSuAdmin.POST("/caterings/:id/clients/:clientId/meals/copy", meal.Copy)
+			caAdminSuAdmin.PUT("/caterings/:id/clients/:clientId/meals/:mealId/dishes/:dishId/portions", meal.UpdatePortions)
+			caAdminSuAdmin.POST("/caterings/:id/clients/:clientId/meals/rollback", meal.Rollback)
+
+			// catering menu templates
+			caAdminSuAdmin.POST("/caterings/:id/menu-templates", menuTemplate.Add)
+			caAdminSuAdmin.GET("/caterings/:id/menu-templates", menuTemplate.Get)
+			caAdminSuAdmin.GET("/caterings/:id/menu-templates/:menuTemplateId", menuTemplate.GetByID)
+			caAdminSuAdmin.PUT("/caterings/:id/menu-templates/:menuTemplateId", menuTemplate.Update)
+			caAdminSuAdmin.DELETE("/caterings/:id/menu-templates/:menuTemplateId", menuTemplate.Delete)
+			caAdminSuAdmin.GET("/caterings/:id/menu-templates/:menuTemplateId/preview", menuTemplate.Preview)
+			caAdminSuAdmin.PUT("/caterings/:id/menu-templates/:menuTemplateId/overrides", menuTemplate.AddOverride)
+			caAdminSuAdmin.DELETE("/caterings/:id/menu-templates/:menuTemplateId/overrides", menuTemplate.DeleteOverride)
 
 			// catering schedules
 			caAdminSuAdmin.PUT("/caterings/:id/schedules/:scheduleId", cateringSchedule.Update)
 
+			// catering webhooks
+			caAdminSuAdmin.POST("/caterings/:id/webhooks", webhook.Add)
+			caAdminSuAdmin.GET("/caterings/:id/webhooks", webhook.Get)
+			caAdminSuAdmin.PUT("/caterings/:id/webhooks/:webhookId", webhook.Update)
+			caAdminSuAdmin.DELETE("/caterings/:id/webhooks/:webhookId", webhook.Delete)
+			caAdminSuAdmin.GET("/caterings/:id/webhooks/:webhookId/deliveries", webhook.GetDeliveries)
+			caAdminSuAdmin.POST("/caterings/:id/webhooks/:webhookId/deliveries/:deliveryId/redeliver", webhook.Redeliver)
+
+			// catering audit
+			caAdminSuAdmin.GET("/caterings/:id/audit", audit.Get)
+
 		}
 
 		clAdminSuAdmin := authRequired.Group("/")
@@ -154,9 +204,30 @@ func SetupRouter() *gin.Engine {
 
 			// client orders
 			clAdminSuAdmin.GET("/clients/:id/orders", order.GetClientOrders)
+			clAdminSuAdmin.GET("/clients/:id/orders/stream", order.StreamOrders)
 			clAdminSuAdmin.PUT("/clients/:id/orders", order.ApproveOrders)
+			clAdminSuAdmin.PUT("/clients/:id/orders/status", order.UpdateOrdersStatus)
 
 			clAdminSuAdmin.PUT("/clients/:id/auto-approve", client.UpdateAutoApprove)
+			clAdminSuAdmin.PUT("/clients/:id/subsidy", client.UpdateSubsidy)
+			clAdminSuAdmin.GET("/clients/:id/budget", client.GetBudget)
+			clAdminSuAdmin.PUT("/clients/:id/budget", client.UpdateBudget)
+
+			// client reminders
+			clAdminSuAdmin.GET("/clients/:id/reminders", reminder.Get)
+			clAdminSuAdmin.PUT("/clients/:id/reminders", reminder.Update)
+			clAdminSuAdmin.GET("/clients/:id/reminders/preview", reminder.Preview)
+
+			// client webhooks
+			clAdminSuAdmin.POST("/clients/:id/webhooks", webhook.Add)
+			clAdminSuAdmin.GET("/clients/:id/webhooks", webhook.Get)
+			clAdminSuAdmin.PUT("/clients/:id/webhooks/:webhookId", webhook.Update)
+			clAdminSuAdmin.DELETE("/clients/:id/webhooks/:webhookId", webhook.Delete)
+			clAdminSuAdmin.GET("/clients/:id/webhooks/:webhookId/deliveries", webhook.GetDeliveries)
+			clAdminSuAdmin.POST("/clients/:id/webhooks/:webhookId/deliveries/:deliveryId/redeliver", webhook.Redeliver)
+
+			// client audit
+			clAdminSuAdmin.GET("/clients/:id/audit", audit.Get)
 		}
 
 		clAdminUser := authRequired.Group("/")
@@ -168,8 +239,33 @@ func SetupRouter() *gin.Engine {
 		{
 			// client orders
 			clAdminUser.POST("/users/:id/orders", order.Add)
+			clAdminUser.PUT("/users/:id/orders/:orderId", order.Update)
 			clAdminUser.DELETE("/users/:id/orders/:orderId", order.CancelOrder)
 			clAdminUser.GET("/users/:id/orders", order.GetUserOrder)
+			clAdminUser.GET("/users/:id/orders-history", order.GetHistory)
+			clAdminUser.POST("/users/:id/orders/:orderId/repeat", order.Repeat)
+			clAdminUser.POST("/users/:id/orders-bulk", order.AddBulk)
+			clAdminUser.DELETE("/users/:id/orders-bulk", order.CancelBulk)
+
+			// standing orders
+			clAdminUser.POST("/users/:id/standing-orders", standingOrder.Add)
+			clAdminUser.GET("/users/:id/standing-orders", standingOrder.Get)
+			clAdminUser.PUT("/users/:id/standing-orders/:standingOrderId", standingOrder.Update)
+			clAdminUser.DELETE("/users/:id/standing-orders/:standingOrderId", standingOrder.Delete)
+			clAdminUser.POST("/users/:id/standing-orders/:standingOrderId/skips", standingOrder.AddSkip)
+			clAdminUser.DELETE("/users/:id/standing-orders/:standingOrderId/skips", standingOrder.DeleteSkip)
+
+			// reminders
+			clAdminUser.GET("/users/:id/reminders", reminder.GetUser)
+			clAdminUser.PUT("/users/:id/reminders", reminder.UpdateUser)
+
+			// notifications
+			clAdminUser.GET("/users/:id/notifications", notification.Get)
+			clAdminUser.PUT("/users/:id/notifications", notification.Read)
+
+			// dietary profile
+			clAdminUser.GET("/users/:id/dietary-profile", label.GetProfile)
+			clAdminUser.PUT("/users/:id/dietary-profile", label.UpdateProfile)
 
 			clAdminUser.GET("/clients/:id/order-status", order.GetOrderStatus)
 		}
@@ -187,6 +283,7 @@ func SetupRouter() *gin.Engine {
 
 			// catering meals
 			allUsers.GET("/caterings/:id/clients/:clientId/meals", meal.Get)
+			allUsers.GET("/caterings/:id/clients/:clientId/meals/:mealId/versions/:a/diff/:b", meal.Diff)
 
 			// schedules
 			allUsers.GET("/caterings/:id/schedules", cateringSchedule.Get)
@@ -196,6 +293,9 @@ func SetupRouter() *gin.Engine {
 			allUsers.GET("/caterings/:id/dishes", dish.Get)
 			allUsers.GET("/caterings/:id/dishes/:dishId", dish.GetByID)
 
+			// labels
+			allUsers.GET("/caterings/:id/labels", label.Get)
+
 			// auth
 			allUsers.PUT("/auth/change-password", auth.ChangePassword)
 		}
@@ -211,6 +311,7 @@ func SetupRouter() *gin.Engine {
 			allAdmins.GET("/clients", client.Get)
 			allAdmins.GET("/caterings/:id/clients", client.GetByCateringID)
 			allAdmins.GET("/clients/:id", client.GetByID)
+			allAdmins.GET("/clients/:id/subsidy", client.GetSubsidy)
 
 			// caterings
 			allAdmins.GET("/caterings", catering.Get)
@@ -225,7 +326,7 @@ func SetupRouter() *gin.Engine {
 			allAdmins.GET("/clients/:id/addresses", address.Get)
 
 			// orders
-			allAdmins.GET("/clients/:id/orders-file", order.GetClientOrdersExcel)
+			allAdmins.GET("/clients/:id/orders-file", order.ExportClientOrders)
 		}
 	}
 	return r
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// StandingOrder struct
+type StandingOrder struct{}
+
+// NewStandingOrder return pointer to standing order struct
+// with all methods
+func NewStandingOrder() *StandingOrder {
+	return &StandingOrder{}
+}
+
+var standingOrderService = services.NewStandingOrderService()
+
+// Add creates standing order for user
+// @Summary Returns created standing order
+// @Produce json
+// @Accept json
+// @Tags users standing orders
+// @Param id path string true "User ID"
+// @Param body body swagger.StandingOrderRequest false "Standing order"
+// @Success 201 {object} swagger.StandingOrder false "Standing order"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/standing-orders [post]
+func (s StandingOrder) Add(c *gin.Context) {
+	var path url.PathID
+	var body models.StandingOrderRequest
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := standingOrderService.Add(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Get returns standing orders of user
+// @Summary Returns list of standing orders
+// @Produce json
+// @Tags users standing orders
+// @Param id path string true "User ID"
+// @Success 200 {array} swagger.StandingOrder false "Standing orders"
+// @Failure 400 {object} Error "Error"
+// @Router /users/{id}/standing-orders [get]
+func (s StandingOrder) Get(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := standingOrderService.Get(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Update replaces standing order, also used to pause or resume it
+// @Summary Returns updated standing order
+// @Produce json
+// @Accept json
+// @Tags users standing orders
+// @Param id path string true "User ID"
+// @Param standingOrderId path string true "Standing order ID"
+// @Param body body swagger.StandingOrderRequest false "Standing order"
+// @Success 200 {object} swagger.StandingOrder false "Standing order"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/standing-orders/{standingOrderId} [put]
+func (s StandingOrder) Update(c *gin.Context) {
+	var path url.PathStandingOrder
+	var body models.StandingOrderRequest
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := standingOrderService.Update(path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete removes standing order
+// @Summary Returns error or 204 status code if success
+// @Produce json
+// @Tags users standing orders
+// @Param id path string true "User ID"
+// @Param standingOrderId path string true "Standing order ID"
+// @Success 204 "Successfully deleted"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/standing-orders/{standingOrderId} [delete]
+func (s StandingOrder) Delete(c *gin.Context) {
+	var path url.PathStandingOrder
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	code, err := standingOrderService.Delete(path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddSkip skips single date of standing order
+// @Summary Returns error or 204 status code if success
+// @Produce json
+// @Tags users standing orders
+// @Param id path string true "User ID"
+// @Param standingOrderId path string true "Standing order ID"
+// @Param date query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Success 204 "Successfully skipped"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/standing-orders/{standingOrderId}/skips [post]
+func (s StandingOrder) AddSkip(c *gin.Context) {
+	var path url.PathStandingOrder
+	var query url.DateQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	code, err := standingOrderService.AddSkip(path, query)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteSkip cancels skip of single date of standing order
+// @Summary Returns error or 204 status code if success
+// @Produce json
+// @Tags users standing orders
+// @Param id path string true "User ID"
+// @Param standingOrderId path string true "Standing order ID"
+// @Param date query string true "Date query in YYYY-MM-DDT00:00:00Z format"
+// @Success 204 "Successfully deleted"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /users/{id}/standing-orders/{standingOrderId}/skips [delete]
+func (s StandingOrder) DeleteSkip(c *gin.Context) {
+	var path url.PathStandingOrder
+	var query url.DateQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	code, err := standingOrderService.DeleteSkip(path, query)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
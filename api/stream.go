@@ -0,0 +1,46 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/events"
+	"github.com/gin-gonic/gin"
+)
+
+// streamHeartbeat is interval of comments sent to idle stream,
+// so proxies don't close connection without events
+const streamHeartbeat = 25 * time.Second
+
+// streamEvents writes events of subscription as server-sent events until
+// client disconnects, stream of dropped slow subscriber is closed and
+// client has to reload data and connect again
+func streamEvents(c *gin.Context, subscription *events.Subscription) {
+	defer subscription.Close()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -4,9 +4,14 @@ import uuid "github.com/satori/go.uuid"
 
 // AddDish request scheme
 type AddDish struct {
-	Name       string    `json:"name" gorm:"not null" binding:"required" example:"грибной суп"`
-	Weight     float32   `json:"weight" gorm:"not null" binding:"required" example:"250"`
-	Price      float32   `json:"price" gorm:"not null" binding:"required" example:"120"`
-	Desc       string    `json:"desc" example:"Очень вкусный"`
-	CategoryID uuid.UUID `json:"categoryId" binding:"required"`
+	Name       string      `json:"name" gorm:"not null" binding:"required" example:"грибной суп"`
+	Weight     float32     `json:"weight" gorm:"not null" binding:"required" example:"250"`
+	Price      float32     `json:"price" gorm:"not null" binding:"required" example:"120"`
+	Desc       string      `json:"desc" example:"Очень вкусный"`
+	Kcal       float32     `json:"kcal" example:"180"`
+	Protein    float32     `json:"protein" example:"8.5"`
+	Fat        float32     `json:"fat" example:"6"`
+	Carbs      float32     `json:"carbs" example:"24"`
+	CategoryID uuid.UUID   `json:"categoryId" binding:"required"`
+	LabelIDs   []uuid.UUID `json:"labelIds"`
 } // @name AddDishRequest
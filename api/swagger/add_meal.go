@@ -7,7 +7,8 @@ import (
 
 // AddMeal request scheme
 type AddMeal struct {
-	CateringID uuid.UUID `json:"-"`
-	Date       time.Time `json:"date" binding:"required" example:"2020-06-20T00:00:00Z"`
-	Dishes     []string  `json:"dishes" binding:"required"`
+	CateringID uuid.UUID      `json:"-"`
+	Date       time.Time      `json:"date" binding:"required" example:"2020-06-20T00:00:00Z"`
+	Dishes     []string       `json:"dishes" binding:"required"`
+	Portions   map[string]int `json:"portions"`
 } // @name AddMealRequest
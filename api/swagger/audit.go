@@ -0,0 +1,30 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// AuditEvent struct for response
+type AuditEvent struct {
+	ID         uuid.UUID   `json:"id"`
+	ActorID    *uuid.UUID  `json:"actorId"`
+	ActorName  string      `json:"actorName" example:"super admin"`
+	ActorRole  string      `json:"actorRole" example:"Super administrator"`
+	CateringID *uuid.UUID  `json:"cateringId"`
+	ClientID   *uuid.UUID  `json:"clientId"`
+	EntityType string      `json:"entityType" example:"order"`
+	EntityID   uuid.UUID   `json:"entityId"`
+	Action     string      `json:"action" example:"status_change"`
+	Before     interface{} `json:"before"`
+	After      interface{} `json:"after"`
+	CreatedAt  time.Time   `json:"createdAt" example:"2020-06-24T18:00:00Z"`
+}
+
+// AuditEvents struct for response
+type AuditEvents struct {
+	Items []AuditEvent `json:"items"`
+	Page  int          `json:"page" example:"1"`
+	Total int          `json:"total" example:"12"`
+}
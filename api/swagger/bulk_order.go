@@ -0,0 +1,23 @@
+package swagger
+
+import uuid "github.com/satori/go.uuid"
+
+// BulkOrderRequest struct for request scheme
+// keys are dates in YYYY-MM-DDT00:00:00Z format
+type BulkOrderRequest map[string]OrderRequest
+
+// BulkOrderResult struct for response
+type BulkOrderResult struct {
+	Date    string      `json:"date" example:"2020-06-22T00:00:00Z"`
+	Success bool        `json:"success"`
+	Order   *UserOrder  `json:"order,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	DishIDs []uuid.UUID `json:"dishIds,omitempty"`
+}
+
+// BulkOrderResponse struct for response
+type BulkOrderResponse struct {
+	Code    int               `json:"code,omitempty" example:"400"`
+	Error   string            `json:"error,omitempty" example:"some days can't be ordered"`
+	Results []BulkOrderResult `json:"results"`
+}
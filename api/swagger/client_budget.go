@@ -0,0 +1,18 @@
+package swagger
+
+// UpdateBudget struct for request scheme
+type UpdateBudget struct {
+	MonthlyBudget    *float32 `json:"monthlyBudget" example:"50000"`
+	UserMonthlyLimit *float32 `json:"userMonthlyLimit" example:"3000"`
+	UserDailyLimit   *float32 `json:"userDailyLimit" example:"200"`
+}
+
+// ClientBudget struct for response
+type ClientBudget struct {
+	MonthlyBudget    *float32 `json:"monthlyBudget" example:"50000"`
+	UserMonthlyLimit *float32 `json:"userMonthlyLimit" example:"3000"`
+	UserDailyLimit   *float32 `json:"userDailyLimit" example:"200"`
+	Month            string   `json:"month" example:"2020-06"`
+	Spent            float32  `json:"spent" example:"12500.5"`
+	Remaining        *float32 `json:"remaining" example:"37499.5"`
+}
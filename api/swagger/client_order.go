@@ -4,12 +4,18 @@ import uuid "github.com/satori/go.uuid"
 
 // SummaryUserOrder struct
 type SummaryUserOrder struct {
-	ID      uuid.UUID           `json:"-"`
-	Name    string              `json:"name" gorm:"column:full_name"`
-	Floor   int                 `json:"floor"`
-	Items   []ItemsSummaryOrder `json:"items"`
-	Comment string              `json:"comment"`
-	Total   float32             `json:"total"`
+	ID            uuid.UUID           `json:"-"`
+	OrderID       uuid.UUID           `json:"orderId" gorm:"column:order_id"`
+	Status        string              `json:"status"`
+	Reason        *string             `json:"reason"`
+	Name          string              `json:"name" gorm:"column:full_name"`
+	Floor         int                 `json:"floor"`
+	Items         []ItemsSummaryOrder `json:"items"`
+	Comment       string              `json:"comment"`
+	Total         float32             `json:"total"`
+	CompanyShare  float32             `json:"companyShare"`
+	EmployeeShare float32             `json:"employeeShare"`
+	Nutrition     Nutrition           `json:"nutrition"`
 }
 
 // SummaryOrder struct
@@ -32,10 +38,13 @@ type ItemsSummaryOrder struct {
 
 // SummaryOrderResult struct
 type SummaryOrderResult struct {
-	SummaryOrders []SummaryOrder     `json:"summary"`
-	UserOrders    []SummaryUserOrder `json:"userOrders"`
-	Total         float32            `json:"summaryTotal"`
-	Status        *string            `json:"status"`
+	SummaryOrders []SummaryOrder      `json:"summary"`
+	UserOrders    []SummaryUserOrder  `json:"userOrders"`
+	Total         float32             `json:"summaryTotal"`
+	CompanyTotal  float32             `json:"companyTotal"`
+	EmployeeTotal float32             `json:"employeeTotal"`
+	Status        *string             `json:"status"`
+	Delivery      []OrderStatusChange `json:"delivery"`
 }
 
 // SummaryOrdersResponse struct
@@ -0,0 +1,16 @@
+package swagger
+
+import uuid "github.com/satori/go.uuid"
+
+// SubsidyCategory struct for request and response scheme
+type SubsidyCategory struct {
+	CategoryID uuid.UUID `json:"categoryId"`
+	Amount     float32   `json:"amount" example:"150"`
+}
+
+// ClientSubsidy struct for request and response scheme
+type ClientSubsidy struct {
+	Type       string            `json:"type" example:"fixed"`
+	Amount     float32           `json:"amount" example:"300"`
+	Categories []SubsidyCategory `json:"categories"`
+}
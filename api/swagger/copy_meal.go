@@ -0,0 +1,32 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CopyMeal request scheme
+type CopyMeal struct {
+	Date      time.Time   `json:"date" example:"2020-06-20T00:00:00Z"`
+	Dates     []time.Time `json:"dates" example:"2020-06-21T00:00:00Z"`
+	ClientIDs []string    `json:"clientIds"`
+} // @name CopyMealRequest
+
+// CopiedMeal struct response
+type CopiedMeal struct {
+	ClientID uuid.UUID     `json:"clientId"`
+	Date     time.Time     `json:"date" example:"2020-06-21T00:00:00Z"`
+	MealID   *uuid.UUID    `json:"mealId"`
+	Version  string        `json:"version" example:"V.2"`
+	Dishes   int           `json:"dishes" example:"5"`
+	Skipped  []SkippedDish `json:"skipped"`
+	Error    string        `json:"error,omitempty" example:"can't set less portions than already ordered (2)"`
+} // @name CopiedMealResponse
+
+// SkippedDish struct response
+type SkippedDish struct {
+	DishID uuid.UUID `json:"dishId"`
+	Name   string    `json:"name" example:"борщ"`
+	Reason string    `json:"reason" example:"category deleted"`
+}
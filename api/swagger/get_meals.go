@@ -8,9 +8,17 @@ import (
 
 // GetMeal struct response
 type GetMeal struct {
-	Version string        `json:"version"`
-	MealID  uuid.UUID     `json:"mealId"`
-	Date    string        `json:"date"`
-	Person  string        `json:"person"`
-	Result  []domain.Dish `json:"dishes"`
+	Version string     `json:"version"`
+	MealID  uuid.UUID  `json:"mealId"`
+	Date    string     `json:"date"`
+	Person  string     `json:"person"`
+	Result  []MealDish `json:"dishes"`
 } //@name GetMealsResponse
+
+// MealDish struct response
+type MealDish struct {
+	domain.Dish
+	Portions  *int `json:"portions" example:"20"`
+	Remaining *int `json:"remaining" example:"5"`
+	SoldOut   bool `json:"soldOut"`
+}
@@ -2,5 +2,7 @@ package swagger
 
 // OrderStatus struct
 type OrderStatus struct {
-	Status string `json:"status"`
+	Status string         `json:"status"`
+	Counts map[string]int `json:"counts"`
+	Cutoff string         `json:"cutoff" example:"2020-06-19T16:45:00+03:00"`
 }
@@ -0,0 +1,58 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CreateInvoice struct for request scheme
+type CreateInvoice struct {
+	From     string  `json:"from" example:"2020-06-01T00:00:00Z"`
+	To       string  `json:"to" example:"2020-06-30T00:00:00Z"`
+	Discount float32 `json:"discount" example:"500"`
+}
+
+// UpdateInvoice struct for request scheme
+type UpdateInvoice struct {
+	Status   *string  `json:"status" example:"issued"`
+	Discount *float32 `json:"discount" example:"500"`
+}
+
+// InvoiceItem struct for response
+type InvoiceItem struct {
+	ID     uuid.UUID `json:"id"`
+	Date   time.Time `json:"date"`
+	DishID uuid.UUID `json:"dishId"`
+	Name   string    `json:"name" example:"доширак"`
+	Price  float32   `json:"price" example:"120"`
+	Amount int       `json:"amount" example:"15"`
+	Total  float32   `json:"total" example:"1800"`
+}
+
+// Invoice struct for response
+type Invoice struct {
+	ID            uuid.UUID     `json:"id"`
+	CateringID    uuid.UUID     `json:"cateringId"`
+	ClientID      uuid.UUID     `json:"clientId"`
+	ClientName    string        `json:"clientName" example:"Dymi"`
+	Number        *int          `json:"number" example:"12"`
+	From          time.Time     `json:"from"`
+	To            time.Time     `json:"to"`
+	Status        string        `json:"status" example:"draft"`
+	Subtotal      float32       `json:"subtotal" example:"25000"`
+	Discount      float32       `json:"discount" example:"500"`
+	Total         float32       `json:"total" example:"24500"`
+	CompanyShare  float32       `json:"companyShare" example:"20000"`
+	EmployeeShare float32       `json:"employeeShare" example:"5000"`
+	IssuedAt      *time.Time    `json:"issuedAt"`
+	PaidAt        *time.Time    `json:"paidAt"`
+	Items         []InvoiceItem `json:"items"`
+}
+
+// GetInvoices struct for response
+type GetInvoices struct {
+	Items []Invoice `json:"items"`
+	Page  int       `json:"page" example:"1"`
+	Total int       `json:"total" example:"3"`
+}
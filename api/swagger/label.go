@@ -0,0 +1,36 @@
+package swagger
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// AddLabel request scheme
+type AddLabel struct {
+	Name string `json:"name" example:"celery"`
+	Type string `json:"type" example:"allergen"`
+} // @name AddLabelRequest
+
+// UpdateDietaryProfile request scheme
+type UpdateDietaryProfile struct {
+	LabelIDs []uuid.UUID `json:"labelIds"`
+}
+
+// DietaryProfile struct for response
+type DietaryProfile struct {
+	Labels []Label `json:"labels"`
+}
+
+// Label struct for response
+type Label struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name" example:"vegan"`
+	Type string    `json:"type" example:"dietary"`
+}
+
+// DietaryWarning struct for response
+type DietaryWarning struct {
+	DishID      uuid.UUID `json:"dishId"`
+	Name        string    `json:"name" example:"доширак"`
+	Allergens   []string  `json:"allergens" example:"gluten"`
+	MissingTags []string  `json:"missingTags" example:"vegan"`
+}
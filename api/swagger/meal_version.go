@@ -0,0 +1,37 @@
+package swagger
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// RollbackMeal request scheme
+type RollbackMeal struct {
+	MealID  string `json:"mealId"`
+	Version string `json:"version" example:"V.1"`
+} // @name RollbackMealRequest
+
+// MealVersionDish struct response
+type MealVersionDish struct {
+	DishID     uuid.UUID `json:"dishId"`
+	Name       string    `json:"name" example:"борщ"`
+	CategoryID uuid.UUID `json:"categoryId"`
+	Price      float32   `json:"price" example:"120"`
+}
+
+// MealPriceChange struct response
+type MealPriceChange struct {
+	DishID   uuid.UUID `json:"dishId"`
+	Name     string    `json:"name" example:"борщ"`
+	OldPrice float32   `json:"oldPrice" example:"120"`
+	NewPrice float32   `json:"newPrice" example:"130"`
+}
+
+// MealDiff struct response
+type MealDiff struct {
+	MealID       uuid.UUID         `json:"mealId"`
+	From         string            `json:"from" example:"V.1"`
+	To           string            `json:"to" example:"V.2"`
+	Added        []MealVersionDish `json:"added"`
+	Removed      []MealVersionDish `json:"removed"`
+	PriceChanges []MealPriceChange `json:"priceChanges"`
+} // @name MealDiffResponse
@@ -0,0 +1,59 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplateItem struct for request scheme
+type MenuTemplateItem struct {
+	Day        int        `json:"day" example:"0"`
+	ClientID   uuid.UUID  `json:"clientId"`
+	DishID     *uuid.UUID `json:"dishId"`
+	CategoryID *uuid.UUID `json:"categoryId"`
+}
+
+// MenuTemplateRequest struct for request scheme
+type MenuTemplateRequest struct {
+	Name      string             `json:"name" example:"Весенняя ротация"`
+	StartDate time.Time          `json:"startDate" example:"2020-06-01T00:00:00Z"`
+	Length    int                `json:"length" example:"14"`
+	Enabled   bool               `json:"enabled"`
+	Items     []MenuTemplateItem `json:"items"`
+}
+
+// MenuTemplateOverride struct for request and response scheme
+type MenuTemplateOverride struct {
+	ClientID uuid.UUID   `json:"clientId"`
+	Date     time.Time   `json:"date" example:"2020-06-03T00:00:00Z"`
+	Dishes   []uuid.UUID `json:"dishes"`
+}
+
+// MenuTemplate struct for response
+type MenuTemplate struct {
+	ID        uuid.UUID              `json:"id"`
+	Name      string                 `json:"name" example:"Весенняя ротация"`
+	StartDate time.Time              `json:"startDate" example:"2020-06-01T00:00:00Z"`
+	Length    int                    `json:"length" example:"14"`
+	Enabled   bool                   `json:"enabled"`
+	Items     []MenuTemplateItem     `json:"items"`
+	Overrides []MenuTemplateOverride `json:"overrides"`
+}
+
+// MenuTemplateDish struct for response
+type MenuTemplateDish struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name" example:"борщ"`
+	CategoryID uuid.UUID `json:"categoryId"`
+}
+
+// MenuTemplateDay struct for response
+type MenuTemplateDay struct {
+	Date      time.Time          `json:"date" example:"2020-06-03T00:00:00Z"`
+	ClientID  uuid.UUID          `json:"clientId"`
+	Day       int                `json:"day" example:"2"`
+	Override  bool               `json:"override"`
+	Published bool               `json:"published"`
+	Dishes    []MenuTemplateDish `json:"dishes"`
+}
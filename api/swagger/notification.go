@@ -0,0 +1,33 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Notification struct for response
+type Notification struct {
+	ID        uuid.UUID  `json:"id"`
+	OrderID   uuid.UUID  `json:"orderId"`
+	Date      time.Time  `json:"date" example:"2020-06-25T00:00:00Z"`
+	From      string     `json:"from" example:"pending"`
+	To        string     `json:"to" example:"approved"`
+	Title     string     `json:"title" example:"Заказ подтвержден"`
+	Message   string     `json:"message" example:"Заказ подтвержден, заказ на 25.06.2020"`
+	CreatedAt time.Time  `json:"createdAt" example:"2020-06-24T18:00:00Z"`
+	ReadAt    *time.Time `json:"readAt"`
+}
+
+// Notifications struct for response
+type Notifications struct {
+	Items  []Notification `json:"items"`
+	Page   int            `json:"page" example:"1"`
+	Total  int            `json:"total" example:"12"`
+	Unread int            `json:"unread" example:"3"`
+}
+
+// ReadNotifications struct for request scheme
+type ReadNotifications struct {
+	IDs []uuid.UUID `json:"ids"`
+}
@@ -0,0 +1,9 @@
+package swagger
+
+// Nutrition struct for response
+type Nutrition struct {
+	Kcal    float32 `json:"kcal" example:"450"`
+	Protein float32 `json:"protein" example:"22.5"`
+	Fat     float32 `json:"fat" example:"14"`
+	Carbs   float32 `json:"carbs" example:"58"`
+}
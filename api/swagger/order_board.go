@@ -0,0 +1,41 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderBoardOrder struct for response
+type OrderBoardOrder struct {
+	OrderID       uuid.UUID `json:"orderId"`
+	UserID        uuid.UUID `json:"userId"`
+	ClientID      uuid.UUID `json:"clientId"`
+	CateringID    uuid.UUID `json:"cateringId"`
+	Date          time.Time `json:"date" example:"2020-06-25T00:00:00Z"`
+	Status        string    `json:"status" example:"approved"`
+	Total         float32   `json:"total" example:"12.5"`
+	CompanyShare  float32   `json:"companyShare" example:"10"`
+	EmployeeShare float32   `json:"employeeShare" example:"2.5"`
+}
+
+// OrderBoardDish struct for response
+type OrderBoardDish struct {
+	DishID uuid.UUID `json:"dishId"`
+	Name   string    `json:"name" example:"доширак"`
+	Amount int       `json:"amount" example:"14"`
+}
+
+// OrderBoardData struct for response
+type OrderBoardData struct {
+	Order  OrderBoardOrder  `json:"order"`
+	Dishes []OrderBoardDish `json:"dishes"`
+}
+
+// OrderBoardEvent struct for response
+type OrderBoardEvent struct {
+	Type       string         `json:"type" example:"order.created"`
+	CateringID uuid.UUID      `json:"cateringId"`
+	ClientID   uuid.UUID      `json:"clientId"`
+	Data       OrderBoardData `json:"data"`
+}
@@ -0,0 +1,10 @@
+package swagger
+
+import uuid "github.com/satori/go.uuid"
+
+// OrderError struct for response
+type OrderError struct {
+	Code    int         `json:"code" example:"400"`
+	Error   string      `json:"error" example:"dishes are not in the menu for that day"`
+	DishIDs []uuid.UUID `json:"dishIds"`
+}
@@ -0,0 +1,39 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// UserOrderHistory struct for response
+type UserOrderHistory struct {
+	Items         []OrderItem `json:"items"`
+	Status        string      `json:"status"`
+	Reason        *string     `json:"reason"`
+	Total         float32     `json:"total"`
+	CompanyShare  float32     `json:"companyShare"`
+	EmployeeShare float32     `json:"employeeShare"`
+	OrderID       uuid.UUID   `json:"orderId"`
+	Date          time.Time   `json:"date"`
+	Comment       string      `json:"comment"`
+	Nutrition     Nutrition   `json:"nutrition"`
+}
+
+// OrderHistoryPeriod struct for response
+type OrderHistoryPeriod struct {
+	Period        string    `json:"period" example:"2020-06-01"`
+	Orders        int       `json:"orders" example:"12"`
+	Total         float32   `json:"total" example:"1450"`
+	CompanyShare  float32   `json:"companyShare" example:"1200"`
+	EmployeeShare float32   `json:"employeeShare" example:"250"`
+	Nutrition     Nutrition `json:"nutrition"`
+}
+
+// OrderHistory struct for response
+type OrderHistory struct {
+	Items   []UserOrderHistory   `json:"items"`
+	Periods []OrderHistoryPeriod `json:"periods"`
+	Page    int                  `json:"page" example:"1"`
+	Total   int                  `json:"total" example:"20"`
+}
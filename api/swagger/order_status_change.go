@@ -0,0 +1,18 @@
+package swagger
+
+import "time"
+
+// UpdateDeliveryStatus request scheme
+type UpdateDeliveryStatus struct {
+	Status string `json:"status" example:"failed"`
+	Note   string `json:"note" example:"courier didn't find the office"`
+}
+
+// OrderStatusChange struct for response
+type OrderStatusChange struct {
+	From      string    `json:"from" example:"preparing"`
+	To        string    `json:"to" example:"out_for_delivery"`
+	Note      string    `json:"note"`
+	UserName  string    `json:"userName"`
+	ChangedAt time.Time `json:"changedAt"`
+}
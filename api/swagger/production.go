@@ -0,0 +1,55 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProductionClientAmount struct for response
+type ProductionClientAmount struct {
+	ClientID   uuid.UUID `json:"clientId"`
+	ClientName string    `json:"clientName" example:"Dymi"`
+	Amount     int       `json:"amount" example:"12"`
+}
+
+// ProductionDish struct for response
+type ProductionDish struct {
+	DishID  uuid.UUID                `json:"dishId"`
+	Name    string                   `json:"name" example:"доширак"`
+	Amount  int                      `json:"amount" example:"30"`
+	Clients []ProductionClientAmount `json:"clients"`
+}
+
+// ProductionCategory struct for response
+type ProductionCategory struct {
+	CategoryID   uuid.UUID        `json:"categoryId"`
+	CategoryName string           `json:"categoryName" example:"гарнир"`
+	Amount       int              `json:"amount" example:"45"`
+	Dishes       []ProductionDish `json:"dishes"`
+}
+
+// ProductionFloor struct for response
+type ProductionFloor struct {
+	Floor    int     `json:"floor" example:"3"`
+	Address  *string `json:"address" example:"Minsk, Nemiga, 5"`
+	Orders   int     `json:"orders" example:"8"`
+	Portions int     `json:"portions" example:"20"`
+}
+
+// ProductionClient struct for response
+type ProductionClient struct {
+	ClientID   uuid.UUID         `json:"clientId"`
+	ClientName string            `json:"clientName" example:"Dymi"`
+	Orders     int               `json:"orders" example:"15"`
+	Portions   int               `json:"portions" example:"40"`
+	Floors     []ProductionFloor `json:"floors"`
+}
+
+// ProductionSheet struct for response
+type ProductionSheet struct {
+	Date       time.Time            `json:"date"`
+	Portions   int                  `json:"portions" example:"120"`
+	Categories []ProductionCategory `json:"categories"`
+	Clients    []ProductionClient   `json:"clients"`
+}
@@ -0,0 +1,48 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// UpdateReminder struct for request scheme
+type UpdateReminder struct {
+	Enabled bool `json:"enabled" example:"true"`
+	Offset  int  `json:"offset" example:"60"`
+}
+
+// ClientReminder struct for response
+type ClientReminder struct {
+	Enabled  bool       `json:"enabled" example:"true"`
+	Offset   int        `json:"offset" example:"60"`
+	LastDate *time.Time `json:"lastDate" example:"2020-06-25T00:00:00Z"`
+}
+
+// UpdateUserReminder struct for request scheme
+type UpdateUserReminder struct {
+	Enabled bool `json:"enabled" example:"false"`
+}
+
+// UserReminder struct for response
+type UserReminder struct {
+	Enabled bool `json:"enabled" example:"false"`
+}
+
+// ReminderRecipient struct for response
+type ReminderRecipient struct {
+	UserID    uuid.UUID `json:"userId"`
+	FirstName string    `json:"firstName" example:"Ivan"`
+	LastName  string    `json:"lastName" example:"Ivanov"`
+	Email     string    `json:"email" example:"ivanov@example.com"`
+	Floor     int       `json:"floor" example:"3"`
+}
+
+// ReminderPreview struct for response
+type ReminderPreview struct {
+	Enabled    bool                `json:"enabled" example:"true"`
+	Date       time.Time           `json:"date" example:"2020-06-25T00:00:00Z"`
+	Cutoff     time.Time           `json:"cutoff" example:"2020-06-24T18:00:00Z"`
+	SendAt     time.Time           `json:"sendAt" example:"2020-06-24T17:00:00Z"`
+	Recipients []ReminderRecipient `json:"recipients"`
+}
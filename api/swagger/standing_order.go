@@ -0,0 +1,41 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrderItem struct for request scheme
+type StandingOrderItem struct {
+	DishID     *uuid.UUID `json:"dishId"`
+	CategoryID *uuid.UUID `json:"categoryId"`
+	Amount     int        `json:"amount" example:"1"`
+}
+
+// StandingOrderRequest struct for request scheme
+type StandingOrderRequest struct {
+	Weekdays []int               `json:"weekdays" example:"0,1,2,3,4"`
+	EndDate  *time.Time          `json:"endDate"`
+	Paused   bool                `json:"paused"`
+	Comment  string              `json:"comment"`
+	Items    []StandingOrderItem `json:"items"`
+}
+
+// StandingOrderFailure struct for response
+type StandingOrderFailure struct {
+	Date   time.Time `json:"date"`
+	Reason string    `json:"reason" example:"no dishes of standing order in published menu"`
+}
+
+// StandingOrder struct for response
+type StandingOrder struct {
+	ID       uuid.UUID              `json:"id"`
+	Weekdays []int                  `json:"weekdays" example:"0,1,2,3,4"`
+	EndDate  *time.Time             `json:"endDate"`
+	Paused   bool                   `json:"paused"`
+	Comment  string                 `json:"comment"`
+	Items    []StandingOrderItem    `json:"items"`
+	Skips    []time.Time            `json:"skips"`
+	Failures []StandingOrderFailure `json:"failures"`
+}
@@ -0,0 +1,10 @@
+package swagger
+
+import uuid "github.com/satori/go.uuid"
+
+// UpdateOrdersStatus request scheme
+type UpdateOrdersStatus struct {
+	OrderIDs []uuid.UUID `json:"orderIds"`
+	Status   string      `json:"status" example:"rejected"`
+	Reason   string      `json:"reason" example:"exceeds the allowance"`
+}
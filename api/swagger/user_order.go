@@ -6,17 +6,39 @@ import (
 
 // UserOrder struct for response
 type UserOrder struct {
-	Items   []OrderItem `json:"items"`
-	Status  string      `json:"status"`
-	Total   int         `json:"total"`
-	OrderID uuid.UUID   `json:"orderId" gorm:"type:column:order_id"`
+	Items         []OrderItem      `json:"items"`
+	Status        string           `json:"status"`
+	Reason        *string          `json:"reason" example:"exceeds the allowance"`
+	Total         int              `json:"total"`
+	CompanyShare  float32          `json:"companyShare" example:"10"`
+	EmployeeShare float32          `json:"employeeShare" example:"2.5"`
+	OrderID       uuid.UUID        `json:"orderId" gorm:"type:column:order_id"`
+	Nutrition     Nutrition        `json:"nutrition"`
+	Warnings      []DietaryWarning `json:"warnings"`
 }
 
 // OrderItem struct for response
+// nutrition facts are facts of one portion
 type OrderItem struct {
 	ID     uuid.UUID `json:"id" gorm:"column:dish_id"`
 	Image  *string   `json:"image" gorm:"column:path"`
 	Price  int       `json:"price"`
 	Name   string    `json:"name"`
 	Amount int       `json:"amount"`
+	Nutrition
+}
+
+// OrderChange struct for response
+type OrderChange struct {
+	Field  string     `json:"field" example:"dish"`
+	DishID *uuid.UUID `json:"dishId,omitempty"`
+	Name   string     `json:"name,omitempty"`
+	Before string     `json:"before" example:"1"`
+	After  string     `json:"after" example:"2"`
+}
+
+// UpdatedUserOrder struct for response
+type UpdatedUserOrder struct {
+	UserOrder
+	Changes []OrderChange `json:"changes"`
 }
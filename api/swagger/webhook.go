@@ -0,0 +1,46 @@
+package swagger
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// WebhookRequest struct for request scheme
+type WebhookRequest struct {
+	URL    string   `json:"url" example:"https://example.com/hooks/meals"`
+	Secret string   `json:"secret" example:"a3f1c9e07b2d4e5f"`
+	Events []string `json:"events" example:"order.created,order.canceled"`
+	Active *bool    `json:"active" example:"true"`
+}
+
+// Webhook struct for response
+type Webhook struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url" example:"https://example.com/hooks/meals"`
+	Events    []string  `json:"events" example:"order.created,order.canceled"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"createdAt" example:"2020-06-24T18:00:00Z"`
+}
+
+// WebhookDelivery struct for response
+type WebhookDelivery struct {
+	ID            uuid.UUID  `json:"id"`
+	WebhookID     uuid.UUID  `json:"webhookId"`
+	Event         string     `json:"event" example:"order.created"`
+	Payload       string     `json:"payload"`
+	Status        string     `json:"status" example:"delivered"`
+	Attempts      int        `json:"attempts" example:"1"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt"`
+	ResponseCode  *int       `json:"responseCode" example:"200"`
+	Error         *string    `json:"error"`
+	DeliveredAt   *time.Time `json:"deliveredAt"`
+	CreatedAt     time.Time  `json:"createdAt" example:"2020-06-24T18:00:00Z"`
+}
+
+// WebhookDeliveries struct for response
+type WebhookDeliveries struct {
+	Items []WebhookDelivery `json:"items"`
+	Page  int               `json:"page" example:"1"`
+	Total int               `json:"total" example:"12"`
+}
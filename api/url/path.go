@@ -48,6 +48,12 @@ type PathDishGet struct {
 	CategoryID string `uri:"categoryID" json:"categoryID" binding:"required"`
 }
 
+// PathLabel struct for path binding
+type PathLabel struct {
+	ID      string `uri:"id" json:"id" binding:"required"`
+	LabelID string `uri:"labelId" json:"labelId" binding:"required"`
+}
+
 // PathImageDish struct for path binding
 type PathImageDish struct {
 	CateringID string `uri:"id" json:"id" binding:"required"`
@@ -72,3 +78,52 @@ type PathOrder struct {
 	ID      string `uri:"id" json:"id" binding:"required"`
 	OrderID string `uri:"orderId" json:"orderId" binding:"required"`
 }
+
+// PathMealDish struct for path binding
+type PathMealDish struct {
+	ID       string `uri:"id" json:"id" binding:"required"`
+	ClientID string `uri:"clientId" json:"clientId" binding:"required"`
+	MealID   string `uri:"mealId" json:"mealId" binding:"required"`
+	DishID   string `uri:"dishId" json:"dishId" binding:"required"`
+}
+
+// PathStandingOrder struct for path binding
+type PathStandingOrder struct {
+	ID              string `uri:"id" json:"id" binding:"required"`
+	StandingOrderID string `uri:"standingOrderId" json:"standingOrderId" binding:"required"`
+}
+
+// PathInvoice struct for path binding
+type PathInvoice struct {
+	ID        string `uri:"id" json:"id" binding:"required"`
+	ClientID  string `uri:"clientId" json:"clientId" binding:"required"`
+	InvoiceID string `uri:"invoiceId" json:"invoiceId" binding:"required"`
+}
+
+// PathWebhook struct for path binding
+type PathWebhook struct {
+	ID        string `uri:"id" json:"id" binding:"required"`
+	WebhookID string `uri:"webhookId" json:"webhookId" binding:"required"`
+}
+
+// PathWebhookDelivery struct for path binding
+type PathWebhookDelivery struct {
+	ID         string `uri:"id" json:"id" binding:"required"`
+	WebhookID  string `uri:"webhookId" json:"webhookId" binding:"required"`
+	DeliveryID string `uri:"deliveryId" json:"deliveryId" binding:"required"`
+}
+
+// PathMenuTemplate struct for path binding
+type PathMenuTemplate struct {
+	ID             string `uri:"id" json:"id" binding:"required"`
+	MenuTemplateID string `uri:"menuTemplateId" json:"menuTemplateId" binding:"required"`
+}
+
+// PathMealVersionDiff struct for path binding
+type PathMealVersionDiff struct {
+	ID       string `uri:"id" json:"id" binding:"required"`
+	ClientID string `uri:"clientId" json:"clientId" binding:"required"`
+	MealID   string `uri:"mealId" json:"mealId" binding:"required"`
+	A        string `uri:"a" json:"a" binding:"required"`
+	B        string `uri:"b" json:"b" binding:"required"`
+}
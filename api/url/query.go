@@ -28,9 +28,73 @@ type CategoryIDQuery struct {
 	CategoryID string `form:"categoryID" binding:"required"`
 }
 
+// LabelTypeQuery struct used for binding type of labels
+type LabelTypeQuery struct {
+	Type string `form:"type" binding:"omitempty,oneof=allergen dietary"`
+}
+
+// LabelFilterQuery struct used for binding label filters of dishes,
+// both are comma separated label names, dish is returned when it
+// has none of excluded allergens and all of tags
+type LabelFilterQuery struct {
+	ExcludeAllergens string `form:"exclude_allergens"`
+	Tags             string `form:"tags"`
+}
+
 // UserFilterQuery used to filter and sort users in DB
 type UserFilterQuery struct {
 	Query  string `form:"q"`
 	Role   string `form:"role"`
 	Status string `form:"status"`
 }
+
+// DateRangeQuery struct used for binding range of dates
+type DateRangeQuery struct {
+	From string `form:"from" binding:"required"`
+	To   string `form:"to" binding:"required"`
+}
+
+// OrderHistoryQuery struct used for binding order history filters,
+// orders are grouped for totals by day, week or month
+type OrderHistoryQuery struct {
+	From    string   `form:"from" binding:"required"`
+	To      string   `form:"to" binding:"required"`
+	Status  []string `form:"status"`
+	GroupBy string   `form:"groupBy"`
+}
+
+// FileFormatQuery struct used for binding format of rendered file
+type FileFormatQuery struct {
+	Format string `form:"format"`
+}
+
+// OrderExportQuery struct used for binding order export,
+// date exports one day, from and to export range of days
+type OrderExportQuery struct {
+	Date   string `form:"date"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+	Format string `form:"format"`
+}
+
+// NotificationQuery struct used for binding notifications filter
+type NotificationQuery struct {
+	Unread bool `form:"unread"`
+}
+
+// AuditQuery struct used for binding filters of audit events,
+// dates are inclusive and all filters are optional
+type AuditQuery struct {
+	Entity   string `form:"entity"`
+	EntityID string `form:"entityId"`
+	ActorID  string `form:"actorId"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+}
+
+// MenuTemplateOverrideQuery struct used for binding
+// date and client of menu template override
+type MenuTemplateOverrideQuery struct {
+	Date     string `form:"date" binding:"required"`
+	ClientID string `form:"clientId" binding:"required"`
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Webhook struct
+type Webhook struct{}
+
+// NewWebhook return pointer to webhook struct
+// with all methods
+func NewWebhook() *Webhook {
+	return &Webhook{}
+}
+
+var webhookService = services.NewWebhookService()
+
+// pathCompanyType returns type of company which resources are requested,
+// the same handlers serve catering and client routes
+func pathCompanyType(c *gin.Context) string {
+	if strings.HasPrefix(c.FullPath(), "/caterings/") {
+		return enums.CompanyTypesEnum.Catering
+	}
+
+	return enums.CompanyTypesEnum.Client
+}
+
+// Add creates webhook
+// @Summary Creates webhook of catering or client, payloads are signed with secret
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Catering or Client ID"
+// @Param body body swagger.WebhookRequest false "Webhook"
+// @Success 201 {object} swagger.Webhook false "Webhook"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/webhooks [post]
+// @Router /clients/{id}/webhooks [post]
+func (w Webhook) Add(c *gin.Context) {
+	var path url.PathID
+	var body models.WebhookRequest
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := webhookService.Add(pathCompanyType(c), path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Get returns webhooks
+// @Summary Returns list of webhooks of catering or client
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Catering or Client ID"
+// @Success 200 {array} swagger.Webhook "Webhooks"
+// @Failure 400 {object} Error "Error"
+// @Router /caterings/{id}/webhooks [get]
+// @Router /clients/{id}/webhooks [get]
+func (w Webhook) Get(c *gin.Context) {
+	var path url.PathID
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := webhookService.Get(pathCompanyType(c), path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Update updates webhook
+// @Summary Updates webhook, secret is kept if it's empty
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Catering or Client ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param body body swagger.WebhookRequest false "Webhook"
+// @Success 200 {object} swagger.Webhook false "Webhook"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/webhooks/{webhookId} [put]
+// @Router /clients/{id}/webhooks/{webhookId} [put]
+func (w Webhook) Update(c *gin.Context) {
+	var path url.PathWebhook
+	var body models.WebhookRequest
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderBody(&body, c); err != nil {
+		return
+	}
+
+	result, code, err := webhookService.Update(pathCompanyType(c), path, body)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete deletes webhook
+// @Summary Deletes webhook with its deliveries
+// @Tags webhooks
+// @Param id path string true "Catering or Client ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 204 "Successfully deleted"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/webhooks/{webhookId} [delete]
+// @Router /clients/{id}/webhooks/{webhookId} [delete]
+func (w Webhook) Delete(c *gin.Context) {
+	var path url.PathWebhook
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	code, err := webhookService.Delete(pathCompanyType(c), path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeliveries returns deliveries of webhook
+// @Summary Returns page of deliveries of webhook from the newest one
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Catering or Client ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param limit query int false "used for pagination"
+// @Param page query int false "used for pagination"
+// @Success 200 {object} swagger.WebhookDeliveries false "Deliveries"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/webhooks/{webhookId}/deliveries [get]
+// @Router /clients/{id}/webhooks/{webhookId}/deliveries [get]
+func (w Webhook) GetDeliveries(c *gin.Context) {
+	var path url.PathWebhook
+	var query url.PaginationQuery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	if err := utils.RequestBinderQuery(&query, c); err != nil {
+		return
+	}
+
+	result, code, err := webhookService.GetDeliveries(pathCompanyType(c), path, query)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Redeliver sends delivery again
+// @Summary Sends delivery of webhook again and returns its result
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Catering or Client ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} swagger.WebhookDelivery false "Delivery"
+// @Failure 400 {object} Error "Error"
+// @Failure 404 {object} Error "Not Found"
+// @Router /caterings/{id}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+// @Router /clients/{id}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func (w Webhook) Redeliver(c *gin.Context) {
+	var path url.PathWebhookDelivery
+
+	if err := utils.RequestBinderURI(&path, c); err != nil {
+		return
+	}
+
+	result, code, err := webhookService.Redeliver(pathCompanyType(c), path)
+
+	if err != nil {
+		utils.CreateError(code, err, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
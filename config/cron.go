@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/robfig/cron"
 )
 
@@ -11,5 +13,5 @@ var CRON struct {
 }
 
 func init() {
-	CRON.Cron = cron.New()
+	CRON.Cron = cron.NewWithLocation(time.UTC)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/Aiscom-LLC/meals-api/db/seeds/dev"
 	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/utils"
 	"github.com/jinzhu/gorm"
 	"gopkg.in/gormigrate.v1"
 )
@@ -35,7 +36,7 @@ func main() {
 }
 
 func migrate() {
-	m := gormigrate.New(config.DB, gormigrate.DefaultOptions, []*gormigrate.Migration{})
+	m := gormigrate.New(config.DB, gormigrate.DefaultOptions, migrations())
 
 	m.InitSchema(func(tx *gorm.DB) error {
 		err := tx.AutoMigrate(
@@ -57,6 +58,32 @@ func migrate() {
 			&domain.Order{},
 			&domain.OrderDishes{},
 			&domain.UserOrders{},
+			&domain.OrderChange{},
+			&domain.MealDishPortion{},
+			&domain.StandingOrder{},
+			&domain.StandingOrderItem{},
+			&domain.StandingOrderSkip{},
+			&domain.StandingOrderPlacement{},
+			&domain.OrderStatusChange{},
+			&domain.ClientSubsidy{},
+			&domain.ClientSubsidyCategory{},
+			&domain.ClientBudget{},
+			&domain.Invoice{},
+			&domain.InvoiceItem{},
+			&domain.ClientReminder{},
+			&domain.ReminderOptOut{},
+			&domain.ReminderDelivery{},
+			&domain.Notification{},
+			&domain.Webhook{},
+			&domain.WebhookDelivery{},
+			&domain.AuditEvent{},
+			&domain.Label{},
+			&domain.DishLabel{},
+			&domain.UserLabel{},
+			&domain.MenuTemplate{},
+			&domain.MenuTemplateItem{},
+			&domain.MenuTemplateOverride{},
+			&domain.MenuTemplatePlacement{},
 		)
 		if err != nil {
 			return err.Error
@@ -77,8 +104,252 @@ func migrate() {
 
 }
 
+// labelsUniqueNameIndex keeps names of labels unique
+// within type of catering regardless of case
+const labelsUniqueNameIndex = "CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_catering_type_name" +
+	" ON labels (catering_id, type, lower(name)) WHERE deleted_at IS NULL"
+
+// migrations returns changes for databases
+// created before InitSchema got the same columns
+func migrations() []*gormigrate.Migration {
+	return []*gormigrate.Migration{
+		{
+			ID: "order_dishes_snapshot",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&domain.OrderDishes{}).Error; err != nil {
+					return err
+				}
+
+				return tx.Exec("UPDATE order_dishes od SET name = d.name, price = d.price," +
+					" category_id = d.category_id, category_name = c.name" +
+					" FROM dishes d LEFT JOIN categories c ON c.id = d.category_id" +
+					" WHERE od.dish_id = d.id AND od.name IS NULL").Error
+			},
+		},
+		{
+			ID: "order_changes",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.OrderChange{}).Error
+			},
+		},
+		{
+			ID: "meal_dish_portions",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.MealDishPortion{}).Error
+			},
+		},
+		{
+			ID: "standing_orders",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(
+					&domain.StandingOrder{},
+					&domain.StandingOrderItem{},
+					&domain.StandingOrderSkip{},
+					&domain.StandingOrderPlacement{},
+				).Error
+			},
+		},
+		{
+			ID: "order_reason",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.Order{}).Error
+			},
+		},
+		{
+			ID: "order_status_changes",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.OrderStatusChange{}).Error
+			},
+		},
+		{
+			ID: "order_shares",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(
+					&domain.Order{},
+					&domain.ClientSubsidy{},
+					&domain.ClientSubsidyCategory{},
+				).Error; err != nil {
+					return err
+				}
+
+				// clients don't have subsidies yet, so as for orders created
+				// without subsidy of client company pays in full
+				return tx.Exec("UPDATE orders SET company_share = coalesce(total, 0), employee_share = 0" +
+					" WHERE company_share IS NULL OR employee_share IS NULL").Error
+			},
+		},
+		{
+			ID: "client_budgets",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.ClientBudget{}).Error
+			},
+		},
+		{
+			ID: "invoices",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.Invoice{}, &domain.InvoiceItem{}).Error
+			},
+		},
+		{
+			ID: "reminders",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.ClientReminder{}, &domain.ReminderOptOut{}).Error
+			},
+		},
+		{
+			ID: "notifications",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.Notification{}).Error
+			},
+		},
+		{
+			ID: "webhooks",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.Webhook{}, &domain.WebhookDelivery{}).Error
+			},
+		},
+		{
+			ID: "audit_events",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.AuditEvent{}).Error
+			},
+		},
+		{
+			ID: "labels",
+			Migrate: func(tx *gorm.DB) error {
+				var caterings []domain.Catering
+
+				if err := tx.AutoMigrate(&domain.Label{}, &domain.DishLabel{}, &domain.UserLabel{}).Error; err != nil {
+					return err
+				}
+
+				if err := tx.Find(&caterings).Error; err != nil {
+					return err
+				}
+
+				for i := range caterings {
+					if err := utils.AddDefaultCateringLabels(tx, caterings[i].ID); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+		},
+		{
+			ID: "nutrition",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.Dish{}, &domain.OrderDishes{}).Error
+			},
+		},
+		{
+			ID: "menu_templates",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(
+					&domain.MenuTemplate{},
+					&domain.MenuTemplateItem{},
+					&domain.MenuTemplateOverride{},
+					&domain.MenuTemplatePlacement{},
+				).Error
+			},
+		},
+		{
+			ID: "meal_dish_prices",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&domain.MealDish{}).Error; err != nil {
+					return err
+				}
+
+				return tx.Exec("UPDATE meal_dishes md SET price = d.price FROM dishes d WHERE d.id = md.dish_id").Error
+			},
+		},
+		{
+			ID: "standing_order_placement_reason",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.StandingOrderPlacement{}).Error
+			},
+		},
+		{
+			ID: "notification_email_lease",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.Notification{}).Error
+			},
+		},
+		{
+			ID: "labels_unique_name",
+			Migrate: func(tx *gorm.DB) error {
+				// labels added twice by migration and seeds are merged
+				// into the oldest one before index is created
+				duplicates := "(SELECT id, first_value(id) OVER (PARTITION BY catering_id, type, lower(name)" +
+					" ORDER BY created_at, id) AS keep_id FROM labels WHERE deleted_at IS NULL) d"
+
+				for _, query := range []string{
+					"UPDATE dish_labels dl SET label_id = d.keep_id FROM " + duplicates +
+						" WHERE d.id = dl.label_id AND d.id != d.keep_id",
+					"UPDATE user_labels ul SET label_id = d.keep_id FROM " + duplicates +
+						" WHERE d.id = ul.label_id AND d.id != d.keep_id",
+					"DELETE FROM dish_labels a USING dish_labels b" +
+						" WHERE a.dish_id = b.dish_id AND a.label_id = b.label_id AND a.id > b.id",
+					"DELETE FROM user_labels a USING user_labels b" +
+						" WHERE a.user_id = b.user_id AND a.label_id = b.label_id AND a.id > b.id",
+					"DELETE FROM labels l USING " + duplicates + " WHERE d.id = l.id AND d.id != d.keep_id",
+					labelsUniqueNameIndex,
+				} {
+					if err := tx.Exec(query).Error; err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+		},
+		{
+			ID: "reminder_deliveries",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&domain.ReminderDelivery{}).Error
+			},
+		},
+		{
+			ID: "notification_email_attempts",
+			Migrate: func(tx *gorm.DB) error {
+				if err := tx.AutoMigrate(&domain.Notification{}).Error; err != nil {
+					return err
+				}
+
+				return tx.Exec("UPDATE notifications SET email_attempts = 0 WHERE email_attempts IS NULL").Error
+			},
+		},
+	}
+}
+
 func drop() {
 	config.DB.DropTableIfExists(
+		&domain.MenuTemplatePlacement{},
+		&domain.MenuTemplateOverride{},
+		&domain.MenuTemplateItem{},
+		&domain.MenuTemplate{},
+		&domain.UserLabel{},
+		&domain.DishLabel{},
+		&domain.Label{},
+		&domain.AuditEvent{},
+		&domain.WebhookDelivery{},
+		&domain.Webhook{},
+		&domain.Notification{},
+		&domain.ReminderDelivery{},
+		&domain.ReminderOptOut{},
+		&domain.ClientReminder{},
+		&domain.InvoiceItem{},
+		&domain.Invoice{},
+		&domain.ClientBudget{},
+		&domain.ClientSubsidyCategory{},
+		&domain.ClientSubsidy{},
+		&domain.OrderStatusChange{},
+		&domain.StandingOrderPlacement{},
+		&domain.StandingOrderSkip{},
+		&domain.StandingOrderItem{},
+		&domain.StandingOrder{},
+		&domain.MealDishPortion{},
+		&domain.OrderChange{},
 		&domain.UserOrders{},
 		&domain.OrderDishes{},
 		&domain.Order{},
@@ -107,6 +378,7 @@ func seeds() {
 
 	dev.CreateCaterings()
 	dev.CreateCateringSchedules()
+	dev.CreateCateringLabels()
 	dev.CreateClients()
 	dev.CreateClientSchedules()
 
@@ -149,6 +421,8 @@ func addDbConstraints() {
 	config.DB.Model(&domain.MealDish{}).AddForeignKey("meal_id", "meals(id)", "CASCADE", "CASCADE")
 	config.DB.Model(&domain.MealDish{}).AddForeignKey("dish_id", "dishes(id)", "CASCADE", "CASCADE")
 
+	config.DB.Model(&domain.MealDishPortion{}).AddForeignKey("dish_id", "dishes(id)", "CASCADE", "CASCADE")
+
 	config.DB.Model(&domain.ImageDish{}).AddForeignKey("dish_id", "dishes(id)", "CASCADE", "CASCADE")
 	config.DB.Model(&domain.ImageDish{}).AddForeignKey("image_id", "images(id)", "CASCADE", "CASCADE")
 
@@ -157,6 +431,57 @@ func addDbConstraints() {
 
 	config.DB.Model(&domain.UserOrders{}).AddForeignKey("order_id", "orders(id)", "CASCADE", "CASCADE")
 	config.DB.Model(&domain.UserOrders{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+
+	config.DB.Model(&domain.OrderChange{}).AddForeignKey("order_id", "orders(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.OrderChange{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+
+	config.DB.Model(&domain.OrderStatusChange{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.OrderStatusChange{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+
+	config.DB.Model(&domain.ClientSubsidy{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.ClientSubsidyCategory{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.ClientSubsidyCategory{}).AddForeignKey("category_id", "categories(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.ClientBudget{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+
+	config.DB.Model(&domain.Invoice{}).AddForeignKey("catering_id", "caterings(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.Invoice{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.InvoiceItem{}).AddForeignKey("invoice_id", "invoices(id)", "CASCADE", "CASCADE")
+
+	config.DB.Model(&domain.ClientReminder{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.ReminderOptOut{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.ReminderDelivery{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+
+	config.DB.Model(&domain.Notification{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.Notification{}).AddForeignKey("order_id", "orders(id)", "CASCADE", "CASCADE")
+
+	config.DB.Model(&domain.Webhook{}).AddForeignKey("catering_id", "caterings(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.Webhook{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.WebhookDelivery{}).AddForeignKey("webhook_id", "webhooks(id)", "CASCADE", "CASCADE")
+
+	config.DB.Model(&domain.StandingOrder{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.StandingOrderItem{}).AddForeignKey("standing_order_id", "standing_orders(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.StandingOrderItem{}).AddForeignKey("dish_id", "dishes(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.StandingOrderItem{}).AddForeignKey("category_id", "categories(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.StandingOrderSkip{}).AddForeignKey("standing_order_id", "standing_orders(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.StandingOrderPlacement{}).AddForeignKey("standing_order_id", "standing_orders(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.StandingOrderPlacement{}).AddForeignKey("order_id", "orders(id)", "SET NULL", "CASCADE")
+
+	config.DB.Model(&domain.Label{}).AddForeignKey("catering_id", "caterings(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.DishLabel{}).AddForeignKey("dish_id", "dishes(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.DishLabel{}).AddForeignKey("label_id", "labels(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.UserLabel{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.UserLabel{}).AddForeignKey("label_id", "labels(id)", "CASCADE", "CASCADE")
+	config.DB.Exec(labelsUniqueNameIndex)
+
+	config.DB.Model(&domain.MenuTemplate{}).AddForeignKey("catering_id", "caterings(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.MenuTemplateItem{}).AddForeignKey("menu_template_id", "menu_templates(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.MenuTemplateItem{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.MenuTemplateItem{}).AddForeignKey("dish_id", "dishes(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.MenuTemplateItem{}).AddForeignKey("category_id", "categories(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.MenuTemplateOverride{}).AddForeignKey("menu_template_id", "menu_templates(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.MenuTemplateOverride{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.MenuTemplatePlacement{}).AddForeignKey("menu_template_id", "menu_templates(id)", "CASCADE", "CASCADE")
+	config.DB.Model(&domain.MenuTemplatePlacement{}).AddForeignKey("client_id", "clients(id)", "CASCADE", "CASCADE")
 }
 
 func createTypes() {
@@ -178,14 +503,58 @@ func createTypes() {
 		enums.StatusTypesEnum.Active,
 	)
 
-	orderStatusTypesQuery := fmt.Sprintf("CREATE TYPE order_status_types AS ENUM ('%s', '%s', '%s')",
+	orderStatusTypesQuery := fmt.Sprintf("CREATE TYPE order_status_types AS ENUM ('%s', '%s', '%s', '%s', '%s', '%s', '%s', '%s')",
 		enums.OrderStatusTypesEnum.Approved,
 		enums.OrderStatusTypesEnum.Canceled,
 		enums.OrderStatusTypesEnum.Pending,
+		enums.OrderStatusTypesEnum.Rejected,
+		enums.OrderStatusTypesEnum.Preparing,
+		enums.OrderStatusTypesEnum.OutForDelivery,
+		enums.OrderStatusTypesEnum.Delivered,
+		enums.OrderStatusTypesEnum.Failed,
+	)
+
+	subsidyTypesQuery := fmt.Sprintf("CREATE TYPE subsidy_types AS ENUM ('%s', '%s', '%s', '%s')",
+		enums.SubsidyTypesEnum.Full,
+		enums.SubsidyTypesEnum.Fixed,
+		enums.SubsidyTypesEnum.Percent,
+		enums.SubsidyTypesEnum.Category,
+	)
+
+	invoiceStatusTypesQuery := fmt.Sprintf("CREATE TYPE invoice_status_types AS ENUM ('%s', '%s', '%s')",
+		enums.InvoiceStatusTypesEnum.Draft,
+		enums.InvoiceStatusTypesEnum.Issued,
+		enums.InvoiceStatusTypesEnum.Paid,
+	)
+
+	webhookDeliveryStatusTypesQuery := fmt.Sprintf("CREATE TYPE webhook_delivery_status_types AS ENUM ('%s', '%s', '%s')",
+		enums.WebhookDeliveryStatusTypesEnum.Pending,
+		enums.WebhookDeliveryStatusTypesEnum.Delivered,
+		enums.WebhookDeliveryStatusTypesEnum.Failed,
+	)
+
+	labelTypesQuery := fmt.Sprintf("CREATE TYPE label_types AS ENUM ('%s', '%s')",
+		enums.LabelTypesEnum.Allergen,
+		enums.LabelTypesEnum.Dietary,
 	)
 
 	config.DB.Exec(userTypesQuery)
 	config.DB.Exec(companyTypesQuery)
 	config.DB.Exec(statusTypesQuery)
 	config.DB.Exec(orderStatusTypesQuery)
+	config.DB.Exec(subsidyTypesQuery)
+	config.DB.Exec(invoiceStatusTypesQuery)
+	config.DB.Exec(webhookDeliveryStatusTypesQuery)
+	config.DB.Exec(labelTypesQuery)
+
+	// values added after type was created
+	for _, status := range []string{
+		enums.OrderStatusTypesEnum.Rejected,
+		enums.OrderStatusTypesEnum.Preparing,
+		enums.OrderStatusTypesEnum.OutForDelivery,
+		enums.OrderStatusTypesEnum.Delivered,
+		enums.OrderStatusTypesEnum.Failed,
+	} {
+		config.DB.Exec(fmt.Sprintf("ALTER TYPE order_status_types ADD VALUE IF NOT EXISTS '%s'", status))
+	}
 }
@@ -2,6 +2,7 @@ package dev
 
 import (
 	"fmt"
+	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository"
@@ -32,7 +33,7 @@ func CreateImageDishes() {
 
 		categoryID := categoryResult.ID.String()
 
-		dishesArray, _, _ = dishRepo.Get(cateringID, categoryID)
+		dishesArray, _, _ = dishRepo.Get(cateringID, categoryID, url.LabelFilterQuery{})
 
 		var imageDish domain.ImageDish
 		for i := range dishesArray {
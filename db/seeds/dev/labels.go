@@ -0,0 +1,33 @@
+package dev
+
+import (
+	"fmt"
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/utils"
+)
+
+// CreateCateringLabels will populate table with default allergens and dietary tags
+func CreateCateringLabels() {
+	seedExists := config.DB.Where("name = ?", "init catering labels").First(&domain.Seed{}).Error
+	if seedExists != nil {
+		seed := domain.Seed{
+			Name: "init catering labels",
+		}
+
+		var caterings []domain.Catering
+		config.DB.Find(&caterings)
+		for i := range caterings {
+			// labels of caterings which existed before labels migration are already added
+			if config.DB.Where("catering_id = ?", caterings[i].ID).First(&domain.Label{}).RecordNotFound() {
+				if err := utils.AddDefaultCateringLabels(config.DB, caterings[i].ID); err != nil {
+					fmt.Printf("Can't add labels of catering %s: %v \n", caterings[i].ID, err)
+				}
+			}
+		}
+		config.DB.Create(&seed)
+		fmt.Println("=== Catering labels seeds created ===")
+	} else {
+		fmt.Printf("Seed `init catering labels` already exists \n")
+	}
+}
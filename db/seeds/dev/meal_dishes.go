@@ -2,6 +2,7 @@ package dev
 
 import (
 	"fmt"
+	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository"
@@ -36,20 +37,22 @@ func CreateMealDishes() {
 		categoryID2 := categoryResult2.ID.String()
 
 		t := time.Hour * 24
-		dishesArray, _, _ = dishRepo.Get(cateringID, categoryID)
-		dishesArray2, _, _ = dishRepo.Get(cateringID, categoryID2)
+		dishesArray, _, _ = dishRepo.Get(cateringID, categoryID, url.LabelFilterQuery{})
+		dishesArray2, _, _ = dishRepo.Get(cateringID, categoryID2, url.LabelFilterQuery{})
 
 		mealResult, _, _ := mealRepo.GetByKey("date", time.Now().AddDate(0, 0, 0).Truncate(t).Format(time.RFC3339))
 		var mealDish domain.MealDish
 		for i := range dishesArray {
 			mealDish.DishID = dishesArray[i].ID
 			mealDish.MealID = mealResult.ID
+			mealDish.Price = dishesArray[i].Price
 			config.DB.Create(&mealDish)
 		}
 
 		for i := range dishesArray {
 			mealDish.DishID = dishesArray2[i].ID
 			mealDish.MealID = mealResult.ID
+			mealDish.Price = dishesArray2[i].Price
 			config.DB.Create(&mealDish)
 		}
 
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// AuditEvent struct for DB
+// records action of user with entity of catering or client,
+// entity state before and after action is kept as JSON,
+// events stay after their entities and actors are deleted
+type AuditEvent struct {
+	Base
+	ActorID    *uuid.UUID `json:"actorId"`
+	ActorRole  string     `json:"actorRole"`
+	CateringID *uuid.UUID `json:"cateringId" gorm:"index"`
+	ClientID   *uuid.UUID `json:"clientId" gorm:"index"`
+	EntityType string     `json:"entityType"`
+	EntityID   uuid.UUID  `json:"entityId"`
+	Action     string     `json:"action"`
+	Before     *string    `json:"before" sql:"type:jsonb"`
+	After      *string    `json:"after" sql:"type:jsonb"`
+}
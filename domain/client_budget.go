@@ -0,0 +1,16 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// ClientBudget struct for DB
+// limits of company share of orders, nil limit isn't checked,
+// user limits apply to each user of client separately
+type ClientBudget struct {
+	Base
+	ClientID         uuid.UUID `json:"-" gorm:"unique_index"`
+	MonthlyBudget    *float32  `json:"monthlyBudget"`
+	UserMonthlyLimit *float32  `json:"userMonthlyLimit"`
+	UserDailyLimit   *float32  `json:"userDailyLimit"`
+}
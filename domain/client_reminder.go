@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ClientReminder struct for DB
+// reminders are sent offset minutes before cutoff of orders,
+// last date is date of orders for which reminders were sent last time
+type ClientReminder struct {
+	Base
+	ClientID uuid.UUID  `json:"-" gorm:"unique_index"`
+	Enabled  bool       `json:"enabled"`
+	Offset   int        `json:"offset"`
+	LastDate *time.Time `json:"lastDate"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// ClientSubsidy struct for DB
+// part of order total paid by client instead of employee,
+// Amount is sum per day for fixed type and percent for percent type,
+// caps of category type are kept in ClientSubsidyCategory,
+// client without subsidy pays full order total
+type ClientSubsidy struct {
+	Base
+	ClientID uuid.UUID `json:"-" gorm:"unique_index"`
+	Type     string    `sql:"type:subsidy_types" json:"type"`
+	Amount   float32   `json:"amount"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// ClientSubsidyCategory struct for DB
+// sum per day paid by client for dishes of category
+type ClientSubsidyCategory struct {
+	Base
+	ClientID   uuid.UUID `json:"-"`
+	CategoryID uuid.UUID `json:"categoryId"`
+	Amount     float32   `json:"amount"`
+}
@@ -5,13 +5,17 @@ import (
 )
 
 // Dish struct used in DB
+// Nutrition is nutrition facts of one portion with provided weight
 type Dish struct {
 	Base
+	Nutrition
 	Name       string       `json:"name" gorm:"not null" binding:"required"`
 	Weight     float32      `json:"weight" gorm:"not null" binding:"required"`
 	Price      float32      `json:"price" gorm:"not null" binding:"required"`
 	Desc       string       `json:"desc"`
 	Images     []ImageArray `json:"images"`
+	Labels     []LabelArray `json:"labels" gorm:"-"`
+	LabelIDs   []uuid.UUID  `json:"labelIds,omitempty" gorm:"-" swaggerignore:"true"`
 	CateringID uuid.UUID    `json:"-"`
 	CategoryID uuid.UUID    `json:"categoryId,omitempty"`
 } //@name DishRequest
@@ -0,0 +1,10 @@
+package domain
+
+import uuid "github.com/satori/go.uuid"
+
+// DishLabel struct for DB
+type DishLabel struct {
+	Base
+	DishID  uuid.UUID `json:"dishId" gorm:"index"`
+	LabelID uuid.UUID `json:"labelId"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Invoice struct for DB
+// bill of catering for approved orders of client for period,
+// Number is sequential per catering and is set when invoice is issued,
+// Total is Subtotal without Discount, CompanyShare and EmployeeShare
+// show which part of Subtotal is covered by client subsidy
+type Invoice struct {
+	Base
+	CateringID    uuid.UUID  `json:"cateringId" gorm:"unique_index:idx_invoices_number"`
+	ClientID      uuid.UUID  `json:"clientId"`
+	Number        *int       `json:"number" gorm:"unique_index:idx_invoices_number"`
+	PeriodFrom    time.Time  `json:"from"`
+	PeriodTo      time.Time  `json:"to"`
+	Status        string     `sql:"type:invoice_status_types" json:"status"`
+	Subtotal      float32    `json:"subtotal"`
+	Discount      float32    `json:"discount"`
+	Total         float32    `json:"total"`
+	CompanyShare  float32    `json:"companyShare"`
+	EmployeeShare float32    `json:"employeeShare"`
+	IssuedAt      *time.Time `json:"issuedAt"`
+	PaidAt        *time.Time `json:"paidAt"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// InvoiceItem struct for DB
+// portions of dish ordered by client for date
+type InvoiceItem struct {
+	Base
+	InvoiceID uuid.UUID `json:"-"`
+	Date      time.Time `json:"date"`
+	DishID    uuid.UUID `json:"dishId"`
+	Name      string    `json:"name"`
+	Price     float32   `json:"price"`
+	Amount    int       `json:"amount"`
+	Total     float32   `json:"total"`
+}
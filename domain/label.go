@@ -0,0 +1,21 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// Label struct for DB
+// allergen or dietary tag which catering attaches to its dishes
+type Label struct {
+	Base
+	Name       string    `json:"name" gorm:"not null" binding:"required"`
+	Type       string    `sql:"type:label_types" json:"type" binding:"required,oneof=allergen dietary"`
+	CateringID uuid.UUID `json:"-" gorm:"index"`
+} //@name LabelResponse
+
+// LabelArray struct
+type LabelArray struct {
+	ID   string `json:"id" gorm:"column:id"`
+	Name string `json:"name" gorm:"column:name"`
+	Type string `json:"type" gorm:"column:type"`
+} //@name Label
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// MealDishPortion struct for DB
+// limits portions of dish for meal, MealID is shared
+// by all versions of meal, so limit survives new versions
+type MealDishPortion struct {
+	Base
+	MealID   uuid.UUID `json:"mealId" gorm:"unique_index:idx_meal_dish_portions"`
+	DishID   uuid.UUID `json:"dishId" gorm:"unique_index:idx_meal_dish_portions"`
+	Portions int       `json:"portions"`
+	Reserved int       `json:"reserved"`
+}
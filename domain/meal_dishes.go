@@ -5,8 +5,12 @@ import (
 )
 
 // MealDish struct for DB
+// Price is copied from the dish when meal is published,
+// so every version of meal keeps prices it was published with
+// and orders for date of meal are charged with them
 type MealDish struct {
 	Base
 	MealID uuid.UUID `json:"mealId"`
 	DishID uuid.UUID `json:"dishId"`
+	Price  float32   `json:"price"`
 } //@name MealDishRequest
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplate struct for DB
+// cycle of Length days, first day of cycle is StartDate,
+// meals of enabled template are published automatically
+// for working days of catering
+type MenuTemplate struct {
+	Base
+	Name       string    `json:"name" gorm:"not null"`
+	CateringID uuid.UUID `json:"-"`
+	StartDate  time.Time `json:"startDate"`
+	Length     int       `json:"length"`
+	Enabled    bool      `json:"enabled"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplateItem struct for DB
+// item contains either dish or category for client on day of cycle,
+// for category all its dishes are published
+type MenuTemplateItem struct {
+	Base
+	MenuTemplateID uuid.UUID  `json:"-"`
+	Day            int        `json:"day"`
+	ClientID       uuid.UUID  `json:"clientId"`
+	DishID         *uuid.UUID `json:"dishId"`
+	CategoryID     *uuid.UUID `json:"categoryId"`
+}
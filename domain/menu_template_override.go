@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplateOverride struct for DB
+// dishes which replace day of cycle for single date of client,
+// meal isn't published for that date if Dishes are empty
+type MenuTemplateOverride struct {
+	Base
+	MenuTemplateID uuid.UUID      `json:"-" gorm:"unique_index:idx_menu_template_overrides"`
+	ClientID       uuid.UUID      `json:"clientId" gorm:"unique_index:idx_menu_template_overrides"`
+	Date           time.Time      `json:"date" gorm:"unique_index:idx_menu_template_overrides"`
+	Dishes         pq.StringArray `json:"dishes" gorm:"type:uuid[]"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplatePlacement struct for DB
+// keeps dates already handled by template for client,
+// MealID is mealId of published meal, it's nil
+// if meal wasn't published by template
+type MenuTemplatePlacement struct {
+	Base
+	MenuTemplateID uuid.UUID `gorm:"unique_index:idx_menu_template_placements"`
+	ClientID       uuid.UUID `gorm:"unique_index:idx_menu_template_placements"`
+	Date           time.Time `gorm:"unique_index:idx_menu_template_placements"`
+	MealID         *uuid.UUID
+}
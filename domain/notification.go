@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Notification struct for DB
+// in-app notification of user about status change of order,
+// emailed at is set when email is sent, notification taken
+// for emailing is hidden from other runs until lease is over,
+// email failed at is set when all attempts to send email failed
+type Notification struct {
+	Base
+	UserID           uuid.UUID  `json:"-" gorm:"index"`
+	OrderID          uuid.UUID  `json:"orderId"`
+	Date             time.Time  `json:"date"`
+	FromStatus       string     `sql:"type:order_status_types" json:"from"`
+	ToStatus         string     `sql:"type:order_status_types" json:"to"`
+	Title            string     `json:"title"`
+	Message          string     `json:"message"`
+	ReadAt           *time.Time `json:"readAt"`
+	EmailedAt        *time.Time `json:"-"`
+	EmailLeasedUntil *time.Time `json:"-"`
+	EmailAttempts    int        `json:"-"`
+	EmailFailedAt    *time.Time `json:"-"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+// Nutrition struct of nutrition facts,
+// for dish they are facts of one portion of dish weight
+type Nutrition struct {
+	Kcal    float32 `json:"kcal" binding:"min=0"`
+	Protein float32 `json:"protein" binding:"min=0"`
+	Fat     float32 `json:"fat" binding:"min=0"`
+	Carbs   float32 `json:"carbs" binding:"min=0"`
+} //@name Nutrition
+
+// Add returns sum of nutrition facts and provided ones multiplied by amount
+func (n Nutrition) Add(other Nutrition, amount int) Nutrition {
+	return Nutrition{
+		Kcal:    n.Kcal + other.Kcal*float32(amount),
+		Protein: n.Protein + other.Protein*float32(amount),
+		Fat:     n.Fat + other.Fat*float32(amount),
+		Carbs:   n.Carbs + other.Carbs*float32(amount),
+	}
+}
@@ -5,10 +5,14 @@ import (
 )
 
 // Order struct for db
+// Total is split between client and employee by client subsidy
 type Order struct {
 	Base
-	Total   *float32
-	Status  *string `sql:"type:order_status_types"`
-	Comment *string
-	Date    time.Time
+	Total         *float32
+	CompanyShare  *float32
+	EmployeeShare *float32
+	Status        *string `sql:"type:order_status_types"`
+	Comment       *string
+	Reason        *string
+	Date          time.Time
 }
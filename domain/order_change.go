@@ -0,0 +1,18 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderChange struct for DB
+// keeps what was changed in pending order on update
+type OrderChange struct {
+	Base
+	OrderID uuid.UUID  `json:"-"`
+	UserID  uuid.UUID  `json:"-"`
+	Field   string     `json:"field"`
+	DishID  *uuid.UUID `json:"dishId,omitempty"`
+	Name    string     `json:"name,omitempty"`
+	Before  string     `json:"before"`
+	After   string     `json:"after"`
+}
@@ -5,9 +5,17 @@ import (
 )
 
 // OrderDishes struct for DB
+// Name, Price, Category and Nutrition are copied from the dish
+// when order is placed, so later dish changes
+// don't affect already created orders
 type OrderDishes struct {
 	Base
-	OrderID uuid.UUID
-	DishID  uuid.UUID
-	Amount  int
+	OrderID      uuid.UUID
+	DishID       uuid.UUID
+	Amount       int
+	Name         string
+	Price        float32
+	CategoryID   uuid.UUID
+	CategoryName string
+	Nutrition
 }
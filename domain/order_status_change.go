@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// OrderStatusChange struct for DB
+// keeps delivery status changes of client orders for date
+type OrderStatusChange struct {
+	Base
+	ClientID   uuid.UUID `json:"-"`
+	Date       time.Time `json:"-"`
+	UserID     uuid.UUID `json:"-"`
+	FromStatus string    `sql:"type:order_status_types" json:"from"`
+	ToStatus   string    `sql:"type:order_status_types" json:"to"`
+	Note       string    `json:"note"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReminderDelivery struct for DB
+// keeps dates users were reminded about,
+// so every user is reminded once per date
+type ReminderDelivery struct {
+	Base
+	UserID uuid.UUID `gorm:"unique_index:idx_reminder_deliveries"`
+	Date   time.Time `gorm:"unique_index:idx_reminder_deliveries"`
+}
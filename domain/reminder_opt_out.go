@@ -0,0 +1,12 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReminderOptOut struct for DB
+// user with opt out doesn't receive order reminders
+type ReminderOptOut struct {
+	Base
+	UserID uuid.UUID `json:"-" gorm:"unique_index"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrder struct for DB
+// template of order which is placed for user
+// automatically on provided weekdays
+type StandingOrder struct {
+	Base
+	UserID   uuid.UUID     `json:"-"`
+	Weekdays pq.Int64Array `json:"weekdays" gorm:"type:integer[]"`
+	EndDate  *time.Time    `json:"endDate"`
+	Paused   bool          `json:"paused"`
+	Comment  string        `json:"comment"`
+}
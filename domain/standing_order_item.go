@@ -0,0 +1,16 @@
+package domain
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrderItem struct for DB
+// item contains either dish or category, for category
+// any dish of it from published meal is ordered
+type StandingOrderItem struct {
+	Base
+	StandingOrderID uuid.UUID  `json:"-"`
+	DishID          *uuid.UUID `json:"dishId"`
+	CategoryID      *uuid.UUID `json:"categoryId"`
+	Amount          int        `json:"amount"`
+}
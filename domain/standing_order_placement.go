@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrderPlacement struct for DB
+// keeps dates already handled by standing order,
+// OrderID is nil if user ordered that day by themselves
+// or if order couldn't be placed, then Reason is set
+type StandingOrderPlacement struct {
+	Base
+	StandingOrderID uuid.UUID `gorm:"unique_index:idx_standing_order_placements"`
+	Date            time.Time `gorm:"unique_index:idx_standing_order_placements"`
+	OrderID         *uuid.UUID
+	Reason          *string
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrderSkip struct for DB
+// date for which standing order isn't placed
+type StandingOrderSkip struct {
+	Base
+	StandingOrderID uuid.UUID `json:"-" gorm:"unique_index:idx_standing_order_skips"`
+	Date            time.Time `json:"date" gorm:"unique_index:idx_standing_order_skips"`
+}
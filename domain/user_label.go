@@ -0,0 +1,12 @@
+package domain
+
+import uuid "github.com/satori/go.uuid"
+
+// UserLabel struct for DB
+// dietary profile of user, user avoids allergen labels
+// and eats only dishes with all dietary labels
+type UserLabel struct {
+	Base
+	UserID  uuid.UUID `json:"userId" gorm:"index"`
+	LabelID uuid.UUID `json:"labelId"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Webhook struct for DB
+// endpoint of catering or client which receives events
+// signed with secret, catering webhook receives events of all its clients
+type Webhook struct {
+	Base
+	CateringID *uuid.UUID     `json:"cateringId"`
+	ClientID   *uuid.UUID     `json:"clientId"`
+	URL        string         `json:"url"`
+	Secret     string         `json:"-"`
+	Events     pq.StringArray `json:"events" gorm:"type:varchar(30)[]"`
+	Active     bool           `json:"active"`
+}
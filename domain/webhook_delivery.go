@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// WebhookDelivery struct for DB
+// event sent to webhook, pending delivery is retried
+// at next attempt time until it's delivered or attempts are over
+type WebhookDelivery struct {
+	Base
+	WebhookID     uuid.UUID  `json:"webhookId" gorm:"index"`
+	Event         string     `json:"event"`
+	Payload       string     `json:"payload" gorm:"type:text"`
+	Status        string     `sql:"type:webhook_delivery_status_types" json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt"`
+	ResponseCode  *int       `json:"responseCode"`
+	Error         *string    `json:"error"`
+	DeliveredAt   *time.Time `json:"deliveredAt"`
+}
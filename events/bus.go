@@ -0,0 +1,124 @@
+package events
+
+import (
+	"sync"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// subscriptionBuffer is number of events kept for subscriber
+// until it reads them, subscriber which falls behind is dropped
+const subscriptionBuffer = 64
+
+// Event struct
+// is published to subscribers of its catering and client
+type Event struct {
+	Type       string      `json:"type"`
+	CateringID uuid.UUID   `json:"cateringId"`
+	ClientID   uuid.UUID   `json:"clientId"`
+	Data       interface{} `json:"data"`
+}
+
+// Subscription struct
+// receives events of one catering or one client,
+// Events channel is closed when subscription is closed or dropped
+type Subscription struct {
+	Events     <-chan Event
+	events     chan Event
+	cateringID uuid.UUID
+	clientID   uuid.UUID
+	bus        *Bus
+}
+
+// Bus struct
+// in-process bus which delivers events to subscribers,
+// publishing never waits for subscribers
+type Bus struct {
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewBus returns pointer to empty bus
+func NewBus() *Bus {
+	return &Bus{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Orders is bus of order changes used by live order board
+var Orders = NewBus()
+
+// matches returns true if event belongs to company of subscription
+func (s *Subscription) matches(event Event) bool {
+	if s.cateringID != uuid.Nil {
+		return s.cateringID == event.CateringID
+	}
+
+	return s.clientID == event.ClientID
+}
+
+// Subscribe returns subscription to events of catering,
+// or of client if catering id is nil
+func (b *Bus) Subscribe(cateringID, clientID uuid.UUID) *Subscription {
+	events := make(chan Event, subscriptionBuffer)
+	subscription := &Subscription{
+		Events:     events,
+		events:     events,
+		cateringID: cateringID,
+		clientID:   clientID,
+		bus:        b,
+	}
+
+	b.mu.Lock()
+	b.subscriptions[subscription] = struct{}{}
+	b.mu.Unlock()
+
+	return subscription
+}
+
+// HasSubscribers returns true if someone is subscribed
+// to events of provided catering or client
+func (b *Bus) HasSubscribers(cateringID, clientID uuid.UUID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscription := range b.subscriptions {
+		if subscription.matches(Event{CateringID: cateringID, ClientID: clientID}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Publish sends event to matching subscribers without waiting,
+// subscriber with full buffer is dropped and has to subscribe again
+func (b *Bus) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscription := range b.subscriptions {
+		if !subscription.matches(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			delete(b.subscriptions, subscription)
+			close(subscription.events)
+		}
+	}
+}
+
+// Close removes subscription from bus, it's safe
+// to close subscription which is already dropped
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	if _, ok := s.bus.subscriptions[s]; ok {
+		delete(s.bus.subscriptions, s)
+		close(s.events)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/Aiscom-LLC/meals-api/backups"
 
 	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/services"
 	"github.com/Aiscom-LLC/meals-api/utils"
 )
 
@@ -31,13 +33,25 @@ func init() {
 				for key, value := range clientIDMap {
 					if currentDay == key && currentTime == value {
 						nextDay := time.Now().Add(time.Hour * 24).UTC().Truncate(time.Hour * 24).Format(time.RFC3339)
-						_ = orderRepo.ApproveOrders(entryKey, nextDay)
+						_ = orderRepo.ApproveOrders(entryKey, nextDay, domain.User{})
 					}
 				}
 			}
 		}
 	})
+	standingOrderService := services.NewStandingOrderService()
+	menuTemplateService := services.NewMenuTemplateService()
+	_ = config.CRON.Cron.AddFunc("@every 0h10m0s", menuTemplateService.PublishMeals)
+	_ = config.CRON.Cron.AddFunc(utils.CronStringCreator("*/10", "*"), standingOrderService.PlaceOrders)
+	reminderService := services.NewReminderService()
+	_ = config.CRON.Cron.AddFunc("@every 0h1m0s", reminderService.SendReminders)
+	notificationService := services.NewNotificationService()
+	_ = config.CRON.Cron.AddFunc("@every 0h1m0s", notificationService.SendEmails)
+	webhookService := services.NewWebhookService()
+	_ = config.CRON.Cron.AddFunc("@every 0h1m0s", webhookService.SendDeliveries)
+
 	if os.Getenv("BACKUP") == "true" {
-		_ = config.CRON.Cron.AddFunc(utils.CronStringCreator("Europe/Moscow", "00", "00"), backups.CreateBackup)
+		// midnight in Europe/Moscow
+		_ = config.CRON.Cron.AddFunc(utils.CronStringCreator("00", "21"), backups.CreateBackup)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"net/smtp"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Aiscom-LLC/meals-api/domain"
 )
@@ -54,6 +55,44 @@ func RecoveryPassword(user domain.User, password string, url string) error {
 	return nil
 }
 
+// SendOrderReminder reminds user to order meals
+// for provided date before cutoff of orders
+func SendOrderReminder(firstName, email string, date, cutoff time.Time, url string) error {
+	auth = smtp.PlainAuth("", os.Getenv("SMTP_EMAIL"), os.Getenv("SMTP_PASSWORD"), "smtp.gmail.com")
+
+	r := NewRequest([]string{email},
+		"TastyOffice напоминание о заказе",
+		"Здравствуйте, "+firstName+"\n"+
+			"Вы еще не сделали заказ на "+date.Format("02.01.2006")+".\n"+
+			"Заказы принимаются до "+cutoff.Format("15:04 02.01.2006")+".\n"+
+			"Сделать заказ: "+url+"\n"+
+			"Желаем Вам приятного аппетита и хорошего дня!")
+
+	if err := r.SendEmail(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SendNotification sends notification about
+// status change of order to user
+func SendNotification(firstName, email, title, message string) error {
+	auth = smtp.PlainAuth("", os.Getenv("SMTP_EMAIL"), os.Getenv("SMTP_PASSWORD"), "smtp.gmail.com")
+
+	r := NewRequest([]string{email},
+		"TastyOffice: "+title,
+		"Здравствуйте, "+firstName+"\n"+
+			message+".\n"+
+			"Желаем Вам приятного аппетита и хорошего дня!")
+
+	if err := r.SendEmail(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Request struct
 type Request struct {
 	to      []string
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AuditRepo struct
+type AuditRepo struct{}
+
+// NewAuditRepo returns pointer to audit repository
+// with all methods
+func NewAuditRepo() *AuditRepo {
+	return &AuditRepo{}
+}
+
+// auditJSON returns entity as JSON, missing entity is saved as null
+func auditJSON(entity interface{}) *string {
+	if entity == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(entity)
+
+	if err != nil {
+		return nil
+	}
+
+	result := string(data)
+
+	return &result
+}
+
+// orderAuditState struct
+// state of order saved in audit events of status changes
+type orderAuditState struct {
+	Status string  `json:"status"`
+	Reason *string `json:"reason,omitempty"`
+}
+
+// orderAuditEvent returns audit event of status change of client order
+func orderAuditEvent(clientID string, orderID uuid.UUID) domain.AuditEvent {
+	parsedClientID := uuid.FromStringOrNil(clientID)
+
+	return domain.AuditEvent{
+		ClientID:   &parsedClientID,
+		EntityType: enums.AuditEntitiesEnum.Order,
+		EntityID:   orderID,
+		Action:     enums.AuditActionsEnum.StatusChange,
+	}
+}
+
+// addAuditEvent saves action of actor with entity in provided transaction,
+// catering of client is added to event, so catering sees actions in its clients
+func addAuditEvent(db *gorm.DB, actor domain.User, event domain.AuditEvent, before, after interface{}) error {
+	if actor.ID != uuid.Nil {
+		event.ActorID = &actor.ID
+		event.ActorRole = actor.Role
+	}
+
+	if event.CateringID == nil && event.ClientID != nil {
+		var client domain.Client
+
+		if err := db.
+			Unscoped().
+			Where("id = ?", *event.ClientID).
+			First(&client).
+			Error; err == nil {
+			event.CateringID = &client.CateringID
+		}
+	}
+
+	event.Before = auditJSON(before)
+	event.After = auditJSON(after)
+
+	return db.Create(&event).Error
+}
+
+// saveAudit saves action of actor with entity after action is done,
+// failed audit doesn't fail action which is already saved
+func saveAudit(actor domain.User, event domain.AuditEvent, before, after interface{}) {
+	// nolint:errcheck
+	addAuditEvent(config.DB, actor, event, before, after)
+}
+
+// Get returns page of audit events of catering or client from the newest one,
+// zero from and to dates aren't used for filtering
+func (a AuditRepo) Get(companyType, ownerID string, query url.AuditQuery, from, to time.Time, pagination url.PaginationQuery) (models.AuditEvents, int, error) {
+	result := models.AuditEvents{
+		Items: make([]models.AuditEvent, 0),
+		Page:  pagination.Page,
+	}
+
+	auditEvents := config.DB.
+		Table("audit_events as ae").
+		Where("ae.deleted_at IS NULL")
+
+	if companyType == enums.CompanyTypesEnum.Catering {
+		if cateringExist := config.DB.
+			Where("id = ?", ownerID).
+			Find(&domain.Catering{}).
+			RowsAffected; cateringExist == 0 {
+			return models.AuditEvents{}, http.StatusNotFound, errors.New("catering not found")
+		}
+		auditEvents = auditEvents.Where("ae.catering_id = ?", ownerID)
+	} else {
+		if clientExist := config.DB.
+			Where("id = ?", ownerID).
+			Find(&domain.Client{}).
+			RowsAffected; clientExist == 0 {
+			return models.AuditEvents{}, http.StatusNotFound, errors.New("client not found")
+		}
+		auditEvents = auditEvents.Where("ae.client_id = ?", ownerID)
+	}
+
+	if query.Entity != "" {
+		auditEvents = auditEvents.Where("ae.entity_type = ?", query.Entity)
+	}
+
+	if query.EntityID != "" {
+		auditEvents = auditEvents.Where("ae.entity_id = ?", query.EntityID)
+	}
+
+	if query.ActorID != "" {
+		auditEvents = auditEvents.Where("ae.actor_id = ?", query.ActorID)
+	}
+
+	if !from.IsZero() {
+		auditEvents = auditEvents.Where("ae.created_at >= ?", from)
+	}
+
+	if !to.IsZero() {
+		auditEvents = auditEvents.Where("ae.created_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	if err := auditEvents.
+		Count(&result.Total).
+		Error; err != nil {
+		return models.AuditEvents{}, http.StatusBadRequest, err
+	}
+
+	if err := auditEvents.
+		Select("ae.id, ae.actor_id, coalesce(u.first_name || ' ' || u.last_name, '') as actor_name," +
+			" ae.actor_role, ae.catering_id, ae.client_id, ae.entity_type, ae.entity_id, ae.action," +
+			" ae.before, ae.after, ae.created_at").
+		Joins("left join users u on u.id = ae.actor_id").
+		Order("ae.created_at DESC").
+		Limit(pagination.Limit).
+		Offset((pagination.Page - 1) * pagination.Limit).
+		Scan(&result.Items).
+		Error; err != nil {
+		return models.AuditEvents{}, http.StatusBadRequest, err
+	}
+
+	return result, 0, nil
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/jinzhu/gorm"
 )
 
 // CategoryRepo struct
@@ -21,7 +23,7 @@ func NewCategoryRepo() *CategoryRepo {
 
 // Add creates dish category
 // returns dish category and error
-func (dc CategoryRepo) Add(category *domain.Category) error {
+func (dc CategoryRepo) Add(category *domain.Category, actor domain.User) error {
 	if exist := config.DB.
 		Unscoped().
 		Where("catering_id = ? AND client_id = ? AND name = ? AND (deleted_at >  ? OR deleted_at IS NULL)",
@@ -31,8 +33,24 @@ func (dc CategoryRepo) Add(category *domain.Category) error {
 		return errors.New("this category already exist")
 	}
 
-	err := config.DB.Create(category).Error
-	return err
+	if err := config.DB.Create(category).Error; err != nil {
+		return err
+	}
+
+	saveAudit(actor, categoryAuditEvent(*category, enums.AuditActionsEnum.Create), nil, category)
+
+	return nil
+}
+
+// categoryAuditEvent returns audit event of action with category of client
+func categoryAuditEvent(category domain.Category, action string) domain.AuditEvent {
+	return domain.AuditEvent{
+		CateringID: &category.CateringID,
+		ClientID:   &category.ClientID,
+		EntityType: enums.AuditEntitiesEnum.Category,
+		EntityID:   category.ID,
+		Action:     action,
+	}
 }
 
 // Get returns list of categories of passed catering ID
@@ -73,7 +91,20 @@ func (dc CategoryRepo) GetByKey(key, value, cateringID string) (domain.Category,
 
 // Delete soft deletes reading from DB
 // returns gorm.DB struct with methods
-func (dc CategoryRepo) Delete(path url.PathCategory) (int, error) {
+func (dc CategoryRepo) Delete(path url.PathCategory, actor domain.User) (int, error) {
+	var before domain.Category
+
+	if err := config.DB.
+		Unscoped().
+		Where("id = ?", path.CategoryID).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("category not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
 	result := config.DB.
 		Unscoped().
 		Model(&domain.Category{}).
@@ -88,12 +119,27 @@ func (dc CategoryRepo) Delete(path url.PathCategory) (int, error) {
 		return http.StatusNotFound, errors.New("category not found")
 	}
 
+	saveAudit(actor, categoryAuditEvent(before, enums.AuditActionsEnum.Delete), before, nil)
+
 	return 0, nil
 }
 
 // Update checks if that name already exists in provided catering
 // if its exists throws and error, if not updates the reading
-func (dc CategoryRepo) Update(path url.PathCategory, category *domain.Category) (int, error) {
+func (dc CategoryRepo) Update(path url.PathCategory, category *domain.Category, actor domain.User) (int, error) {
+	var before, after domain.Category
+
+	if err := config.DB.
+		Unscoped().
+		Where("id = ?", path.CategoryID).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("category not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
 	if categoryExist := config.DB.
 		Where("catering_id = ? AND name = ? AND id = ? AND (deleted_at > ? OR deleted_at IS NULL)",
 			path.ID, category.Name, path.CategoryID, time.Now()).
@@ -115,5 +161,15 @@ func (dc CategoryRepo) Update(path url.PathCategory, category *domain.Category)
 		return http.StatusNotFound, errors.New("category not found")
 	}
 
+	if err := config.DB.
+		Unscoped().
+		Where("id = ?", path.CategoryID).
+		First(&after).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, categoryAuditEvent(after, enums.AuditActionsEnum.Update), before, after)
+
 	return 0, nil
 }
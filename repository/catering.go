@@ -10,6 +10,7 @@ import (
 	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/jinzhu/gorm"
 )
 
 // CateringRepo struct
@@ -38,7 +39,7 @@ func (c CateringRepo) Add(catering *domain.Catering) error {
 
 	utils.AddDefaultCateringSchedules(catering.ID)
 
-	return err
+	return utils.AddDefaultCateringLabels(config.DB, catering.ID)
 }
 
 // Get returns list of caterings with pagination args
@@ -97,8 +98,20 @@ func (c CateringRepo) GetByKey(key, value string) (domain.Catering, error) {
 
 // Delete soft delete of catering with passed id
 // returns error if exists
-func (c CateringRepo) Delete(id string) error {
+func (c CateringRepo) Delete(id string, actor domain.User) error {
 	var cateringUsers []domain.CateringUser
+	var before domain.Catering
+
+	if err := config.DB.
+		Where("id = ?", id).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return errors.New("catering not found")
+		}
+		return err
+	}
+
 	if cateringExist := config.DB.
 		Where("id = ?", id).
 		Delete(&domain.Catering{}).
@@ -119,12 +132,32 @@ func (c CateringRepo) Delete(id string) error {
 				"deleted_at": time.Now(),
 			})
 	}
+
+	saveAudit(actor, domain.AuditEvent{
+		CateringID: &before.ID,
+		EntityType: enums.AuditEntitiesEnum.Catering,
+		EntityID:   before.ID,
+		Action:     enums.AuditActionsEnum.Delete,
+	}, before, nil)
+
 	return nil
 }
 
 // Update updates catering with passed args
 // returns updated catering struct and error if exists
-func (c CateringRepo) Update(id string, catering domain.Catering) (int, error) {
+func (c CateringRepo) Update(id string, catering domain.Catering, actor domain.User) (int, error) {
+	var before, after domain.Catering
+
+	if err := config.DB.
+		Where("id = ?", id).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("catering not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
 	if cateringExist := config.DB.
 		Where("name = ? AND id = ?", catering.Name, id).
 		Find(&catering).
@@ -142,5 +175,19 @@ func (c CateringRepo) Update(id string, catering domain.Catering) (int, error) {
 		return http.StatusNotFound, errors.New("catering not found")
 	}
 
+	if err := config.DB.
+		Where("id = ?", id).
+		First(&after).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, domain.AuditEvent{
+		CateringID: &before.ID,
+		EntityType: enums.AuditEntitiesEnum.Catering,
+		EntityID:   before.ID,
+		Action:     enums.AuditActionsEnum.Update,
+	}, before, after)
+
 	return 0, nil
 }
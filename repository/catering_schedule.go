@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/jinzhu/gorm"
 	"github.com/jinzhu/now"
 	"net/http"
@@ -45,7 +46,7 @@ func (cs CateringScheduleRepo) Get(cateringID string) ([]domain.CateringSchedule
 }
 
 // Update updates schedule and returns new updated schedule
-func (cs CateringScheduleRepo) Update(cateringID, scheduleID string, isWorking *bool, newSchedule *domain.CateringSchedule) (int, error) {
+func (cs CateringScheduleRepo) Update(cateringID, scheduleID string, isWorking *bool, newSchedule *domain.CateringSchedule, actor domain.User) (int, error) {
 	var schedule domain.CateringSchedule
 
 	if err := config.DB.
@@ -147,6 +148,8 @@ func (cs CateringScheduleRepo) Update(cateringID, scheduleID string, isWorking *
 		newSchedule.IsWorking = *isWorking
 	}
 
+	before := schedule
+
 	config.DB.
 		Model(&schedule).
 		Where("id = ?", schedule.ID).
@@ -158,5 +161,12 @@ func (cs CateringScheduleRepo) Update(cateringID, scheduleID string, isWorking *
 		}).
 		Scan(newSchedule)
 
+	saveAudit(actor, domain.AuditEvent{
+		CateringID: &schedule.CateringID,
+		EntityType: enums.AuditEntitiesEnum.CateringSchedule,
+		EntityID:   schedule.ID,
+		Action:     enums.AuditActionsEnum.Update,
+	}, before, newSchedule)
+
 	return 0, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
 )
 
 // CateringUserRepo struct
@@ -22,6 +23,16 @@ func NewCateringUserRepo() *CateringUserRepo {
 	return &CateringUserRepo{}
 }
 
+// cateringUserAuditEvent returns audit event of action with user of catering
+func cateringUserAuditEvent(cateringID, userID uuid.UUID, action string) domain.AuditEvent {
+	return domain.AuditEvent{
+		CateringID: &cateringID,
+		EntityType: enums.AuditEntitiesEnum.CateringUser,
+		EntityID:   userID,
+		Action:     action,
+	}
+}
+
 func (cur *CateringUserRepo) GetByKey(key, value string) (domain.CateringUser, error) {
 	var user domain.CateringUser
 	err := config.DB.
@@ -31,11 +42,22 @@ func (cur *CateringUserRepo) GetByKey(key, value string) (domain.CateringUser, e
 	return user, err
 }
 
-func (cur *CateringUserRepo) Add(cateringUser domain.CateringUser) error {
-	err := config.DB.
+func (cur *CateringUserRepo) Add(cateringUser domain.CateringUser, actor domain.User) error {
+	if err := config.DB.
 		Create(&cateringUser).
-		Error
-	return err
+		Error; err != nil {
+		return err
+	}
+
+	after, err := UserRepo{}.GetByID(cateringUser.UserID.String())
+
+	if err != nil {
+		return err
+	}
+
+	saveAudit(actor, cateringUserAuditEvent(cateringUser.CateringID, cateringUser.UserID, enums.AuditActionsEnum.Create), nil, after)
+
+	return nil
 }
 
 func (cur *CateringUserRepo) Get(cateringID string, pagination url.PaginationQuery, filters url.UserFilterQuery) ([]models.GetCateringUser, int, int, error) {
@@ -93,9 +115,9 @@ func (cur *CateringUserRepo) Get(cateringID string, pagination url.PaginationQue
 	return users, total, 0, nil
 }
 
-func (cur *CateringUserRepo) Delete(cateringID, ctxUserRole string, user domain.User) (int, error) {
+func (cur *CateringUserRepo) Delete(cateringID string, user domain.User, actor domain.User) (int, error) {
 	var totalUsers int
-	if ctxUserRole != enums.UserRoleEnum.SuperAdmin {
+	if actor.Role != enums.UserRoleEnum.SuperAdmin {
 		config.DB.
 			Table("users as u").
 			Joins("left join catering_users cu on cu.user_id = u.id").
@@ -108,6 +130,15 @@ func (cur *CateringUserRepo) Delete(cateringID, ctxUserRole string, user domain.
 		}
 	}
 
+	before, err := UserRepo{}.GetByID(user.ID.String())
+
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("user not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
 	if userExist := config.DB.
 		Table("users as u").
 		Where("u.id = ?", user.ID).
@@ -115,10 +146,22 @@ func (cur *CateringUserRepo) Delete(cateringID, ctxUserRole string, user domain.
 		RowsAffected; userExist == 0 {
 		return http.StatusNotFound, errors.New("user not found")
 	}
+
+	saveAudit(actor, cateringUserAuditEvent(uuid.FromStringOrNil(cateringID), user.ID, enums.AuditActionsEnum.Delete), before, nil)
+
 	return 0, nil
 }
 
-func (cur *CateringUserRepo) Update(user *domain.User) (int, error) {
+func (cur *CateringUserRepo) Update(cateringID string, user *domain.User, actor domain.User) (int, error) {
+	before, err := UserRepo{}.GetByID(user.ID.String())
+
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("user not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
 	if userExist := config.DB.
 		Where("id = ? AND email = ?", user.ID, user.Email).
 		Find(&domain.User{}).
@@ -142,5 +185,14 @@ func (cur *CateringUserRepo) Update(user *domain.User) (int, error) {
 		}
 		return http.StatusBadRequest, err
 	}
+
+	after, err := UserRepo{}.GetByID(user.ID.String())
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, cateringUserAuditEvent(uuid.FromStringOrNil(cateringID), user.ID, enums.AuditActionsEnum.Update), before, after)
+
 	return 0, nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/Aiscom-LLC/meals-api/repository/enums"
 
 	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
 )
 
 // ClientRepo struct
@@ -90,7 +91,7 @@ func (c ClientRepo) GetCateringClientsOrders(cateringID string, query url.Pagina
 		Joins("left join user_orders uo on cu.user_id = uo.user_id").
 		Joins("left join orders o on uo.order_id = o.id").
 		Joins("left join order_dishes od on od.order_id = o.id").
-		Where("clients.catering_id = ? AND o.status = ? AND o.date = ?", cateringID, enums.OrderStatusTypesEnum.Approved, query.Date).
+		Where("clients.catering_id = ? AND o.status IN (?) AND o.date = ?", cateringID, cateringStatuses, query.Date).
 		Group("clients.name, clients.id").
 		Scan(&cateringClients).
 		Count(&total).
@@ -106,7 +107,7 @@ func (c ClientRepo) GetCateringClientsOrders(cateringID string, query url.Pagina
 			Joins("left join client_users cu on cu.client_id = clients.id").
 			Joins("left join user_orders uo on cu.user_id = uo.user_id").
 			Joins("left join orders o on uo.order_id = o.id").
-			Where("clients.catering_id = ? AND o.status = ? AND o.date = ?", cateringID, enums.OrderStatusTypesEnum.Approved, query.Date).
+			Where("clients.catering_id = ? AND o.status IN (?) AND o.date = ?", cateringID, cateringStatuses, query.Date).
 			Group("clients.name, clients.id").
 			Pluck("sum(o.total)", &total)
 
@@ -170,8 +171,20 @@ func (c ClientRepo) Get(query url.PaginationQuery, cateringID, role string) ([]m
 }
 
 // Delete soft delete of client
-func (c ClientRepo) Delete(id string) error {
+func (c ClientRepo) Delete(id string, actor domain.User) error {
 	var clientUsers []domain.ClientUser
+	var before domain.Client
+
+	if err := config.DB.
+		Where("id = ?", id).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return errors.New("client not found")
+		}
+		return err
+	}
+
 	if clientExist := config.DB.
 		Where("id = ?", id).
 		Delete(&domain.Client{}).
@@ -192,12 +205,32 @@ func (c ClientRepo) Delete(id string) error {
 				"deleted_at": time.Now(),
 			})
 	}
+
+	saveAudit(actor, domain.AuditEvent{
+		ClientID:   &before.ID,
+		EntityType: enums.AuditEntitiesEnum.Client,
+		EntityID:   before.ID,
+		Action:     enums.AuditActionsEnum.Delete,
+	}, before, nil)
+
 	return nil
 }
 
 // Update updates client with passed args
 // returns error and status code
-func (c ClientRepo) Update(id string, client domain.Client) (int, error) {
+func (c ClientRepo) Update(id string, client domain.Client, actor domain.User) (int, error) {
+	var before, after domain.Client
+
+	if err := config.DB.
+		Where("id = ?", id).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("client not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
 	if clientExist := config.DB.
 		Where("name = ? AND id = ?", client.Name, id).
 		Find(&domain.Client{}).
@@ -218,11 +251,25 @@ func (c ClientRepo) Update(id string, client domain.Client) (int, error) {
 		return http.StatusNotFound, errors.New("client not found")
 	}
 
+	if err := config.DB.
+		Where("id = ?", id).
+		First(&after).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, domain.AuditEvent{
+		ClientID:   &before.ID,
+		EntityType: enums.AuditEntitiesEnum.Client,
+		EntityID:   before.ID,
+		Action:     enums.AuditActionsEnum.Update,
+	}, before, after)
+
 	return 0, nil
 }
 
 // UpdateAutoApproveOrders Updates auto approve settings
-func (c ClientRepo) UpdateAutoApproveOrders(id string, status bool) (int, error) {
+func (c ClientRepo) UpdateAutoApproveOrders(id string, status bool, actor domain.User) (int, error) {
 	var prevStatus []bool
 
 	config.DB.
@@ -244,6 +291,14 @@ func (c ClientRepo) UpdateAutoApproveOrders(id string, status bool) (int, error)
 		return http.StatusBadRequest, errors.New("can't set the same value")
 	}
 
+	parsedID := uuid.FromStringOrNil(id)
+	saveAudit(actor, domain.AuditEvent{
+		ClientID:   &parsedID,
+		EntityType: enums.AuditEntitiesEnum.Client,
+		EntityID:   parsedID,
+		Action:     enums.AuditActionsEnum.Update,
+	}, map[string]bool{"autoApproveOrders": prevStatus[0]}, map[string]bool{"autoApproveOrders": status})
+
 	if status {
 		_, _ = c.InitAutoApprove(id)
 	} else {
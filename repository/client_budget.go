@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ClientBudgetRepo struct
+type ClientBudgetRepo struct{}
+
+// NewClientBudgetRepo returns pointer to client budget repository
+// with all methods
+func NewClientBudgetRepo() *ClientBudgetRepo {
+	return &ClientBudgetRepo{}
+}
+
+// budgetStatuses are statuses of orders which are paid by client,
+// approved orders stay paid while they are delivered
+var budgetStatuses = []string{
+	enums.OrderStatusTypesEnum.Pending,
+	enums.OrderStatusTypesEnum.Approved,
+	enums.OrderStatusTypesEnum.Preparing,
+	enums.OrderStatusTypesEnum.OutForDelivery,
+	enums.OrderStatusTypesEnum.Delivered,
+}
+
+// Get returns budget of client with company share
+// of orders spent in current month
+func (cb ClientBudgetRepo) Get(clientID string) (models.ClientBudget, int, error) {
+	if clientExist := config.DB.
+		Where("id = ?", clientID).
+		Find(&domain.Client{}).
+		RowsAffected; clientExist == 0 {
+		return models.ClientBudget{}, http.StatusNotFound, errors.New("client not found")
+	}
+
+	var budget domain.ClientBudget
+
+	if err := config.DB.
+		Where("client_id = ?", clientID).
+		First(&budget).
+		Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		return models.ClientBudget{}, http.StatusBadRequest, err
+	}
+
+	from := monthStart(time.Now().UTC())
+	spent, err := budgetSpent(config.DB, "cu.client_id = ?", clientID, from, from.AddDate(0, 1, 0), uuid.Nil)
+
+	if err != nil {
+		return models.ClientBudget{}, http.StatusBadRequest, err
+	}
+
+	result := models.ClientBudget{
+		MonthlyBudget:    budget.MonthlyBudget,
+		UserMonthlyLimit: budget.UserMonthlyLimit,
+		UserDailyLimit:   budget.UserDailyLimit,
+		Month:            from.Format("2006-01"),
+		Spent:            spent,
+	}
+
+	if budget.MonthlyBudget != nil {
+		remaining := *budget.MonthlyBudget - spent
+		result.Remaining = &remaining
+	}
+
+	return result, 0, nil
+}
+
+// Update replaces budget of client
+func (cb ClientBudgetRepo) Update(clientID string, budget domain.ClientBudget) (models.ClientBudget, int, error) {
+	parsedClientID, _ := uuid.FromString(clientID)
+
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Unscoped().
+		Where("client_id = ?", clientID).
+		Delete(&domain.ClientBudget{}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.ClientBudget{}, http.StatusBadRequest, err
+	}
+
+	budget.ClientID = parsedClientID
+
+	if err := tx.Create(&budget).Error; err != nil {
+		tx.Rollback()
+		return models.ClientBudget{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.ClientBudget{}, http.StatusBadRequest, err
+	}
+
+	return cb.Get(clientID)
+}
+
+// checkBudget returns error if company share of order exceeds
+// remaining budget of user's client or limits of user,
+// orderID is excluded from spent sums when order is updated
+func checkBudget(tx *gorm.DB, userID string, date time.Time, companyShare float32, orderID uuid.UUID) error {
+	var budget domain.ClientBudget
+
+	// lock budget row, so concurrent orders of client
+	// can't exceed budget together
+	if err := tx.
+		Set("gorm:query_option", "FOR UPDATE").
+		Where("client_id IN (?)", tx.
+			Model(&domain.ClientUser{}).
+			Select("client_id").
+			Where("user_id = ?", userID).
+			SubQuery()).
+		First(&budget).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	from := monthStart(date)
+	to := from.AddDate(0, 1, 0)
+
+	limits := []struct {
+		limit    *float32
+		where    string
+		value    interface{}
+		from, to time.Time
+		name     string
+	}{
+		{budget.MonthlyBudget, "cu.client_id = ?", budget.ClientID, from, to, "monthly budget of client"},
+		{budget.UserMonthlyLimit, "uo.user_id = ?", userID, from, to, "monthly limit of user"},
+		{budget.UserDailyLimit, "uo.user_id = ?", userID, date, date.AddDate(0, 0, 1), "daily limit of user"},
+	}
+
+	for _, limit := range limits {
+		if limit.limit == nil {
+			continue
+		}
+
+		spent, err := budgetSpent(tx, limit.where, limit.value, limit.from, limit.to, orderID)
+
+		if err != nil {
+			return err
+		}
+
+		if remaining := *limit.limit - spent; companyShare > remaining {
+			if remaining < 0 {
+				remaining = 0
+			}
+			return fmt.Errorf("order exceeds %s, remaining amount is %.2f", limit.name, remaining)
+		}
+	}
+
+	return nil
+}
+
+// budgetSpent returns company share of orders
+// with budget statuses placed in [from, to)
+func budgetSpent(db *gorm.DB, where string, value interface{}, from, to time.Time, orderID uuid.UUID) (float32, error) {
+	var spent float32
+
+	if err := db.
+		Model(&domain.Order{}).
+		Select("coalesce(sum(orders.company_share), 0)").
+		Joins("left join user_orders uo on uo.order_id = orders.id").
+		Joins("left join client_users cu on cu.user_id = uo.user_id").
+		Where(where, value).
+		Where("orders.date >= ? AND orders.date < ? AND orders.status IN (?) AND orders.id != ?",
+			from, to, budgetStatuses, orderID).
+		Row().
+		Scan(&spent); err != nil {
+		return 0, err
+	}
+
+	return spent, nil
+}
+
+// monthStart returns first day of month of provided date
+func monthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
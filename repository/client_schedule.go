@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/Aiscom-LLC/meals-api/repository/models"
 	"net/http"
 	"sort"
@@ -116,7 +117,7 @@ func (cs ClientScheduleRepo) Get(clientID string) ([]models.ClientSchedulesCater
 }
 
 // Update updates client's schedule with new values
-func (cs ClientScheduleRepo) Update(clientID, scheduleID string, isWorking *bool, newSchedule domain.ClientSchedule) (models.ClientSchedulesCatering, int, error) {
+func (cs ClientScheduleRepo) Update(clientID, scheduleID string, isWorking *bool, newSchedule domain.ClientSchedule, actor domain.User) (models.ClientSchedulesCatering, int, error) {
 	var client domain.Client
 	var clientsOldSchedule domain.ClientSchedule
 	var cateringSchedule domain.CateringSchedule
@@ -255,6 +256,18 @@ func (cs ClientScheduleRepo) Update(clientID, scheduleID string, isWorking *bool
 		newSchedule.IsWorking = *isWorking
 	}
 
+	var before domain.ClientSchedule
+
+	if err := config.DB.
+		Where("id = ?", scheduleID).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return models.ClientSchedulesCatering{}, http.StatusNotFound, errors.New("schedule not found")
+		}
+		return models.ClientSchedulesCatering{}, http.StatusBadRequest, err
+	}
+
 	config.DB.
 		Model(&domain.ClientSchedule{}).
 		Where("id = ?", scheduleID).
@@ -266,5 +279,37 @@ func (cs ClientScheduleRepo) Update(clientID, scheduleID string, isWorking *bool
 		}).
 		Scan(&updatedSchedule)
 
+	saveAudit(actor, domain.AuditEvent{
+		ClientID:   &client.ID,
+		EntityType: enums.AuditEntitiesEnum.ClientSchedule,
+		EntityID:   before.ID,
+		Action:     enums.AuditActionsEnum.Update,
+	}, before, updatedSchedule)
+
 	return updatedSchedule, 0, nil
 }
+
+// GetWithCatering returns client's schedules
+// joined with catering's schedules for the same days
+func (cs ClientScheduleRepo) GetWithCatering(clientID string) ([]models.ClientSchedulesCatering, int, error) {
+	var schedules []models.ClientSchedulesCatering
+
+	if err := config.DB.
+		Model(&domain.ClientSchedule{}).
+		Select("client_schedules.*, cs.start as catering_start, cs.end as catering_end,"+
+			" cs.is_working as catering_is_working").
+		Joins("left join clients c on client_schedules.client_id = c.id").
+		Joins("left join catering_schedules cs on cs.catering_id = c.catering_id and cs.day = client_schedules.day").
+		Where("client_schedules.client_id = ?", clientID).
+		Order("client_schedules.day").
+		Scan(&schedules).
+		Error; err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if len(schedules) == 0 {
+		return nil, http.StatusNotFound, errors.New("client schedule not found")
+	}
+
+	return schedules, 0, nil
+}
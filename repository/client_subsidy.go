@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ClientSubsidyRepo struct
+type ClientSubsidyRepo struct{}
+
+// NewClientSubsidyRepo returns pointer to client subsidy repository
+// with all methods
+func NewClientSubsidyRepo() *ClientSubsidyRepo {
+	return &ClientSubsidyRepo{}
+}
+
+// Get returns subsidy of client,
+// client without subsidy pays full order total
+func (cs ClientSubsidyRepo) Get(clientID string) (models.ClientSubsidy, int, error) {
+	if clientExist := config.DB.
+		Where("id = ?", clientID).
+		Find(&domain.Client{}).
+		RowsAffected; clientExist == 0 {
+		return models.ClientSubsidy{}, http.StatusNotFound, errors.New("client not found")
+	}
+
+	subsidy, categories, err := clientSubsidy(config.DB, clientID)
+
+	if err != nil {
+		return models.ClientSubsidy{}, http.StatusBadRequest, err
+	}
+
+	return models.ClientSubsidy{
+		Type:       subsidy.Type,
+		Amount:     subsidy.Amount,
+		Categories: categories,
+	}, 0, nil
+}
+
+// clientSubsidy returns subsidy of client and its category caps
+func clientSubsidy(db *gorm.DB, clientID string) (domain.ClientSubsidy, []domain.ClientSubsidyCategory, error) {
+	var subsidy domain.ClientSubsidy
+	categories := make([]domain.ClientSubsidyCategory, 0)
+
+	if err := db.
+		Where("client_id = ?", clientID).
+		First(&subsidy).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return domain.ClientSubsidy{Type: enums.SubsidyTypesEnum.Full}, categories, nil
+		}
+		return domain.ClientSubsidy{}, nil, err
+	}
+
+	if err := db.
+		Where("client_id = ?", clientID).
+		Find(&categories).
+		Error; err != nil {
+		return domain.ClientSubsidy{}, nil, err
+	}
+
+	return subsidy, categories, nil
+}
+
+// Update replaces subsidy of client with its category caps,
+// it's applied to orders created or updated after that
+func (cs ClientSubsidyRepo) Update(clientID string, subsidy domain.ClientSubsidy, categories []domain.ClientSubsidyCategory) (models.ClientSubsidy, int, error) {
+	parsedClientID, _ := uuid.FromString(clientID)
+
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Unscoped().
+		Where("client_id = ?", clientID).
+		Delete(&domain.ClientSubsidy{}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.ClientSubsidy{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.
+		Unscoped().
+		Where("client_id = ?", clientID).
+		Delete(&domain.ClientSubsidyCategory{}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.ClientSubsidy{}, http.StatusBadRequest, err
+	}
+
+	subsidy.ClientID = parsedClientID
+
+	if err := tx.Create(&subsidy).Error; err != nil {
+		tx.Rollback()
+		return models.ClientSubsidy{}, http.StatusBadRequest, err
+	}
+
+	for i := range categories {
+		categories[i].ClientID = parsedClientID
+
+		if err := tx.Create(&categories[i]).Error; err != nil {
+			tx.Rollback()
+			return models.ClientSubsidy{}, http.StatusBadRequest, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.ClientSubsidy{}, http.StatusBadRequest, err
+	}
+
+	return cs.Get(clientID)
+}
+
+// orderShares splits order total of user into company and employee shares
+// by subsidy of user's client, users without client pay nothing
+func orderShares(db *gorm.DB, userID string, orderDishes []domain.OrderDishes, total float32) (float32, float32, error) {
+	var clientIDs []string
+
+	db.
+		Model(&domain.ClientUser{}).
+		Where("user_id = ?", userID).
+		Pluck("client_id", &clientIDs)
+
+	if len(clientIDs) == 0 {
+		return total, 0, nil
+	}
+
+	subsidy, categories, err := clientSubsidy(db, clientIDs[0])
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var companyShare float32
+
+	switch subsidy.Type {
+	case enums.SubsidyTypesEnum.Fixed:
+		companyShare = subsidy.Amount
+	case enums.SubsidyTypesEnum.Percent:
+		companyShare = total * subsidy.Amount / 100
+	case enums.SubsidyTypesEnum.Category:
+		categoryTotals := make(map[uuid.UUID]float32)
+
+		for _, dish := range orderDishes {
+			categoryTotals[dish.CategoryID] += dish.Price * float32(dish.Amount)
+		}
+
+		for _, category := range categories {
+			companyShare += float32(math.Min(float64(categoryTotals[category.CategoryID]), float64(category.Amount)))
+		}
+	default:
+		companyShare = total
+	}
+
+	companyShare = float32(math.Round(math.Min(float64(companyShare), float64(total))*100) / 100)
+
+	return companyShare, total - companyShare, nil
+}
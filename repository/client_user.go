@@ -12,6 +12,7 @@ import (
 	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
 )
 
 // ClientUserRepo struct
@@ -23,11 +24,32 @@ func NewClientUserRepo() *ClientUserRepo {
 	return &ClientUserRepo{}
 }
 
-func (cur *ClientUserRepo) Add(clientUser domain.ClientUser) error {
-	err := config.DB.
+// clientUserAuditEvent returns audit event of action with user of client
+func clientUserAuditEvent(clientID, userID uuid.UUID, action string) domain.AuditEvent {
+	return domain.AuditEvent{
+		ClientID:   &clientID,
+		EntityType: enums.AuditEntitiesEnum.ClientUser,
+		EntityID:   userID,
+		Action:     action,
+	}
+}
+
+func (cur *ClientUserRepo) Add(clientUser domain.ClientUser, actor domain.User) error {
+	if err := config.DB.
 		Create(&clientUser).
-		Error
-	return err
+		Error; err != nil {
+		return err
+	}
+
+	after, err := UserRepo{}.GetByID(clientUser.UserID.String())
+
+	if err != nil {
+		return err
+	}
+
+	saveAudit(actor, clientUserAuditEvent(clientUser.ClientID, clientUser.UserID, enums.AuditActionsEnum.Create), nil, after)
+
+	return nil
 }
 
 func (cur *ClientUserRepo) Get(clientID, userRole string, pagination url.PaginationQuery, filters url.UserFilterQuery) ([]models.GetClientUser, int, int, error) {
@@ -90,9 +112,9 @@ func (cur *ClientUserRepo) Get(clientID, userRole string, pagination url.Paginat
 	return users, total, 0, nil
 }
 
-func (cur *ClientUserRepo) Delete(clientID, ctxUserRole string, user domain.User) (int, error) {
+func (cur *ClientUserRepo) Delete(clientID string, user domain.User, actor domain.User) (int, error) {
 	var totalUsers int
-	if ctxUserRole == enums.UserRoleEnum.CateringAdmin || ctxUserRole == enums.UserRoleEnum.ClientAdmin {
+	if actor.Role == enums.UserRoleEnum.CateringAdmin || actor.Role == enums.UserRoleEnum.ClientAdmin {
 		config.DB.
 			Table("users as u").
 			Select("u.*, cu.floor").
@@ -107,6 +129,15 @@ func (cur *ClientUserRepo) Delete(clientID, ctxUserRole string, user domain.User
 		}
 	}
 
+	before, err := UserRepo{}.GetByID(user.ID.String())
+
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("user not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
 	if userExist := config.DB.
 		Table("users as u").
 		Where("u.id = ?", user.ID).
@@ -114,10 +145,22 @@ func (cur *ClientUserRepo) Delete(clientID, ctxUserRole string, user domain.User
 		RowsAffected; userExist == 0 {
 		return http.StatusNotFound, errors.New("user not found")
 	}
+
+	saveAudit(actor, clientUserAuditEvent(uuid.FromStringOrNil(clientID), user.ID, enums.AuditActionsEnum.Delete), before, nil)
+
 	return 0, nil
 }
 
-func (cur *ClientUserRepo) Update(user *domain.User, floor *int) (int, error) {
+func (cur *ClientUserRepo) Update(clientID string, user *domain.User, floor *int, actor domain.User) (int, error) {
+	before, err := UserRepo{}.GetByID(user.ID.String())
+
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("user not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
 	if userExist := config.DB.
 		Where("id = ? AND email = ?", user.ID, user.Email).
 		Find(&domain.User{}).
@@ -150,5 +193,13 @@ func (cur *ClientUserRepo) Update(user *domain.User, floor *int) (int, error) {
 			Update("floor", *floor)
 	}
 
+	after, err := UserRepo{}.GetByID(user.ID.String())
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, clientUserAuditEvent(uuid.FromStringOrNil(clientID), user.ID, enums.AuditActionsEnum.Update), before, after)
+
 	return 0, nil
 }
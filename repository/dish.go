@@ -5,7 +5,9 @@ import (
 	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
 	"net/http"
 	"time"
 )
@@ -21,7 +23,7 @@ func NewDishRepo() *DishRepo {
 
 // Add creates new dish entity
 // returns error or nil
-func (d DishRepo) Add(cateringID string, dish *domain.Dish) error {
+func (d DishRepo) Add(cateringID string, dish *domain.Dish, actor domain.User) error {
 	//if dishExist := config.DB.
 	//	Where("catering_id = ? AND category_id = ? AND name = ?", cateringID, dish.CategoryID, dish.Name).
 	//	Find(dish).
@@ -29,26 +31,70 @@ func (d DishRepo) Add(cateringID string, dish *domain.Dish) error {
 	//	return errors.New("this dish already exist in that category")
 	//}
 
-	if err := config.DB.Create(dish).Error; err != nil {
+	tx := config.DB.Begin()
+
+	if err := tx.Create(dish).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := setDishLabels(tx, dish.CateringID, dish.ID, dish.LabelIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return err
 	}
 
+	dish.LabelIDs = nil
+
+	if err := fillDishLabels([]*domain.Dish{dish}); err != nil {
+		return err
+	}
+
+	saveAudit(actor, dishAuditEvent(dish.CateringID, dish.ID, enums.AuditActionsEnum.Create), nil, dish)
+
 	return nil
 }
 
+// dishAuditEvent returns audit event of action with dish of catering
+func dishAuditEvent(cateringID, dishID uuid.UUID, action string) domain.AuditEvent {
+	return domain.AuditEvent{
+		CateringID: &cateringID,
+		EntityType: enums.AuditEntitiesEnum.Dish,
+		EntityID:   dishID,
+		Action:     action,
+	}
+}
+
 // Delete soft delete of entity
 // returns error or nil
-func (d DishRepo) Delete(path url.PathDish) error {
+func (d DishRepo) Delete(path url.PathDish, actor domain.User) error {
+	var before domain.Dish
+
 	if cateringNotExist := config.DB.Where("id = ?", path.CateringID).
 		Find(&domain.Catering{}).RecordNotFound(); cateringNotExist {
 		return errors.New("catering with that ID doesn't exist")
 	}
 
+	if err := config.DB.
+		Where("catering_id = ? AND id = ?", path.CateringID, path.DishID).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return errors.New("dish not found")
+		}
+		return err
+	}
+
 	if rows := config.DB.Where("catering_id = ? AND id = ?", path.CateringID, path.DishID).
 		Delete(&domain.Dish{}).RowsAffected; rows == 0 {
 		return errors.New("dish not found")
 	}
 
+	saveAudit(actor, dishAuditEvent(before.CateringID, before.ID, enums.AuditActionsEnum.Delete), before, nil)
+
 	return nil
 }
 
@@ -98,12 +144,16 @@ func (d DishRepo) FindByID(cateringID, id string) (domain.Dish, int, error) {
 		dish.Images = make([]domain.ImageArray, 0)
 	}
 
+	if err := fillDishLabels([]*domain.Dish{&dish}); err != nil {
+		return domain.Dish{}, http.StatusBadRequest, err
+	}
+
 	return dish, 0, nil
 }
 
-// Get list of dishes
+// Get list of dishes filtered by labels
 // returns array of dishes and error or nil and status code
-func (d DishRepo) Get(cateringID, categoryID string) ([]domain.Dish, int, error) {
+func (d DishRepo) Get(cateringID, categoryID string, filter url.LabelFilterQuery) ([]domain.Dish, int, error) {
 	var dishes []domain.Dish
 
 	if cateringNotExist := config.DB.
@@ -121,12 +171,15 @@ func (d DishRepo) Get(cateringID, categoryID string) ([]domain.Dish, int, error)
 		return nil, http.StatusNotFound, errors.New("category with that ID doesn't exist")
 	}
 
-	err := config.DB.
+	err := labelFilter(config.DB, "dishes.id", filter).
 		Where("catering_id = ? AND category_id = ?", cateringID, categoryID).
 		Find(&dishes).
 		Error
 
+	labelDishes := make([]*domain.Dish, len(dishes))
+
 	for i := range dishes {
+		labelDishes[i] = &dishes[i]
 		var imagesArray []domain.ImageArray
 		config.DB.
 			Model(&domain.Image{}).
@@ -138,12 +191,18 @@ func (d DishRepo) Get(cateringID, categoryID string) ([]domain.Dish, int, error)
 		dishes[i].Images = imagesArray
 	}
 
+	if err == nil {
+		err = fillDishLabels(labelDishes)
+	}
+
 	return dishes, 0, err
 }
 
 // Update updates entity
 // returns error or nil and status code
-func (d DishRepo) Update(path url.PathDish, dish domain.Dish) (int, error) {
+func (d DishRepo) Update(path url.PathDish, dish domain.Dish, actor domain.User) (int, error) {
+	var before, after domain.Dish
+
 	if cateringNotExist := config.DB.
 		Where("id = ?", path.CateringID).
 		Find(&domain.Catering{}).
@@ -159,11 +218,56 @@ func (d DishRepo) Update(path url.PathDish, dish domain.Dish) (int, error) {
 		return http.StatusNotFound, errors.New("dish category not found")
 	}
 
-	if result := config.DB.Model(&dish).
+	if err := config.DB.
+		Where("id = ?", path.DishID).
+		First(&before).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("dish not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
+	if err := fillDishLabels([]*domain.Dish{&before}); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	tx := config.DB.Begin()
+
+	if result := tx.Model(&dish).
 		Where("id = ? AND category_id = ?", path.DishID, dish.CategoryID).
 		Update(&dish).RowsAffected; result == 0 {
+		tx.Rollback()
 		return http.StatusNotFound, errors.New("dish not found")
 	}
 
+	// labels are kept when they aren't provided
+	if dish.LabelIDs != nil {
+		if err := setDishLabels(tx, before.CateringID, before.ID, dish.LabelIDs); err != nil {
+			tx.Rollback()
+			if err.Error() == "label not found" {
+				return http.StatusNotFound, err
+			}
+			return http.StatusBadRequest, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := config.DB.
+		Where("id = ?", path.DishID).
+		First(&after).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := fillDishLabels([]*domain.Dish{&after}); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, dishAuditEvent(after.CateringID, after.ID, enums.AuditActionsEnum.Update), before, after)
+
 	return 0, nil
 }
@@ -0,0 +1,49 @@
+package enums
+
+type auditEntityEnum struct {
+	Order            string
+	Catering         string
+	CateringUser     string
+	CateringSchedule string
+	Client           string
+	ClientUser       string
+	ClientSchedule   string
+	Category         string
+	Dish             string
+	Meal             string
+	Invoice          string
+	Label            string
+	MenuTemplate     string
+}
+
+// AuditEntitiesEnum enum
+var AuditEntitiesEnum = auditEntityEnum{
+	Order:            "order",
+	Catering:         "catering",
+	CateringUser:     "catering_user",
+	CateringSchedule: "catering_schedule",
+	Client:           "client",
+	ClientUser:       "client_user",
+	ClientSchedule:   "client_schedule",
+	Category:         "category",
+	Dish:             "dish",
+	Meal:             "meal",
+	Invoice:          "invoice",
+	Label:            "label",
+	MenuTemplate:     "menu_template",
+}
+
+type auditActionEnum struct {
+	Create       string
+	Update       string
+	Delete       string
+	StatusChange string
+}
+
+// AuditActionsEnum enum
+var AuditActionsEnum = auditActionEnum{
+	Create:       "create",
+	Update:       "update",
+	Delete:       "delete",
+	StatusChange: "status_change",
+}
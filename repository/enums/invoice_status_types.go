@@ -0,0 +1,14 @@
+package enums
+
+type invoiceStatusEnum struct {
+	Draft  string
+	Issued string
+	Paid   string
+}
+
+// InvoiceStatusTypesEnum enum
+var InvoiceStatusTypesEnum = invoiceStatusEnum{
+	Draft:  "draft",
+	Issued: "issued",
+	Paid:   "paid",
+}
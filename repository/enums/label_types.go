@@ -0,0 +1,12 @@
+package enums
+
+type labelEnum struct {
+	Allergen string
+	Dietary  string
+}
+
+// LabelTypesEnum enum
+var LabelTypesEnum = labelEnum{
+	Allergen: "allergen",
+	Dietary:  "dietary",
+}
@@ -0,0 +1,14 @@
+package enums
+
+type orderChangeFieldEnum struct {
+	Dish    string
+	Comment string
+	Total   string
+}
+
+// OrderChangeFieldsEnum enum
+var OrderChangeFieldsEnum = orderChangeFieldEnum{
+	Dish:    "dish",
+	Comment: "comment",
+	Total:   "total",
+}
@@ -4,11 +4,22 @@ type orderStatusEnum struct {
 	Approved string
 	Pending  string
 	Canceled string
+	Rejected string
+	// delivery statuses of approved orders
+	Preparing      string
+	OutForDelivery string
+	Delivered      string
+	Failed         string
 }
 
 // OrderStatusTypesEnum enum
 var OrderStatusTypesEnum = orderStatusEnum{
-	Approved: "approved",
-	Pending:  "pending",
-	Canceled: "canceled",
+	Approved:       "approved",
+	Pending:        "pending",
+	Canceled:       "canceled",
+	Rejected:       "rejected",
+	Preparing:      "preparing",
+	OutForDelivery: "out_for_delivery",
+	Delivered:      "delivered",
+	Failed:         "failed",
 }
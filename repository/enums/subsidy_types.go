@@ -0,0 +1,16 @@
+package enums
+
+type subsidyEnum struct {
+	Full     string
+	Fixed    string
+	Percent  string
+	Category string
+}
+
+// SubsidyTypesEnum enum
+var SubsidyTypesEnum = subsidyEnum{
+	Full:     "full",
+	Fixed:    "fixed",
+	Percent:  "percent",
+	Category: "category",
+}
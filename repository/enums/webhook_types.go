@@ -0,0 +1,38 @@
+package enums
+
+type webhookEventEnum struct {
+	OrderCreated         string
+	OrderUpdated         string
+	OrderCanceled        string
+	OrderApproved        string
+	OrderRejected        string
+	OrderDeliveryUpdated string
+	OrderDelivered       string
+	MealPublished        string
+}
+
+// WebhookEventsEnum enum
+// rejected and delivery updated events are only streamed to live order board
+var WebhookEventsEnum = webhookEventEnum{
+	OrderCreated:         "order.created",
+	OrderUpdated:         "order.updated",
+	OrderCanceled:        "order.canceled",
+	OrderApproved:        "order.approved",
+	OrderRejected:        "order.rejected",
+	OrderDeliveryUpdated: "order.delivery_updated",
+	OrderDelivered:       "order.delivered",
+	MealPublished:        "meal.published",
+}
+
+type webhookDeliveryStatusEnum struct {
+	Pending   string
+	Delivered string
+	Failed    string
+}
+
+// WebhookDeliveryStatusTypesEnum enum
+var WebhookDeliveryStatusTypesEnum = webhookDeliveryStatusEnum{
+	Pending:   "pending",
+	Delivered: "delivered",
+	Failed:    "failed",
+}
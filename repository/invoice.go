@@ -0,0 +1,359 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// InvoiceRepo struct
+type InvoiceRepo struct{}
+
+// NewInvoiceRepo returns pointer to invoice repository
+// with all methods
+func NewInvoiceRepo() *InvoiceRepo {
+	return &InvoiceRepo{}
+}
+
+// invoiceStatuses are statuses of orders which are billed,
+// failed deliveries aren't billed
+var invoiceStatuses = []string{
+	enums.OrderStatusTypesEnum.Approved,
+	enums.OrderStatusTypesEnum.Preparing,
+	enums.OrderStatusTypesEnum.OutForDelivery,
+	enums.OrderStatusTypesEnum.Delivered,
+}
+
+// invoiceAuditEvent returns audit event of action with invoice of client
+func invoiceAuditEvent(clientID string, invoiceID uuid.UUID, action string) domain.AuditEvent {
+	parsedClientID := uuid.FromStringOrNil(clientID)
+
+	return domain.AuditEvent{
+		ClientID:   &parsedClientID,
+		EntityType: enums.AuditEntitiesEnum.Invoice,
+		EntityID:   invoiceID,
+		Action:     action,
+	}
+}
+
+// Add creates draft invoice of client for period
+// with items built from approved orders
+func (i InvoiceRepo) Add(cateringID, clientID string, from, to time.Time, discount float32, actor domain.User) (models.Invoice, int, error) {
+	var overlapExist int
+	var shares struct {
+		CompanyShare  float32
+		EmployeeShare float32
+	}
+	var items []domain.InvoiceItem
+
+	if clientExist := config.DB.
+		Where("id = ? AND catering_id = ?", clientID, cateringID).
+		Find(&domain.Client{}).
+		RowsAffected; clientExist == 0 {
+		return models.Invoice{}, http.StatusNotFound, errors.New("client not found")
+	}
+
+	tx := config.DB.Begin()
+
+	// lock client row, so two invoices for the same period
+	// can't be created at once
+	if err := tx.
+		Set("gorm:query_option", "FOR UPDATE").
+		Where("id = ?", clientID).
+		First(&domain.Client{}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	tx.
+		Model(&domain.Invoice{}).
+		Where("client_id = ? AND period_from <= ? AND period_to >= ?", clientID, to, from).
+		Count(&overlapExist)
+
+	if overlapExist != 0 {
+		tx.Rollback()
+		return models.Invoice{}, http.StatusBadRequest, errors.New("invoice for that period already exists")
+	}
+
+	orders := tx.
+		Table("orders as o").
+		Joins("left join user_orders uo on uo.order_id = o.id").
+		Joins("left join client_users cu on cu.user_id = uo.user_id").
+		Where("cu.client_id = ? AND o.date BETWEEN ? AND ? AND o.status IN (?) AND o.deleted_at IS NULL",
+			clientID, from, to, invoiceStatuses)
+
+	if err := orders.
+		Joins("left join order_dishes od on od.order_id = o.id").
+		Where("od.deleted_at IS NULL").
+		Select("o.date, od.dish_id, od.name, od.price, sum(od.amount) as amount," +
+			" sum(od.amount) * od.price as total").
+		Group("o.date, od.dish_id, od.name, od.price").
+		Order("o.date, od.name").
+		Scan(&items).
+		Error; err != nil {
+		tx.Rollback()
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	if err := orders.
+		Select("coalesce(sum(o.company_share), 0) as company_share," +
+			" coalesce(sum(o.employee_share), 0) as employee_share").
+		Scan(&shares).
+		Error; err != nil {
+		tx.Rollback()
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	var subtotal float32
+
+	for _, item := range items {
+		subtotal += item.Total
+	}
+
+	if discount > subtotal {
+		tx.Rollback()
+		return models.Invoice{}, http.StatusBadRequest, errors.New("discount can't be more than subtotal")
+	}
+
+	invoice := domain.Invoice{
+		CateringID:    uuid.FromStringOrNil(cateringID),
+		ClientID:      uuid.FromStringOrNil(clientID),
+		PeriodFrom:    from,
+		PeriodTo:      to,
+		Status:        enums.InvoiceStatusTypesEnum.Draft,
+		Subtotal:      subtotal,
+		Discount:      discount,
+		Total:         subtotal - discount,
+		CompanyShare:  shares.CompanyShare,
+		EmployeeShare: shares.EmployeeShare,
+	}
+
+	if err := tx.Create(&invoice).Error; err != nil {
+		tx.Rollback()
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	for idx := range items {
+		items[idx].InvoiceID = invoice.ID
+
+		if err := tx.Create(&items[idx]).Error; err != nil {
+			tx.Rollback()
+			return models.Invoice{}, http.StatusBadRequest, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	result, code, err := i.GetByID(cateringID, clientID, invoice.ID.String())
+
+	if err != nil {
+		return models.Invoice{}, code, err
+	}
+
+	saveAudit(actor, invoiceAuditEvent(clientID, invoice.ID, enums.AuditActionsEnum.Create), nil, result)
+
+	return result, 0, nil
+}
+
+// Get returns page of invoices of client without items
+func (i InvoiceRepo) Get(cateringID, clientID string, query url.PaginationQuery) ([]models.Invoice, int, error) {
+	var invoices []models.Invoice
+	var total int
+
+	invoicesQuery := config.DB.
+		Model(&domain.Invoice{}).
+		Joins("left join clients c on c.id = invoices.client_id").
+		Where("invoices.catering_id = ? AND invoices.client_id = ?", cateringID, clientID)
+
+	invoicesQuery.Count(&total)
+
+	if err := invoicesQuery.
+		Select("invoices.*, c.name as client_name").
+		Order("invoices.period_from desc").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Scan(&invoices).
+		Error; err != nil {
+		return nil, 0, err
+	}
+
+	if invoices == nil {
+		invoices = make([]models.Invoice, 0)
+	}
+
+	return invoices, total, nil
+}
+
+// GetByID returns invoice of client with its items
+func (i InvoiceRepo) GetByID(cateringID, clientID, id string) (models.Invoice, int, error) {
+	var invoice models.Invoice
+
+	if err := config.DB.
+		Model(&domain.Invoice{}).
+		Select("invoices.*, c.name as client_name").
+		Joins("left join clients c on c.id = invoices.client_id").
+		Where("invoices.catering_id = ? AND invoices.client_id = ? AND invoices.id = ?", cateringID, clientID, id).
+		Scan(&invoice).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return models.Invoice{}, http.StatusNotFound, errors.New("invoice not found")
+		}
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	if err := config.DB.
+		Where("invoice_id = ?", invoice.ID).
+		Order("date, name").
+		Find(&invoice.Items).
+		Error; err != nil {
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	return invoice, 0, nil
+}
+
+// Update changes status or discount of invoice,
+// issued invoice gets next number of catering
+func (i InvoiceRepo) Update(cateringID, clientID, id string, body models.UpdateInvoice, actor domain.User) (models.Invoice, int, error) {
+	var invoice domain.Invoice
+
+	before, code, err := i.GetByID(cateringID, clientID, id)
+
+	if err != nil {
+		return models.Invoice{}, code, err
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Set("gorm:query_option", "FOR UPDATE").
+		Where("catering_id = ? AND client_id = ? AND id = ?", cateringID, clientID, id).
+		First(&invoice).
+		Error; err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return models.Invoice{}, http.StatusNotFound, errors.New("invoice not found")
+		}
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if body.Discount != nil {
+		if invoice.Status != enums.InvoiceStatusTypesEnum.Draft {
+			tx.Rollback()
+			return models.Invoice{}, http.StatusBadRequest, errors.New("only draft invoice can be changed")
+		}
+
+		if *body.Discount > invoice.Subtotal {
+			tx.Rollback()
+			return models.Invoice{}, http.StatusBadRequest, errors.New("discount can't be more than subtotal")
+		}
+
+		updates["discount"] = *body.Discount
+		updates["total"] = invoice.Subtotal - *body.Discount
+	}
+
+	if body.Status != nil && *body.Status != invoice.Status {
+		now := time.Now().UTC()
+
+		switch {
+		case invoice.Status == enums.InvoiceStatusTypesEnum.Draft && *body.Status == enums.InvoiceStatusTypesEnum.Issued:
+			var number int
+
+			// numbers are given under lock of catering,
+			// so issued invoices are numbered without gaps
+			if err := tx.
+				Set("gorm:query_option", "FOR UPDATE").
+				Where("id = ?", cateringID).
+				First(&domain.Catering{}).
+				Error; err != nil {
+				tx.Rollback()
+				return models.Invoice{}, http.StatusBadRequest, err
+			}
+
+			if err := tx.
+				Model(&domain.Invoice{}).
+				Unscoped().
+				Where("catering_id = ?", cateringID).
+				Select("coalesce(max(number), 0) + 1").
+				Row().
+				Scan(&number); err != nil {
+				tx.Rollback()
+				return models.Invoice{}, http.StatusBadRequest, err
+			}
+
+			updates["number"] = number
+			updates["issued_at"] = now
+		case invoice.Status == enums.InvoiceStatusTypesEnum.Issued && *body.Status == enums.InvoiceStatusTypesEnum.Paid:
+			updates["paid_at"] = now
+		default:
+			tx.Rollback()
+			return models.Invoice{}, http.StatusBadRequest, errors.New("invoice can't be moved from " +
+				invoice.Status + " to " + *body.Status)
+		}
+
+		updates["status"] = *body.Status
+	}
+
+	if len(updates) != 0 {
+		if err := tx.
+			Model(&invoice).
+			Updates(updates).
+			Error; err != nil {
+			tx.Rollback()
+			return models.Invoice{}, http.StatusBadRequest, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.Invoice{}, http.StatusBadRequest, err
+	}
+
+	result, code, err := i.GetByID(cateringID, clientID, id)
+
+	if err != nil {
+		return models.Invoice{}, code, err
+	}
+
+	saveAudit(actor, invoiceAuditEvent(clientID, invoice.ID, enums.AuditActionsEnum.Update), before, result)
+
+	return result, 0, nil
+}
+
+// Delete removes draft invoice with its items
+func (i InvoiceRepo) Delete(cateringID, clientID, id string, actor domain.User) (int, error) {
+	before, code, err := i.GetByID(cateringID, clientID, id)
+
+	if err != nil {
+		return code, err
+	}
+
+	invoice := before.Invoice
+
+	if invoice.Status != enums.InvoiceStatusTypesEnum.Draft {
+		return http.StatusBadRequest, errors.New("only draft invoice can be deleted")
+	}
+
+	if err := config.DB.
+		Unscoped().
+		Delete(&invoice).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, invoiceAuditEvent(clientID, invoice.ID, enums.AuditActionsEnum.Delete), before, nil)
+
+	return 0, nil
+}
@@ -0,0 +1,415 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// LabelRepo struct
+type LabelRepo struct{}
+
+// NewLabelRepo returns pointer to label repository
+// with all methods
+func NewLabelRepo() *LabelRepo {
+	return &LabelRepo{}
+}
+
+// dishLabel struct used for scanning labels of dishes
+type dishLabel struct {
+	DishID uuid.UUID `gorm:"column:dish_id"`
+	domain.LabelArray
+}
+
+// labelAuditEvent returns audit event of action with label of catering
+func labelAuditEvent(cateringID, labelID uuid.UUID, action string) domain.AuditEvent {
+	return domain.AuditEvent{
+		CateringID: &cateringID,
+		EntityType: enums.AuditEntitiesEnum.Label,
+		EntityID:   labelID,
+		Action:     action,
+	}
+}
+
+// labelNames splits comma separated names of labels
+// to lowercase names without empty ones
+func labelNames(value string) []string {
+	var names []string
+
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// labelFilter adds label filters to query of dishes,
+// column is column of dish id in query
+func labelFilter(db *gorm.DB, column string, filter url.LabelFilterQuery) *gorm.DB {
+	labelsQuery := "SELECT dl.dish_id FROM dish_labels dl" +
+		" JOIN labels l ON l.id = dl.label_id" +
+		" WHERE dl.deleted_at IS NULL AND l.deleted_at IS NULL AND l.type = ? AND lower(l.name) IN (?)"
+
+	if allergens := labelNames(filter.ExcludeAllergens); len(allergens) != 0 {
+		db = db.Where(column+" NOT IN ("+labelsQuery+")", enums.LabelTypesEnum.Allergen, allergens)
+	}
+
+	if tags := labelNames(filter.Tags); len(tags) != 0 {
+		db = db.Where(column+" IN ("+labelsQuery+" GROUP BY dl.dish_id HAVING count(DISTINCT l.id) = ?)",
+			enums.LabelTypesEnum.Dietary, tags, len(tags))
+	}
+
+	return db
+}
+
+// uniqueLabelIDs returns label ids without duplicates
+// and error if any of them isn't label of catering
+func uniqueLabelIDs(db *gorm.DB, cateringID uuid.UUID, labelIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	var count int
+	seen := make(map[uuid.UUID]bool)
+
+	for _, id := range labelIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	if err := db.
+		Model(&domain.Label{}).
+		Where("catering_id = ? AND id IN (?)", cateringID, ids).
+		Count(&count).
+		Error; err != nil {
+		return nil, err
+	}
+
+	if count != len(ids) {
+		return nil, errors.New("label not found")
+	}
+
+	return ids, nil
+}
+
+// setDishLabels replaces labels of dish with provided labels of catering
+func setDishLabels(db *gorm.DB, cateringID, dishID uuid.UUID, labelIDs []uuid.UUID) error {
+	ids, err := uniqueLabelIDs(db, cateringID, labelIDs)
+
+	if err != nil {
+		return err
+	}
+
+	if err := db.
+		Unscoped().
+		Where("dish_id = ?", dishID).
+		Delete(&domain.DishLabel{}).
+		Error; err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := db.Create(&domain.DishLabel{
+			DishID:  dishID,
+			LabelID: id,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getDishLabels returns labels of provided dishes by dish id
+func getDishLabels(dishIDs []uuid.UUID) (map[uuid.UUID][]domain.LabelArray, error) {
+	var rows []dishLabel
+	labels := make(map[uuid.UUID][]domain.LabelArray)
+
+	if len(dishIDs) == 0 {
+		return labels, nil
+	}
+
+	if err := config.DB.
+		Table("dish_labels as dl").
+		Select("dl.dish_id, l.id, l.name, l.type").
+		Joins("join labels l on l.id = dl.label_id").
+		Where("dl.dish_id IN (?) AND dl.deleted_at IS NULL AND l.deleted_at IS NULL", dishIDs).
+		Order("l.type, l.name").
+		Scan(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		labels[row.DishID] = append(labels[row.DishID], row.LabelArray)
+	}
+
+	return labels, nil
+}
+
+// fillDishLabels sets labels of provided dishes,
+// dish without labels gets empty list
+func fillDishLabels(dishes []*domain.Dish) error {
+	dishIDs := make([]uuid.UUID, len(dishes))
+
+	for i := range dishes {
+		dishIDs[i] = dishes[i].ID
+	}
+
+	labels, err := getDishLabels(dishIDs)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range dishes {
+		dishes[i].Labels = labels[dishes[i].ID]
+
+		if dishes[i].Labels == nil {
+			dishes[i].Labels = make([]domain.LabelArray, 0)
+		}
+	}
+
+	return nil
+}
+
+// Add creates label for catering
+// returns error and status code
+func (l LabelRepo) Add(label *domain.Label, actor domain.User) (int, error) {
+	if cateringNotExist := config.DB.
+		Where("id = ?", label.CateringID).
+		Find(&domain.Catering{}).
+		RecordNotFound(); cateringNotExist {
+		return http.StatusNotFound, errors.New("catering not found")
+	}
+
+	if exist := config.DB.
+		Where("catering_id = ? AND type = ? AND lower(name) = lower(?)", label.CateringID, label.Type, label.Name).
+		Find(&domain.Label{}).
+		RowsAffected; exist != 0 {
+		return http.StatusBadRequest, errors.New("label with that name already exist")
+	}
+
+	if err := config.DB.Create(label).Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, labelAuditEvent(label.CateringID, label.ID, enums.AuditActionsEnum.Create), nil, label)
+
+	return 0, nil
+}
+
+// Get returns labels of catering, filtered by type if it's provided
+// returns list of labels, error and status code
+func (l LabelRepo) Get(cateringID, labelType string) ([]domain.Label, int, error) {
+	var labels []domain.Label
+
+	if cateringNotExist := config.DB.
+		Where("id = ?", cateringID).
+		Find(&domain.Catering{}).
+		RecordNotFound(); cateringNotExist {
+		return nil, http.StatusNotFound, errors.New("catering not found")
+	}
+
+	query := config.DB.Where("catering_id = ?", cateringID)
+
+	if labelType != "" {
+		query = query.Where("type = ?", labelType)
+	}
+
+	if err := query.
+		Order("type, created_at").
+		Find(&labels).
+		Error; err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if labels == nil {
+		labels = make([]domain.Label, 0)
+	}
+
+	return labels, 0, nil
+}
+
+// Delete soft deletes label of catering,
+// label is removed from dishes and dietary profiles
+// returns error and status code
+func (l LabelRepo) Delete(path url.PathLabel, actor domain.User) (int, error) {
+	var label domain.Label
+
+	if err := config.DB.
+		Where("catering_id = ? AND id = ?", path.ID, path.LabelID).
+		First(&label).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return http.StatusNotFound, errors.New("label not found")
+		}
+		return http.StatusBadRequest, err
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.Delete(&label).Error; err != nil {
+		tx.Rollback()
+		return http.StatusBadRequest, err
+	}
+
+	if err := tx.
+		Where("label_id = ?", label.ID).
+		Delete(&domain.DishLabel{}).
+		Error; err != nil {
+		tx.Rollback()
+		return http.StatusBadRequest, err
+	}
+
+	if err := tx.
+		Where("label_id = ?", label.ID).
+		Delete(&domain.UserLabel{}).
+		Error; err != nil {
+		tx.Rollback()
+		return http.StatusBadRequest, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, labelAuditEvent(label.CateringID, label.ID, enums.AuditActionsEnum.Delete), label, nil)
+
+	return 0, nil
+}
+
+// GetDietaryProfile returns labels of dietary profile of user
+// returns profile, error and status code
+func (l LabelRepo) GetDietaryProfile(userID string) (models.DietaryProfile, int, error) {
+	profile := models.DietaryProfile{
+		Labels: make([]domain.LabelArray, 0),
+	}
+
+	if err := config.DB.
+		Table("user_labels as ul").
+		Select("l.id, l.name, l.type").
+		Joins("join labels l on l.id = ul.label_id").
+		Where("ul.user_id = ? AND ul.deleted_at IS NULL AND l.deleted_at IS NULL", userID).
+		Order("l.type, l.name").
+		Scan(&profile.Labels).
+		Error; err != nil {
+		return models.DietaryProfile{}, http.StatusBadRequest, err
+	}
+
+	return profile, 0, nil
+}
+
+// UpdateDietaryProfile replaces dietary profile of user
+// with provided labels of catering of user
+// returns updated profile, error and status code
+func (l LabelRepo) UpdateDietaryProfile(userID, cateringID uuid.UUID, labelIDs []uuid.UUID) (models.DietaryProfile, int, error) {
+	tx := config.DB.Begin()
+
+	ids, err := uniqueLabelIDs(tx, cateringID, labelIDs)
+
+	if err != nil {
+		tx.Rollback()
+		if err.Error() == "label not found" {
+			return models.DietaryProfile{}, http.StatusNotFound, err
+		}
+		return models.DietaryProfile{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.
+		Unscoped().
+		Where("user_id = ?", userID).
+		Delete(&domain.UserLabel{}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.DietaryProfile{}, http.StatusBadRequest, err
+	}
+
+	for _, id := range ids {
+		if err := tx.Create(&domain.UserLabel{
+			UserID:  userID,
+			LabelID: id,
+		}).Error; err != nil {
+			tx.Rollback()
+			return models.DietaryProfile{}, http.StatusBadRequest, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.DietaryProfile{}, http.StatusBadRequest, err
+	}
+
+	return l.GetDietaryProfile(userID.String())
+}
+
+// GetDietaryWarnings returns dishes which conflict with dietary profile of user,
+// dish conflicts when it has allergen of profile or misses dietary tag of profile
+func (l LabelRepo) GetDietaryWarnings(userID string, dishIDs []uuid.UUID) ([]models.DietaryWarning, error) {
+	var dishes []domain.Dish
+	var warnings []models.DietaryWarning
+
+	profile, _, err := l.GetDietaryProfile(userID)
+
+	if err != nil || len(profile.Labels) == 0 || len(dishIDs) == 0 {
+		return nil, err
+	}
+
+	if err := config.DB.
+		Unscoped().
+		Where("id IN (?)", dishIDs).
+		Order("name").
+		Find(&dishes).
+		Error; err != nil {
+		return nil, err
+	}
+
+	labels, err := getDishLabels(dishIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dish := range dishes {
+		dishLabels := make(map[string]bool)
+
+		for _, label := range labels[dish.ID] {
+			dishLabels[label.ID] = true
+		}
+
+		warning := models.DietaryWarning{
+			DishID:      dish.ID,
+			Name:        dish.Name,
+			Allergens:   make([]string, 0),
+			MissingTags: make([]string, 0),
+		}
+
+		for _, label := range profile.Labels {
+			if label.Type == enums.LabelTypesEnum.Allergen && dishLabels[label.ID] {
+				warning.Allergens = append(warning.Allergens, label.Name)
+			}
+
+			if label.Type == enums.LabelTypesEnum.Dietary && !dishLabels[label.ID] {
+				warning.MissingTags = append(warning.MissingTags, label.Name)
+			}
+		}
+
+		if len(warning.Allergens) != 0 || len(warning.MissingTags) != 0 {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings, nil
+}
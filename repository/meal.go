@@ -5,12 +5,15 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/repository/models"
 
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
 
 	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
 )
 
 // MealRepo struct
@@ -34,16 +37,24 @@ func (m MealRepo) Find(meal *domain.Meal) error {
 
 // Add create meal entity
 // returns new meal item and error
-func (m MealRepo) Add(meal *domain.Meal) error {
+func (m MealRepo) Add(meal *domain.Meal, actor domain.User) error {
 	if err := config.DB.Create(meal).Error; err != nil {
 		return err
 	}
 
+	saveAudit(actor, domain.AuditEvent{
+		CateringID: &meal.CateringID,
+		ClientID:   &meal.ClientID,
+		EntityType: enums.AuditEntitiesEnum.Meal,
+		EntityID:   meal.ID,
+		Action:     enums.AuditActionsEnum.Create,
+	}, nil, meal)
+
 	return nil
 }
 
-// Get returns list of meals, total items if and error
-func (m MealRepo) Get(mealDate time.Time, id, clientID string) ([]models.GetMeal, int, error) {
+// Get returns list of meals with dishes filtered by labels, total items if and error
+func (m MealRepo) Get(mealDate time.Time, id, clientID string, filter url.LabelFilterQuery) ([]models.GetMeal, int, error) {
 	var meals []domain.Meal
 	var mealsResponse []models.GetMeal
 
@@ -59,9 +70,9 @@ func (m MealRepo) Get(mealDate time.Time, id, clientID string) ([]models.GetMeal
 	}
 
 	for _, meal := range meals {
-		var result []domain.Dish
+		var result []models.MealDish
 
-		if err := config.DB.
+		if err := labelFilter(config.DB, "d.id", filter).
 			Unscoped().
 			Model(&domain.Category{}).
 			Select("categories.id as category_id, categories.deleted_at, d.*").
@@ -75,7 +86,11 @@ func (m MealRepo) Get(mealDate time.Time, id, clientID string) ([]models.GetMeal
 			return []models.GetMeal{}, http.StatusNotFound, err
 		}
 
+		labelDishes := make([]*domain.Dish, len(result))
+
 		for i := range result {
+			labelDishes[i] = &result[i].Dish
+
 			var imagesArray []domain.ImageArray
 			config.DB.
 				Model(&domain.Image{}).
@@ -87,6 +102,14 @@ func (m MealRepo) Get(mealDate time.Time, id, clientID string) ([]models.GetMeal
 			result[i].Images = imagesArray
 		}
 
+		if err := fillDishLabels(labelDishes); err != nil {
+			return []models.GetMeal{}, http.StatusBadRequest, err
+		}
+
+		if err := m.fillPortions(meal.MealID, result); err != nil {
+			return []models.GetMeal{}, http.StatusBadRequest, err
+		}
+
 		mealDishes := models.GetMeal{
 			MealID:  meal.MealID,
 			Version: meal.Version,
@@ -106,6 +129,81 @@ func (m MealRepo) Get(mealDate time.Time, id, clientID string) ([]models.GetMeal
 	return mealsResponse, 0, nil
 }
 
+// fillPortions sets portions limit, remaining portions
+// and sold out flag for dishes of provided meal
+func (m MealRepo) fillPortions(mealID uuid.UUID, dishes []models.MealDish) error {
+	var portions []domain.MealDishPortion
+
+	if err := config.DB.
+		Where("meal_id = ?", mealID).
+		Find(&portions).
+		Error; err != nil {
+		return err
+	}
+
+	limits := make(map[uuid.UUID]domain.MealDishPortion)
+
+	for _, portion := range portions {
+		limits[portion.DishID] = portion
+	}
+
+	for i := range dishes {
+		limit, ok := limits[dishes[i].ID]
+
+		if !ok {
+			continue
+		}
+
+		total := limit.Portions
+		remaining := limit.Portions - limit.Reserved
+
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		dishes[i].Portions = &total
+		dishes[i].Remaining = &remaining
+		dishes[i].SoldOut = remaining == 0
+	}
+
+	return nil
+}
+
+// GetLatest returns latest version of meal of client for provided date
+// returns meal, status code and error
+func (m MealRepo) GetLatest(cateringID, clientID string, mealDate time.Time) (domain.Meal, int, error) {
+	var meal domain.Meal
+
+	if err := config.DB.
+		Where("catering_id = ? AND client_id = ? AND date = ?", cateringID, clientID, mealDate).
+		Order("created_at desc").
+		First(&meal).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return domain.Meal{}, http.StatusNotFound, errors.New("meal with this date not found")
+		}
+		return domain.Meal{}, http.StatusBadRequest, err
+	}
+
+	return meal, 0, nil
+}
+
+// GetVersion returns version of meal of client
+// returns meal, status code and error
+func (m MealRepo) GetVersion(cateringID, clientID, mealID, version string) (domain.Meal, int, error) {
+	var meal domain.Meal
+
+	if err := config.DB.
+		Where("catering_id = ? AND client_id = ? AND meal_id = ? AND version = ?", cateringID, clientID, mealID, version).
+		First(&meal).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return domain.Meal{}, http.StatusNotFound, errors.New("meal version not found")
+		}
+		return domain.Meal{}, http.StatusBadRequest, err
+	}
+
+	return meal, 0, nil
+}
+
 // GetByKey get meal by provided key value arguments
 // Returns meal, error and status code
 func (m MealRepo) GetByKey(key, value string) (domain.Meal, int, error) {
@@ -1,8 +1,15 @@
 package repository
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	uuid "github.com/satori/go.uuid"
 )
 
 // MealDishesRepo struct
@@ -22,6 +29,41 @@ func (md MealDishesRepo) Add(mealDish domain.MealDish) error {
 	return err
 }
 
+// GetForCopy returns dishes of meal with deletion dates
+// of dishes and their categories and portion limits, returns err or nil
+func (md MealDishesRepo) GetForCopy(mealID uuid.UUID) ([]models.CopyMealDish, error) {
+	var dishes []models.CopyMealDish
+
+	err := config.DB.
+		Table("meal_dishes as md").
+		Select("d.id as dish_id, d.name, d.deleted_at, c.deleted_at as category_deleted_at, p.portions").
+		Joins("join dishes d on d.id = md.dish_id").
+		Joins("left join categories c on c.id = d.category_id").
+		Joins("left join meals m on m.id = md.meal_id").
+		Joins("left join meal_dish_portions p on p.meal_id = m.meal_id AND p.dish_id = md.dish_id AND p.deleted_at IS NULL").
+		Where("md.meal_id = ? AND md.deleted_at IS NULL", mealID).
+		Order("d.created_at").
+		Scan(&dishes).
+		Error
+	return dishes, err
+}
+
+// GetByMeal returns dishes of meal version with prices
+// it was published with, returns err or nil
+func (md MealDishesRepo) GetByMeal(mealID uuid.UUID) ([]models.MealVersionDish, error) {
+	var dishes []models.MealVersionDish
+
+	err := config.DB.
+		Table("meal_dishes as md").
+		Select("md.dish_id, d.name, d.category_id, md.price").
+		Joins("join dishes d on d.id = md.dish_id").
+		Where("md.meal_id = ? AND md.deleted_at IS NULL", mealID).
+		Order("d.created_at").
+		Scan(&dishes).
+		Error
+	return dishes, err
+}
+
 // Delete soft deletes mealDish, returns err or nil
 func (md MealDishesRepo) Delete(mealID string) error {
 	if err := config.DB.
@@ -33,3 +75,60 @@ func (md MealDishesRepo) Delete(mealID string) error {
 
 	return nil
 }
+
+// SetPortions sets portions limit of dish for meal
+// nil portions removes the limit, returns status code and error
+func (md MealDishesRepo) SetPortions(path url.PathMealDish, portions *int) (domain.MealDishPortion, int, error) {
+	var portion domain.MealDishPortion
+
+	if mealNotExist := config.DB.
+		Where("meal_id = ? AND catering_id = ? AND client_id = ?", path.MealID, path.ID, path.ClientID).
+		First(&domain.Meal{}).
+		RecordNotFound(); mealNotExist {
+		return domain.MealDishPortion{}, http.StatusNotFound, errors.New("meal not found")
+	}
+
+	if dishNotExist := config.DB.
+		Where("id = ? AND catering_id = ?", path.DishID, path.ID).
+		First(&domain.Dish{}).
+		RecordNotFound(); dishNotExist {
+		return domain.MealDishPortion{}, http.StatusNotFound, errors.New("dish with that id not found")
+	}
+
+	exist := !config.DB.
+		Where("meal_id = ? AND dish_id = ?", path.MealID, path.DishID).
+		First(&portion).
+		RecordNotFound()
+
+	if portions == nil {
+		if exist {
+			config.DB.Unscoped().Delete(&portion)
+		}
+		return domain.MealDishPortion{}, 0, nil
+	}
+
+	if *portions < portion.Reserved {
+		return domain.MealDishPortion{}, http.StatusBadRequest,
+			errors.New("can't set less portions than already ordered (" + strconv.Itoa(portion.Reserved) + ")")
+	}
+
+	if exist {
+		if err := config.DB.
+			Model(&portion).
+			Update("portions", *portions).
+			Error; err != nil {
+			return domain.MealDishPortion{}, http.StatusBadRequest, err
+		}
+		return portion, 0, nil
+	}
+
+	portion.MealID, _ = uuid.FromString(path.MealID)
+	portion.DishID, _ = uuid.FromString(path.DishID)
+	portion.Portions = *portions
+
+	if err := config.DB.Create(&portion).Error; err != nil {
+		return domain.MealDishPortion{}, http.StatusBadRequest, err
+	}
+
+	return portion, 0, nil
+}
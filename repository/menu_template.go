@@ -0,0 +1,388 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplateRepo struct
+type MenuTemplateRepo struct{}
+
+// NewMenuTemplateRepo returns pointer to menu template repository
+// with all methods
+func NewMenuTemplateRepo() *MenuTemplateRepo {
+	return &MenuTemplateRepo{}
+}
+
+// menuTemplateAuditEvent returns audit event of action with menu template of catering
+func menuTemplateAuditEvent(cateringID, menuTemplateID uuid.UUID, action string) domain.AuditEvent {
+	return domain.AuditEvent{
+		CateringID: &cateringID,
+		EntityType: enums.AuditEntitiesEnum.MenuTemplate,
+		EntityID:   menuTemplateID,
+		Action:     action,
+	}
+}
+
+// availableDishes returns query of dishes which aren't deleted
+// and whose category isn't deleted on provided date
+func availableDishes(date time.Time) *gorm.DB {
+	return config.DB.
+		Table("dishes as d").
+		Select("DISTINCT d.id, d.name, d.category_id, d.created_at").
+		Joins("join categories c on c.id = d.category_id").
+		Where("d.deleted_at IS NULL AND (c.deleted_at IS NULL OR c.deleted_at > ?)", date).
+		Order("d.created_at")
+}
+
+// Add creates menu template with its items in one transaction
+func (m MenuTemplateRepo) Add(menuTemplate domain.MenuTemplate, items []domain.MenuTemplateItem, actor domain.User) (models.MenuTemplate, int, error) {
+	tx := config.DB.Begin()
+
+	if err := tx.Create(&menuTemplate).Error; err != nil {
+		tx.Rollback()
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	if err := m.createItems(tx, menuTemplate.ID, items); err != nil {
+		tx.Rollback()
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	result, code, err := m.GetByID(menuTemplate.CateringID.String(), menuTemplate.ID.String())
+
+	if err != nil {
+		return models.MenuTemplate{}, code, err
+	}
+
+	saveAudit(actor, menuTemplateAuditEvent(menuTemplate.CateringID, menuTemplate.ID, enums.AuditActionsEnum.Create), nil, result)
+
+	return result, 0, nil
+}
+
+// createItems creates items for provided menu template
+func (m MenuTemplateRepo) createItems(tx *gorm.DB, menuTemplateID uuid.UUID, items []domain.MenuTemplateItem) error {
+	for i := range items {
+		items[i].MenuTemplateID = menuTemplateID
+
+		if err := tx.Create(&items[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get returns list of menu templates of provided catering
+func (m MenuTemplateRepo) Get(cateringID string) ([]models.MenuTemplate, int, error) {
+	var menuTemplates []domain.MenuTemplate
+
+	if cateringNotExist := config.DB.
+		Where("id = ?", cateringID).
+		Find(&domain.Catering{}).
+		RecordNotFound(); cateringNotExist {
+		return nil, http.StatusNotFound, errors.New("catering not found")
+	}
+
+	if err := config.DB.
+		Where("catering_id = ?", cateringID).
+		Order("created_at").
+		Find(&menuTemplates).
+		Error; err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	result, err := m.withItems(menuTemplates)
+
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	return result, 0, nil
+}
+
+// GetByID returns menu template of provided catering
+func (m MenuTemplateRepo) GetByID(cateringID, id string) (models.MenuTemplate, int, error) {
+	var menuTemplate domain.MenuTemplate
+
+	if err := config.DB.
+		Where("catering_id = ? AND id = ?", cateringID, id).
+		First(&menuTemplate).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return models.MenuTemplate{}, http.StatusNotFound, errors.New("menu template not found")
+		}
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	result, err := m.withItems([]domain.MenuTemplate{menuTemplate})
+
+	if err != nil {
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	return result[0], 0, nil
+}
+
+// GetEnabled returns enabled menu templates of all caterings
+func (m MenuTemplateRepo) GetEnabled() ([]models.MenuTemplate, error) {
+	var menuTemplates []domain.MenuTemplate
+
+	if err := config.DB.
+		Where("enabled = ?", true).
+		Order("created_at").
+		Find(&menuTemplates).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return m.withItems(menuTemplates)
+}
+
+// withItems adds items and upcoming overrides to provided menu templates
+func (m MenuTemplateRepo) withItems(menuTemplates []domain.MenuTemplate) ([]models.MenuTemplate, error) {
+	result := make([]models.MenuTemplate, 0, len(menuTemplates))
+
+	for _, menuTemplate := range menuTemplates {
+		var items []domain.MenuTemplateItem
+		var overrides []domain.MenuTemplateOverride
+
+		if err := config.DB.
+			Where("menu_template_id = ?", menuTemplate.ID).
+			Order("day, created_at").
+			Find(&items).
+			Error; err != nil {
+			return nil, err
+		}
+
+		if err := config.DB.
+			Where("menu_template_id = ? AND date >= ?", menuTemplate.ID, time.Now().UTC().Truncate(time.Hour*24)).
+			Order("date, created_at").
+			Find(&overrides).
+			Error; err != nil {
+			return nil, err
+		}
+
+		if items == nil {
+			items = make([]domain.MenuTemplateItem, 0)
+		}
+
+		if overrides == nil {
+			overrides = make([]domain.MenuTemplateOverride, 0)
+		}
+
+		result = append(result, models.MenuTemplate{
+			MenuTemplate: menuTemplate,
+			Items:        items,
+			Overrides:    overrides,
+		})
+	}
+
+	return result, nil
+}
+
+// Update replaces settings and items of menu template,
+// overrides and already published dates aren't changed
+func (m MenuTemplateRepo) Update(cateringID, id string, menuTemplate domain.MenuTemplate, items []domain.MenuTemplateItem, actor domain.User) (models.MenuTemplate, int, error) {
+	before, code, err := m.GetByID(cateringID, id)
+
+	if err != nil {
+		return models.MenuTemplate{}, code, err
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Model(&before.MenuTemplate).
+		Updates(map[string]interface{}{
+			"name":       menuTemplate.Name,
+			"start_date": menuTemplate.StartDate,
+			"length":     menuTemplate.Length,
+			"enabled":    menuTemplate.Enabled,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.
+		Unscoped().
+		Where("menu_template_id = ?", before.ID).
+		Delete(&domain.MenuTemplateItem{}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	if err := m.createItems(tx, before.ID, items); err != nil {
+		tx.Rollback()
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.MenuTemplate{}, http.StatusBadRequest, err
+	}
+
+	result, code, err := m.GetByID(cateringID, id)
+
+	if err != nil {
+		return models.MenuTemplate{}, code, err
+	}
+
+	saveAudit(actor, menuTemplateAuditEvent(before.CateringID, before.ID, enums.AuditActionsEnum.Update), before, result)
+
+	return result, 0, nil
+}
+
+// Delete soft deletes menu template of provided catering,
+// meals which are already published aren't changed
+func (m MenuTemplateRepo) Delete(cateringID, id string, actor domain.User) (int, error) {
+	before, code, err := m.GetByID(cateringID, id)
+
+	if err != nil {
+		return code, err
+	}
+
+	if err := config.DB.
+		Delete(&before.MenuTemplate).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	saveAudit(actor, menuTemplateAuditEvent(before.CateringID, before.ID, enums.AuditActionsEnum.Delete), before, nil)
+
+	return 0, nil
+}
+
+// AddOverride replaces dishes of menu template
+// for single date of client, previous override of date is removed
+func (m MenuTemplateRepo) AddOverride(override domain.MenuTemplateOverride) (domain.MenuTemplateOverride, int, error) {
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Unscoped().
+		Where("menu_template_id = ? AND client_id = ? AND date = ?", override.MenuTemplateID, override.ClientID, override.Date).
+		Delete(&domain.MenuTemplateOverride{}).
+		Error; err != nil {
+		tx.Rollback()
+		return domain.MenuTemplateOverride{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.Create(&override).Error; err != nil {
+		tx.Rollback()
+		return domain.MenuTemplateOverride{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return domain.MenuTemplateOverride{}, http.StatusBadRequest, err
+	}
+
+	return override, 0, nil
+}
+
+// DeleteOverride removes override of date of client,
+// so date gets dishes of its day of cycle again
+func (m MenuTemplateRepo) DeleteOverride(menuTemplateID, clientID string, date time.Time) (int, error) {
+	if isExist := config.DB.
+		Unscoped().
+		Where("menu_template_id = ? AND client_id = ? AND date = ?", menuTemplateID, clientID, date).
+		Delete(&domain.MenuTemplateOverride{}).
+		RowsAffected; isExist == 0 {
+		return http.StatusNotFound, errors.New("date isn't overridden")
+	}
+
+	return 0, nil
+}
+
+// GetDayDishes returns available dishes of items of client
+// for provided day of cycle, dishes of category items are included
+func (m MenuTemplateRepo) GetDayDishes(menuTemplateID, clientID uuid.UUID, day int, date time.Time) ([]models.MenuTemplateDish, error) {
+	var dishes []models.MenuTemplateDish
+
+	err := availableDishes(date).
+		Joins("join menu_template_items i on i.dish_id = d.id OR i.category_id = d.category_id").
+		Where("i.menu_template_id = ? AND i.client_id = ? AND i.day = ? AND i.deleted_at IS NULL", menuTemplateID, clientID, day).
+		Scan(&dishes).
+		Error
+
+	return dishes, err
+}
+
+// GetDishesByIDs returns available dishes with provided ids
+func (m MenuTemplateRepo) GetDishesByIDs(dishIDs []string, date time.Time) ([]models.MenuTemplateDish, error) {
+	var dishes []models.MenuTemplateDish
+
+	if len(dishIDs) == 0 {
+		return dishes, nil
+	}
+
+	err := availableDishes(date).
+		Where("d.id IN (?)", dishIDs).
+		Scan(&dishes).
+		Error
+
+	return dishes, err
+}
+
+// GetPlacements returns dates of range which are already handled by menu template
+func (m MenuTemplateRepo) GetPlacements(menuTemplateID uuid.UUID, from, to time.Time) ([]domain.MenuTemplatePlacement, error) {
+	var placements []domain.MenuTemplatePlacement
+
+	err := config.DB.
+		Where("menu_template_id = ? AND date >= ? AND date <= ?", menuTemplateID, from, to).
+		Find(&placements).
+		Error
+
+	return placements, err
+}
+
+// ClaimPlacement marks date of client as handled for menu template,
+// returns false if date is already handled, so meal of date
+// is published once even when several instances run cron
+func (m MenuTemplateRepo) ClaimPlacement(menuTemplateID, clientID uuid.UUID, date time.Time) (bool, error) {
+	result := config.DB.
+		Set("gorm:insert_option", "ON CONFLICT DO NOTHING").
+		Create(&domain.MenuTemplatePlacement{
+			MenuTemplateID: menuTemplateID,
+			ClientID:       clientID,
+			Date:           date,
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected != 0, nil
+}
+
+// SetPlacementMeal sets mealId of meal published by menu template
+// for claimed date of client
+func (m MenuTemplateRepo) SetPlacementMeal(menuTemplateID, clientID uuid.UUID, date time.Time, mealID uuid.UUID) error {
+	return config.DB.
+		Model(&domain.MenuTemplatePlacement{}).
+		Where("menu_template_id = ? AND client_id = ? AND date = ?", menuTemplateID, clientID, date).
+		Update("meal_id", mealID).
+		Error
+}
+
+// ReleasePlacement removes claim of date of client,
+// so menu template publishes its meal on the next run
+func (m MenuTemplateRepo) ReleasePlacement(menuTemplateID, clientID uuid.UUID, date time.Time) error {
+	return config.DB.
+		Unscoped().
+		Where("menu_template_id = ? AND client_id = ? AND date = ?", menuTemplateID, clientID, date).
+		Delete(&domain.MenuTemplatePlacement{}).
+		Error
+}
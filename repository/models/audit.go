@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// AuditEvent struct response
+type AuditEvent struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actorId"`
+	ActorName  string          `json:"actorName"`
+	ActorRole  string          `json:"actorRole"`
+	CateringID *uuid.UUID      `json:"cateringId"`
+	ClientID   *uuid.UUID      `json:"clientId"`
+	EntityType string          `json:"entityType"`
+	EntityID   uuid.UUID       `json:"entityId"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// AuditEvents struct response
+type AuditEvents struct {
+	Items []AuditEvent `json:"items"`
+	Page  int          `json:"page"`
+	Total int          `json:"total"`
+}
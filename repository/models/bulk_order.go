@@ -0,0 +1,12 @@
+package models
+
+import uuid "github.com/satori/go.uuid"
+
+// BulkOrderResult struct response for single day of bulk request
+type BulkOrderResult struct {
+	Date    string      `json:"date"`
+	Success bool        `json:"success"`
+	Order   *UserOrder  `json:"order,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	DishIDs []uuid.UUID `json:"dishIds,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// UpdateBudget struct for request scheme
+// empty limit removes it
+type UpdateBudget struct {
+	MonthlyBudget    *float32 `json:"monthlyBudget"`
+	UserMonthlyLimit *float32 `json:"userMonthlyLimit"`
+	UserDailyLimit   *float32 `json:"userDailyLimit"`
+}
+
+// ClientBudget struct response
+// spent is company share of orders of current month
+type ClientBudget struct {
+	MonthlyBudget    *float32 `json:"monthlyBudget"`
+	UserMonthlyLimit *float32 `json:"userMonthlyLimit"`
+	UserDailyLimit   *float32 `json:"userDailyLimit"`
+	Month            string   `json:"month"`
+	Spent            float32  `json:"spent"`
+	Remaining        *float32 `json:"remaining"`
+}
@@ -8,11 +8,12 @@ import (
 // ClientSchedulesCatering struct for joined catering table
 type ClientSchedulesCatering struct {
 	domain.Base
-	Day           int       `json:"day"`
-	Start         string    `json:"start"`
-	End           string    `json:"end"`
-	IsWorking     bool      `json:"isWorking"`
-	ClientID      uuid.UUID `json:"-" swaggerignore:"true"`
-	CateringStart string    `json:"cateringStart"`
-	CateringEnd   string    `json:"cateringEnd"`
+	Day               int       `json:"day"`
+	Start             string    `json:"start"`
+	End               string    `json:"end"`
+	IsWorking         bool      `json:"isWorking"`
+	ClientID          uuid.UUID `json:"-" swaggerignore:"true"`
+	CateringStart     string    `json:"cateringStart"`
+	CateringEnd       string    `json:"cateringEnd"`
+	CateringIsWorking bool      `json:"-" swaggerignore:"true"`
 } //@name GetClientSchedulesResponse
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/Aiscom-LLC/meals-api/domain"
+	uuid "github.com/satori/go.uuid"
+)
+
+// SubsidyCategory struct for request scheme
+type SubsidyCategory struct {
+	CategoryID uuid.UUID `json:"categoryId" binding:"required"`
+	Amount     float32   `json:"amount"`
+}
+
+// UpdateSubsidy struct for request scheme
+// amount is sum per day for fixed type and percent for percent type
+type UpdateSubsidy struct {
+	Type       string            `json:"type" binding:"required"`
+	Amount     float32           `json:"amount"`
+	Categories []SubsidyCategory `json:"categories"`
+}
+
+// ClientSubsidy struct response
+type ClientSubsidy struct {
+	Type       string                         `json:"type"`
+	Amount     float32                        `json:"amount"`
+	Categories []domain.ClientSubsidyCategory `json:"categories"`
+}
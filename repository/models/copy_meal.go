@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CopyMeal request scheme, latest version of meal of Date
+// is copied to every date of Dates for every client of ClientIDs,
+// client from path is used if ClientIDs are empty
+type CopyMeal struct {
+	Date      time.Time   `json:"date" binding:"required"`
+	Dates     []time.Time `json:"dates" binding:"required,min=1"`
+	ClientIDs []string    `json:"clientIds"`
+}
+
+// CopiedMeal struct response
+// MealID and Version are empty if all dishes were skipped
+// or meal wasn't copied because of Error
+type CopiedMeal struct {
+	ClientID uuid.UUID     `json:"clientId"`
+	Date     time.Time     `json:"date"`
+	MealID   *uuid.UUID    `json:"mealId"`
+	Version  string        `json:"version"`
+	Dishes   int           `json:"dishes"`
+	Skipped  []SkippedDish `json:"skipped"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// SkippedDish is dish of source meal which wasn't copied
+type SkippedDish struct {
+	DishID uuid.UUID `json:"dishId"`
+	Name   string    `json:"name"`
+	Reason string    `json:"reason"`
+}
+
+// CopyMealDish struct used for scanning dishes of source meal
+type CopyMealDish struct {
+	DishID            uuid.UUID
+	Name              string
+	DeletedAt         *time.Time
+	CategoryDeletedAt *time.Time
+	Portions          *int
+}
@@ -7,9 +7,18 @@ import (
 
 // GetMeal struct response
 type GetMeal struct {
-	Version string        `json:"version"`
-	MealID  uuid.UUID     `json:"mealId"`
-	Date    string        `json:"date"`
-	Person  string        `json:"person"`
-	Result  []domain.Dish `json:"dishes"`
+	Version string     `json:"version"`
+	MealID  uuid.UUID  `json:"mealId"`
+	Date    string     `json:"date"`
+	Person  string     `json:"person"`
+	Result  []MealDish `json:"dishes"`
 } //@name GetMealsResponse
+
+// MealDish struct response
+// Portions and Remaining are nil if dish doesn't have portions limit
+type MealDish struct {
+	domain.Dish
+	Portions  *int `json:"portions" gorm:"-"`
+	Remaining *int `json:"remaining" gorm:"-"`
+	SoldOut   bool `json:"soldOut" gorm:"-"`
+}
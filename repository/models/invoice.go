@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/Aiscom-LLC/meals-api/domain"
+)
+
+// CreateInvoice struct for request scheme
+type CreateInvoice struct {
+	From     string  `json:"from" binding:"required"`
+	To       string  `json:"to" binding:"required"`
+	Discount float32 `json:"discount"`
+}
+
+// UpdateInvoice struct for request scheme
+// discount can be changed only for draft invoice
+type UpdateInvoice struct {
+	Status   *string  `json:"status"`
+	Discount *float32 `json:"discount"`
+}
+
+// Invoice struct response
+type Invoice struct {
+	domain.Invoice
+	ClientName string               `json:"clientName"`
+	Items      []domain.InvoiceItem `json:"items,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/Aiscom-LLC/meals-api/domain"
+	uuid "github.com/satori/go.uuid"
+)
+
+// UpdateDietaryProfile struct for request scheme
+type UpdateDietaryProfile struct {
+	LabelIDs []uuid.UUID `json:"labelIds" binding:"required"`
+}
+
+// DietaryProfile struct response
+type DietaryProfile struct {
+	Labels []domain.LabelArray `json:"labels"`
+}
+
+// DietaryWarning struct response
+// dish of order conflicts with dietary profile of user
+// when it has avoided allergens or misses dietary tags
+type DietaryWarning struct {
+	DishID      uuid.UUID `json:"dishId"`
+	Name        string    `json:"name"`
+	Allergens   []string  `json:"allergens"`
+	MissingTags []string  `json:"missingTags"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	uuid "github.com/satori/go.uuid"
+)
+
+// RollbackMeal request scheme
+// version is name or number of version, V.1 or 1
+type RollbackMeal struct {
+	MealID  string `json:"mealId" binding:"required"`
+	Version string `json:"version" binding:"required"`
+}
+
+// MealVersionDish is dish of meal version
+// with price it was published with
+type MealVersionDish struct {
+	DishID     uuid.UUID `json:"dishId"`
+	Name       string    `json:"name"`
+	CategoryID uuid.UUID `json:"categoryId"`
+	Price      float32   `json:"price"`
+}
+
+// MealPriceChange is change of price of dish
+// which is in both versions of meal
+type MealPriceChange struct {
+	DishID   uuid.UUID `json:"dishId"`
+	Name     string    `json:"name"`
+	OldPrice float32   `json:"oldPrice"`
+	NewPrice float32   `json:"newPrice"`
+}
+
+// MealDiff struct response
+// changes of dishes from version From to version To
+type MealDiff struct {
+	MealID       uuid.UUID         `json:"mealId"`
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	Added        []MealVersionDish `json:"added"`
+	Removed      []MealVersionDish `json:"removed"`
+	PriceChanges []MealPriceChange `json:"priceChanges"`
+}
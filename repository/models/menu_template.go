@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/domain"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplateItem struct for request scheme
+// item must contain either dishId or categoryId,
+// day is number of day of cycle starting from 0
+type MenuTemplateItem struct {
+	Day        int        `json:"day"`
+	ClientID   uuid.UUID  `json:"clientId"`
+	DishID     *uuid.UUID `json:"dishId"`
+	CategoryID *uuid.UUID `json:"categoryId"`
+}
+
+// MenuTemplateRequest struct for request scheme
+type MenuTemplateRequest struct {
+	Name      string             `json:"name" binding:"required"`
+	StartDate time.Time          `json:"startDate" binding:"required"`
+	Length    int                `json:"length" binding:"required"`
+	Enabled   bool               `json:"enabled"`
+	Items     []MenuTemplateItem `json:"items" binding:"required"`
+}
+
+// MenuTemplate struct response
+type MenuTemplate struct {
+	domain.MenuTemplate
+	Items     []domain.MenuTemplateItem     `json:"items"`
+	Overrides []domain.MenuTemplateOverride `json:"overrides"`
+}
+
+// MenuTemplateOverride struct for request scheme
+// empty dishes mean meal isn't published for date
+type MenuTemplateOverride struct {
+	ClientID uuid.UUID   `json:"clientId"`
+	Date     time.Time   `json:"date" binding:"required"`
+	Dishes   []uuid.UUID `json:"dishes"`
+}
+
+// MenuTemplateDay struct response
+// meal of client generated by template for date,
+// Published is true if date is already handled by template
+type MenuTemplateDay struct {
+	Date      time.Time          `json:"date"`
+	ClientID  uuid.UUID          `json:"clientId"`
+	Day       int                `json:"day"`
+	Override  bool               `json:"override"`
+	Published bool               `json:"published"`
+	Dishes    []MenuTemplateDish `json:"dishes"`
+}
+
+// MenuTemplateDish struct response
+type MenuTemplateDish struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	CategoryID uuid.UUID `json:"categoryId"`
+}
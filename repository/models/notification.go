@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Notification struct for response
+type Notification struct {
+	ID         uuid.UUID  `json:"id"`
+	OrderID    uuid.UUID  `json:"orderId"`
+	Date       time.Time  `json:"date"`
+	FromStatus string     `json:"from"`
+	ToStatus   string     `json:"to"`
+	Title      string     `json:"title"`
+	Message    string     `json:"message"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ReadAt     *time.Time `json:"readAt"`
+}
+
+// Notifications struct for response
+// unread is number of all unread notifications of user
+type Notifications struct {
+	Items  []Notification `json:"items"`
+	Page   int            `json:"page"`
+	Total  int            `json:"total"`
+	Unread int            `json:"unread"`
+}
+
+// ReadNotifications struct for request scheme
+// empty ids mark all notifications of user as read
+type ReadNotifications struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+// NotificationEmail struct
+// notification with recipient for emailing
+type NotificationEmail struct {
+	ID            uuid.UUID
+	Title         string
+	Message       string
+	Email         string
+	FirstName     string
+	EmailAttempts int
+}
@@ -0,0 +1,18 @@
+package models
+
+import uuid "github.com/satori/go.uuid"
+
+// OrderBoardDish struct
+// amount of dish ordered by client for date
+type OrderBoardDish struct {
+	DishID uuid.UUID `json:"dishId"`
+	Name   string    `json:"name"`
+	Amount int       `json:"amount"`
+}
+
+// OrderBoardEvent struct
+// changed order with updated amounts of dishes of its client for its date
+type OrderBoardEvent struct {
+	Order  OrderEvent       `json:"order"`
+	Dishes []OrderBoardDish `json:"dishes"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/domain"
+)
+
+// UserOrderHistory struct for response
+type UserOrderHistory struct {
+	UserOrder
+	Date    time.Time `json:"date"`
+	Comment string    `json:"comment"`
+}
+
+// OrderHistoryPeriod struct for response
+// canceled and rejected orders aren't counted
+type OrderHistoryPeriod struct {
+	Period        string           `json:"period"`
+	Orders        int              `json:"orders"`
+	Total         float32          `json:"total"`
+	CompanyShare  float32          `json:"companyShare"`
+	EmployeeShare float32          `json:"employeeShare"`
+	Nutrition     domain.Nutrition `json:"nutrition" gorm:"embedded"`
+}
+
+// OrderHistory struct for response
+type OrderHistory struct {
+	Items   []UserOrderHistory   `json:"items"`
+	Periods []OrderHistoryPeriod `json:"periods"`
+	Page    int                  `json:"page"`
+	Total   int                  `json:"total"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// UpdateDeliveryStatus struct for request scheme
+// note is required for failed delivery
+type UpdateDeliveryStatus struct {
+	Status string `json:"status" binding:"required"`
+	Note   string `json:"note"`
+}
+
+// OrderStatusChange struct response
+type OrderStatusChange struct {
+	From      string    `json:"from" gorm:"column:from_status"`
+	To        string    `json:"to" gorm:"column:to_status"`
+	Note      string    `json:"note"`
+	UserName  string    `json:"userName"`
+	ChangedAt time.Time `json:"changedAt"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProductionClientAmount struct for response
+type ProductionClientAmount struct {
+	ClientID   uuid.UUID `json:"clientId"`
+	ClientName string    `json:"clientName"`
+	Amount     int       `json:"amount"`
+}
+
+// ProductionDish struct for response
+type ProductionDish struct {
+	DishID  uuid.UUID                `json:"dishId"`
+	Name    string                   `json:"name"`
+	Amount  int                      `json:"amount"`
+	Clients []ProductionClientAmount `json:"clients"`
+}
+
+// ProductionCategory struct for response
+type ProductionCategory struct {
+	CategoryID   uuid.UUID        `json:"categoryId"`
+	CategoryName string           `json:"categoryName"`
+	Amount       int              `json:"amount"`
+	Dishes       []ProductionDish `json:"dishes"`
+}
+
+// ProductionFloor struct for response
+// address is nil if client has no address on that floor
+type ProductionFloor struct {
+	Floor    int     `json:"floor"`
+	Address  *string `json:"address"`
+	Orders   int     `json:"orders"`
+	Portions int     `json:"portions"`
+}
+
+// ProductionClient struct for response
+type ProductionClient struct {
+	ClientID   uuid.UUID         `json:"clientId"`
+	ClientName string            `json:"clientName"`
+	Orders     int               `json:"orders"`
+	Portions   int               `json:"portions"`
+	Floors     []ProductionFloor `json:"floors"`
+}
+
+// ProductionSheet struct for response
+// contains approved orders of all clients of catering for one day
+type ProductionSheet struct {
+	Date       time.Time            `json:"date"`
+	Portions   int                  `json:"portions"`
+	Categories []ProductionCategory `json:"categories"`
+	Clients    []ProductionClient   `json:"clients"`
+}
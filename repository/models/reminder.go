@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// UpdateReminder struct for request scheme
+// offset is number of minutes before cutoff of orders
+type UpdateReminder struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+	Offset  int   `json:"offset"`
+}
+
+// ClientReminder struct response
+type ClientReminder struct {
+	Enabled  bool       `json:"enabled"`
+	Offset   int        `json:"offset"`
+	LastDate *time.Time `json:"lastDate"`
+}
+
+// UpdateUserReminder struct for request scheme
+type UpdateUserReminder struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// UserReminder struct response
+type UserReminder struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ReminderRecipient struct
+// user who has no order for date of reminder
+type ReminderRecipient struct {
+	UserID    uuid.UUID `json:"userId" gorm:"column:user_id"`
+	FirstName string    `json:"firstName"`
+	LastName  string    `json:"lastName"`
+	Email     string    `json:"email"`
+	Floor     int       `json:"floor"`
+}
+
+// ReminderPreview struct response
+// date of orders, cutoff, time of sending and users
+// who would be emailed, nothing is sent
+type ReminderPreview struct {
+	Enabled    bool                `json:"enabled"`
+	Date       time.Time           `json:"date"`
+	Cutoff     time.Time           `json:"cutoff"`
+	SendAt     time.Time           `json:"sendAt"`
+	Recipients []ReminderRecipient `json:"recipients"`
+}
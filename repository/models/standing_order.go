@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/domain"
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrderItem struct for request scheme
+// item must contain either dishId or categoryId
+type StandingOrderItem struct {
+	DishID     *uuid.UUID `json:"dishId"`
+	CategoryID *uuid.UUID `json:"categoryId"`
+	Amount     int        `json:"amount"`
+}
+
+// StandingOrderRequest struct for request scheme
+type StandingOrderRequest struct {
+	Weekdays []int               `json:"weekdays" binding:"required"`
+	EndDate  *time.Time          `json:"endDate"`
+	Paused   bool                `json:"paused"`
+	Comment  string              `json:"comment"`
+	Items    []StandingOrderItem `json:"items" binding:"required"`
+}
+
+// StandingOrderFailure struct response
+// date for which order of standing order couldn't be placed
+type StandingOrderFailure struct {
+	Date   time.Time `json:"date"`
+	Reason string    `json:"reason"`
+}
+
+// StandingOrder struct response
+type StandingOrder struct {
+	domain.StandingOrder
+	Items    []domain.StandingOrderItem `json:"items"`
+	Skips    []time.Time                `json:"skips"`
+	Failures []StandingOrderFailure     `json:"failures"`
+}
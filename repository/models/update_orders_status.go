@@ -0,0 +1,11 @@
+package models
+
+import uuid "github.com/satori/go.uuid"
+
+// UpdateOrdersStatus struct for request scheme
+// reason is required for rejected orders
+type UpdateOrdersStatus struct {
+	OrderIDs []uuid.UUID `json:"orderIds" binding:"required"`
+	Status   string      `json:"status" binding:"required"`
+	Reason   string      `json:"reason"`
+}
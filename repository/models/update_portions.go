@@ -0,0 +1,6 @@
+package models
+
+// UpdatePortions request scheme
+type UpdatePortions struct {
+	Portions *int `json:"portions" example:"20"`
+} //@name UpdatePortionsRequest
@@ -1,9 +1,14 @@
 package models
 
-import uuid "github.com/satori/go.uuid"
+import (
+	"github.com/Aiscom-LLC/meals-api/domain"
+	uuid "github.com/satori/go.uuid"
+)
 
 // OrderItem struct for response
+// nutrition facts are facts of one portion
 type OrderItem struct {
+	domain.Nutrition
 	ID     uuid.UUID `json:"id" gorm:"column:dish_id"`
 	Image  *string   `json:"image" gorm:"column:path"`
 	Price  int       `json:"price"`
@@ -12,9 +17,21 @@ type OrderItem struct {
 }
 
 // UserOrder struct for response
+// nutrition is total nutrition facts of all dishes
 type UserOrder struct {
-	Items   []OrderItem `json:"items"`
-	Status  string      `json:"status"`
-	Total   float32     `json:"total"`
-	OrderID uuid.UUID   `json:"orderId" gorm:"column:order_id"`
+	Items         []OrderItem      `json:"items"`
+	Status        string           `json:"status"`
+	Reason        *string          `json:"reason"`
+	Total         float32          `json:"total"`
+	CompanyShare  float32          `json:"companyShare"`
+	EmployeeShare float32          `json:"employeeShare"`
+	OrderID       uuid.UUID        `json:"orderId" gorm:"column:order_id"`
+	Nutrition     domain.Nutrition `json:"nutrition" gorm:"-"`
+	Warnings      []DietaryWarning `json:"warnings,omitempty" gorm:"-"`
+}
+
+// UpdatedUserOrder struct for response
+type UpdatedUserOrder struct {
+	UserOrder
+	Changes []domain.OrderChange `json:"changes"`
 }
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+)
+
+// WebhookRequest struct for request scheme
+// secret can be omitted on update to keep current one
+type WebhookRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events" binding:"required"`
+	Active *bool    `json:"active"`
+}
+
+// Webhook struct response
+// secret isn't returned
+type Webhook struct {
+	ID        uuid.UUID      `json:"id"`
+	URL       string         `json:"url"`
+	Events    pq.StringArray `json:"events"`
+	Active    bool           `json:"active"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// WebhookDelivery struct response
+type WebhookDelivery struct {
+	ID            uuid.UUID  `json:"id"`
+	WebhookID     uuid.UUID  `json:"webhookId"`
+	Event         string     `json:"event"`
+	Payload       string     `json:"payload"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt"`
+	ResponseCode  *int       `json:"responseCode"`
+	Error         *string    `json:"error"`
+	DeliveredAt   *time.Time `json:"deliveredAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// WebhookDeliveries struct response
+type WebhookDeliveries struct {
+	Items []WebhookDelivery `json:"items"`
+	Page  int               `json:"page"`
+	Total int               `json:"total"`
+}
+
+// WebhookDeliveryTarget struct
+// delivery with endpoint and secret of its webhook for sending
+type WebhookDeliveryTarget struct {
+	ID       uuid.UUID
+	Event    string
+	Payload  string
+	Attempts int
+	URL      string
+	Secret   string
+}
+
+// WebhookEvent struct
+// payload of delivery, data depends on event
+type WebhookEvent struct {
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      interface{} `json:"data"`
+}
+
+// OrderEvent struct
+// data of order events
+type OrderEvent struct {
+	OrderID       uuid.UUID  `json:"orderId"`
+	UserID        uuid.UUID  `json:"userId"`
+	ClientID      *uuid.UUID `json:"clientId"`
+	CateringID    *uuid.UUID `json:"cateringId"`
+	Date          time.Time  `json:"date"`
+	Status        string     `json:"status"`
+	Total         float32    `json:"total"`
+	CompanyShare  float32    `json:"companyShare"`
+	EmployeeShare float32    `json:"employeeShare"`
+}
+
+// MealEvent struct
+// data of meal published event
+type MealEvent struct {
+	ID         uuid.UUID   `json:"id"`
+	MealID     uuid.UUID   `json:"mealId"`
+	Version    string      `json:"version"`
+	Date       time.Time   `json:"date"`
+	CateringID uuid.UUID   `json:"cateringId"`
+	ClientID   uuid.UUID   `json:"clientId"`
+	Dishes     []uuid.UUID `json:"dishes"`
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// NotificationRepo struct
+type NotificationRepo struct{}
+
+// NewNotificationRepo returns pointer to notification repository
+// with all methods
+func NewNotificationRepo() *NotificationRepo {
+	return &NotificationRepo{}
+}
+
+// notificationTitles are titles of notifications by new status of order
+var notificationTitles = map[string]string{
+	enums.OrderStatusTypesEnum.Pending:        "Заказ ожидает подтверждения",
+	enums.OrderStatusTypesEnum.Approved:       "Заказ подтвержден",
+	enums.OrderStatusTypesEnum.Rejected:       "Заказ отклонен",
+	enums.OrderStatusTypesEnum.Canceled:       "Заказ отменен",
+	enums.OrderStatusTypesEnum.Preparing:      "Заказ готовится",
+	enums.OrderStatusTypesEnum.OutForDelivery: "Заказ доставляется",
+	enums.OrderStatusTypesEnum.Delivered:      "Заказ доставлен",
+	enums.OrderStatusTypesEnum.Failed:         "Заказ не доставлен",
+}
+
+// addNotification saves notification of user about status change
+// of order in transaction of the change, so notification
+// exists only if status is changed
+func addNotification(tx *gorm.DB, userID string, orderID uuid.UUID, date time.Time, from, to, reason string) error {
+	message := notificationTitles[to] + ", заказ на " + date.Format("02.01.2006")
+
+	if reason != "" {
+		message += ". Причина: " + reason
+	}
+
+	return tx.
+		Create(&domain.Notification{
+			UserID:     uuid.FromStringOrNil(userID),
+			OrderID:    orderID,
+			Date:       date,
+			FromStatus: from,
+			ToStatus:   to,
+			Title:      notificationTitles[to],
+			Message:    message,
+		}).
+		Error
+}
+
+// Get returns page of notifications of user from the newest one
+func (n NotificationRepo) Get(userID string, unread bool, query url.PaginationQuery) (models.Notifications, int, error) {
+	result := models.Notifications{
+		Items: make([]models.Notification, 0),
+		Page:  query.Page,
+	}
+
+	config.DB.
+		Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&result.Unread)
+
+	notifications := config.DB.
+		Model(&domain.Notification{}).
+		Where("user_id = ?", userID)
+
+	if unread {
+		notifications = notifications.Where("read_at IS NULL")
+	}
+
+	notifications.Count(&result.Total)
+
+	if err := notifications.
+		Select("id, order_id, date, from_status, to_status, title, message, created_at, read_at").
+		Order("created_at desc").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Scan(&result.Items).
+		Error; err != nil {
+		return models.Notifications{}, http.StatusBadRequest, err
+	}
+
+	return result, 0, nil
+}
+
+// Read marks provided notifications of user as read,
+// all notifications are marked if ids are empty
+func (n NotificationRepo) Read(userID string, ids []uuid.UUID) (int, error) {
+	notifications := config.DB.
+		Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+
+	if len(ids) != 0 {
+		notifications = notifications.Where("id IN (?)", ids)
+	}
+
+	if err := notifications.
+		Update("read_at", time.Now().UTC()).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return 0, nil
+}
+
+// notificationEmailLease is time for which notification taken
+// for emailing is hidden from other runs of cron while it's being sent,
+// notification which email failed is retried after the lease
+const notificationEmailLease = 10 * time.Minute
+
+// TakeToEmail leases up to limit notifications which aren't emailed yet
+// and returns them with recipients, locked rows are skipped,
+// so every notification is emailed once when several instances run cron
+func (n NotificationRepo) TakeToEmail(limit int) ([]models.NotificationEmail, error) {
+	var notifications []models.NotificationEmail
+
+	now := time.Now().UTC()
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Table("notifications as n").
+		Select("n.id, n.title, n.message, n.email_attempts, u.email, u.first_name").
+		Joins("left join users u on u.id = n.user_id").
+		Where("n.emailed_at IS NULL AND n.email_failed_at IS NULL AND n.deleted_at IS NULL").
+		Where("n.email_leased_until IS NULL OR n.email_leased_until <= ?", now).
+		Order("n.created_at").
+		Limit(limit).
+		Set("gorm:query_option", "FOR UPDATE OF n SKIP LOCKED").
+		Scan(&notifications).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(notifications) == 0 {
+		tx.Rollback()
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(notifications))
+
+	for i := range notifications {
+		ids[i] = notifications[i].ID
+		notifications[i].EmailAttempts++
+	}
+
+	if err := tx.
+		Model(&domain.Notification{}).
+		Where("id IN (?)", ids).
+		Updates(map[string]interface{}{
+			"email_leased_until": now.Add(notificationEmailLease),
+			"email_attempts":     gorm.Expr("email_attempts + 1"),
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// MarkEmailed marks notification as emailed after its email is sent
+func (n NotificationRepo) MarkEmailed(id uuid.UUID) error {
+	return config.DB.
+		Model(&domain.Notification{}).
+		Where("id = ?", id).
+		Update("emailed_at", time.Now().UTC()).
+		Error
+}
+
+// MarkEmailFailed marks notification as failed to email,
+// so it isn't taken for emailing anymore
+func (n NotificationRepo) MarkEmailFailed(id uuid.UUID) error {
+	return config.DB.
+		Model(&domain.Notification{}).
+		Where("id = ?", id).
+		Update("email_failed_at", time.Now().UTC()).
+		Error
+}
@@ -2,14 +2,19 @@ package repository
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 
+	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/repository/models"
 
 	"github.com/Aiscom-LLC/meals-api/config"
 	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/utils"
 
 	"github.com/jinzhu/gorm"
 	uuid "github.com/satori/go.uuid"
@@ -24,83 +29,287 @@ func NewOrderRepo() *OrderRepo {
 	return &OrderRepo{}
 }
 
+// cateringStatuses are statuses of orders which are shown to catering
+var cateringStatuses = []string{
+	enums.OrderStatusTypesEnum.Approved,
+	enums.OrderStatusTypesEnum.Preparing,
+	enums.OrderStatusTypesEnum.OutForDelivery,
+	enums.OrderStatusTypesEnum.Delivered,
+	enums.OrderStatusTypesEnum.Failed,
+}
+
+// statusesPriority is used to choose one status for orders of client
+// with different statuses, the least progressed status goes first
+var statusesPriority = []string{
+	enums.OrderStatusTypesEnum.Pending,
+	enums.OrderStatusTypesEnum.Approved,
+	enums.OrderStatusTypesEnum.Preparing,
+	enums.OrderStatusTypesEnum.OutForDelivery,
+	enums.OrderStatusTypesEnum.Failed,
+	enums.OrderStatusTypesEnum.Delivered,
+	enums.OrderStatusTypesEnum.Rejected,
+}
+
 // Add adds order for provided user id
+// order, order dishes and user order are created in one transaction
 func (o OrderRepo) Add(userID string, date time.Time, newOrder models.OrderRequest) (models.UserOrder, error) {
+	tx := config.DB.Begin()
+
+	order, err := o.add(tx, userID, date, newOrder)
+
+	if err != nil {
+		tx.Rollback()
+		return models.UserOrder{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.UserOrder{}, err
+	}
+
+	publishOrderEvents(enums.WebhookEventsEnum.OrderCreated, order.ID)
+
+	return o.userOrderResponse(order)
+}
+
+// AddBulk adds orders of provided user for several dates in one transaction,
+// if one of orders can't be created nothing is created and
+// index of that order is returned with error, otherwise index is -1
+func (o OrderRepo) AddBulk(userID string, dates []time.Time, newOrders []models.OrderRequest) ([]models.UserOrder, int, error) {
+	var orders []domain.Order
+	var orderIDs []uuid.UUID
+
+	tx := config.DB.Begin()
+
+	for i, date := range dates {
+		order, err := o.add(tx, userID, date, newOrders[i])
+
+		if err != nil {
+			tx.Rollback()
+			return nil, i, err
+		}
+
+		orders = append(orders, order)
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, -1, err
+	}
+
+	publishOrderEvents(enums.WebhookEventsEnum.OrderCreated, orderIDs...)
+
+	userOrders := make([]models.UserOrder, 0, len(orders))
+
+	for _, order := range orders {
+		userOrder, err := o.userOrderResponse(order)
+
+		if err != nil {
+			return nil, -1, err
+		}
+
+		userOrders = append(userOrders, userOrder)
+	}
+
+	return userOrders, -1, nil
+}
+
+// add creates order with order dishes and user order in provided transaction
+func (o OrderRepo) add(tx *gorm.DB, userID string, date time.Time, newOrder models.OrderRequest) (domain.Order, error) {
 	var orderExist int
-	var order domain.Order
-	var userOrder domain.UserOrders
-	var total float32
-	var userOrderResponse models.UserOrder
 
-	config.DB.
+	// lock user row, so concurrent requests of the same user
+	// can't create two orders for one day
+	if err := tx.
+		Set("gorm:query_option", "FOR UPDATE").
+		Where("id = ?", userID).
+		First(&domain.User{}).
+		Error; err != nil {
+		return domain.Order{}, err
+	}
+
+	tx.
 		Model(&domain.UserOrders{}).
 		Select("user_orders.*").
 		Joins("left join orders o on user_orders.order_id = o.id").
-		Where("user_orders.user_id = ? AND o.date = ? AND o.status != ?",
-			userID, date, enums.OrderStatusTypesEnum.Canceled).
+		Where("user_orders.user_id = ? AND o.date = ? AND o.status NOT IN (?)",
+			userID, date, []string{enums.OrderStatusTypesEnum.Canceled, enums.OrderStatusTypesEnum.Rejected}).
 		Count(&orderExist)
 
 	if orderExist != 0 {
-		return models.UserOrder{}, errors.New("order for current day already created")
+		return domain.Order{}, errors.New("order for current day already created")
 	}
 
-	config.DB.Create(&order)
+	orderDishes, total, err := o.snapshotDishes(tx, userID, date, newOrder.Items)
 
-	for _, dish := range newOrder.Items {
-		var price []float32
+	if err != nil {
+		return domain.Order{}, err
+	}
 
-		orderDish := domain.OrderDishes{
-			OrderID: order.ID,
-			DishID:  dish.DishID,
-			Amount:  dish.Amount,
-		}
+	if err := o.reservePortions(tx, userID, date, nil, orderDishes); err != nil {
+		return domain.Order{}, err
+	}
 
-		if err := config.DB.Create(&orderDish).Error; err != nil {
-			return models.UserOrder{}, err
-		}
+	companyShare, employeeShare, err := orderShares(tx, userID, orderDishes, total)
 
-		config.DB.
-			Model(&domain.Dish{}).
-			Where("id = ?", dish.DishID).
-			Pluck("price", &price)
+	if err != nil {
+		return domain.Order{}, err
+	}
 
-		total += price[0] * float32(dish.Amount)
+	if err := checkBudget(tx, userID, date, companyShare, uuid.Nil); err != nil {
+		return domain.Order{}, err
+	}
 
-		order.Total = &total
-		order.Date = date
-		order.Status = &enums.OrderStatusTypesEnum.Pending
-		order.Comment = &newOrder.Comment
+	order := domain.Order{
+		Total:         &total,
+		CompanyShare:  &companyShare,
+		EmployeeShare: &employeeShare,
+		Date:          date,
+		Status:        &enums.OrderStatusTypesEnum.Pending,
+		Comment:       &newOrder.Comment,
+	}
+
+	if err := tx.Create(&order).Error; err != nil {
+		return domain.Order{}, err
+	}
+
+	for i := range orderDishes {
+		orderDishes[i].OrderID = order.ID
 
-		parsedUserID, _ := uuid.FromString(userID)
-		userOrder = domain.UserOrders{
-			UserID:  parsedUserID,
-			OrderID: order.ID,
+		if err := tx.Create(&orderDishes[i]).Error; err != nil {
+			return domain.Order{}, err
 		}
 	}
 
-	config.DB.
-		Model(&order).
-		Update(&order)
+	parsedUserID, _ := uuid.FromString(userID)
+	userOrder := domain.UserOrders{
+		UserID:  parsedUserID,
+		OrderID: order.ID,
+	}
 
-	if err := config.DB.
+	if err := tx.
 		Create(&userOrder).
 		Error; err != nil {
-		return models.UserOrder{}, err
+		return domain.Order{}, err
+	}
+
+	if err := addOrderEvent(tx, enums.WebhookEventsEnum.OrderCreated, order.ID); err != nil {
+		return domain.Order{}, err
 	}
 
-	if err := o.getDishesForOrder(userOrder.OrderID, &userOrderResponse.Items); err != nil {
+	return order, nil
+}
+
+// userOrderResponse returns created order with its dishes
+func (o OrderRepo) userOrderResponse(order domain.Order) (models.UserOrder, error) {
+	var userOrderResponse models.UserOrder
+
+	if err := o.getDishesForOrder(order.ID, &userOrderResponse); err != nil {
 		return models.UserOrder{}, err
 	}
 
-	userOrderResponse.OrderID = userOrder.OrderID
-	userOrderResponse.Total = total
+	userOrderResponse.OrderID = order.ID
+	userOrderResponse.Total = *order.Total
+	userOrderResponse.CompanyShare = *order.CompanyShare
+	userOrderResponse.EmployeeShare = *order.EmployeeShare
 	userOrderResponse.Status = *order.Status
 
 	return userOrderResponse, nil
 }
 
+// snapshotDishes builds order dishes for provided items
+// with name, category and nutrition copied from current dish,
+// price is taken from the latest meal version published
+// for client of user on date, dish which isn't in it
+// gets current price, returns order dishes and total price
+func (o OrderRepo) snapshotDishes(db *gorm.DB, userID string, date time.Time, items []models.Order) ([]domain.OrderDishes, float32, error) {
+	var orderDishes []domain.OrderDishes
+	var total float32
+
+	prices, err := mealPrices(db, userID, date)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, item := range items {
+		var dish domain.Dish
+		var categoryName []string
+
+		if err := db.
+			Where("id = ?", item.DishID).
+			First(&dish).
+			Error; err != nil {
+			if gorm.IsRecordNotFoundError(err) {
+				return nil, 0, errors.New("dish with that id not found")
+			}
+			return nil, 0, err
+		}
+
+		db.
+			Unscoped().
+			Model(&domain.Category{}).
+			Where("id = ?", dish.CategoryID).
+			Pluck("name", &categoryName)
+
+		price, ok := prices[dish.ID]
+
+		if !ok {
+			price = dish.Price
+		}
+
+		orderDish := domain.OrderDishes{
+			DishID:     dish.ID,
+			Amount:     item.Amount,
+			Name:       dish.Name,
+			Price:      price,
+			CategoryID: dish.CategoryID,
+			Nutrition:  dish.Nutrition,
+		}
+
+		if len(categoryName) != 0 {
+			orderDish.CategoryName = categoryName[0]
+		}
+
+		total += price * float32(item.Amount)
+		orderDishes = append(orderDishes, orderDish)
+	}
+
+	return orderDishes, total, nil
+}
+
+// mealPrices returns prices of dishes of the latest meal version
+// published for client of user on provided date
+func mealPrices(db *gorm.DB, userID string, date time.Time) (map[uuid.UUID]float32, error) {
+	var mealDishes []domain.MealDish
+
+	latestMeal := db.
+		Table("meals as m").
+		Select("m.id").
+		Joins("left join client_users cu on cu.client_id = m.client_id").
+		Where("cu.user_id = ? AND m.date = ? AND m.deleted_at IS NULL", userID, date).
+		Order("m.created_at desc").
+		Limit(1).
+		SubQuery()
+
+	if err := db.
+		Where("meal_id = ?", latestMeal).
+		Find(&mealDishes).
+		Error; err != nil {
+		return nil, err
+	}
+
+	prices := make(map[uuid.UUID]float32, len(mealDishes))
+
+	for _, mealDish := range mealDishes {
+		prices[mealDish.DishID] = mealDish.Price
+	}
+
+	return prices, nil
+}
+
 // CancelOrder changes status of order to canceled
-func (o OrderRepo) CancelOrder(userID, orderID string) (int, error) {
+// and releases reserved portions of its dishes
+func (o OrderRepo) CancelOrder(userID, orderID string, actor domain.User) (int, error) {
 	if err := config.DB.
 		Model(&domain.UserOrders{}).
 		Where("user_id = ? AND order_id = ?", userID, orderID).
@@ -112,11 +321,57 @@ func (o OrderRepo) CancelOrder(userID, orderID string) (int, error) {
 		return http.StatusBadRequest, err
 	}
 
-	if err := config.DB.
-		Model(&domain.Order{}).
+	tx := config.DB.Begin()
+
+	if code, err := o.cancel(tx, userID, orderID, actor); err != nil {
+		tx.Rollback()
+		return code, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	publishOrderEvents(enums.WebhookEventsEnum.OrderCanceled, uuid.FromStringOrNil(orderID))
+
+	return 0, nil
+}
+
+// CancelBulk cancels provided orders of user in one transaction,
+// if one of orders can't be canceled nothing is changed
+func (o OrderRepo) CancelBulk(userID string, orderIDs []string, actor domain.User) (int, error) {
+	var canceledIDs []uuid.UUID
+
+	tx := config.DB.Begin()
+
+	for _, orderID := range orderIDs {
+		if code, err := o.cancel(tx, userID, orderID, actor); err != nil {
+			tx.Rollback()
+			return code, err
+		}
+		canceledIDs = append(canceledIDs, uuid.FromStringOrNil(orderID))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	publishOrderEvents(enums.WebhookEventsEnum.OrderCanceled, canceledIDs...)
+
+	return 0, nil
+}
+
+// cancel changes status of pending order to canceled
+// and releases its portions in provided transaction
+func (o OrderRepo) cancel(tx *gorm.DB, userID, orderID string, actor domain.User) (int, error) {
+	var order domain.Order
+	var orderDishes []domain.OrderDishes
+	var clientUser domain.ClientUser
+
+	if err := tx.
+		Set("gorm:query_option", "FOR UPDATE").
 		Where("id = ? AND status = ?", orderID, enums.OrderStatusTypesEnum.Pending).
-		Find(&domain.Order{}).
-		Update("status", enums.OrderStatusTypesEnum.Canceled).
+		First(&order).
 		Error; err != nil {
 		if gorm.IsRecordNotFoundError(err) {
 			return http.StatusNotFound, errors.New("order not found or already canceled/approved")
@@ -124,18 +379,372 @@ func (o OrderRepo) CancelOrder(userID, orderID string) (int, error) {
 		return http.StatusBadRequest, err
 	}
 
+	if err := tx.
+		Model(&order).
+		Update("status", enums.OrderStatusTypesEnum.Canceled).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := addNotification(tx, userID, order.ID, order.Date, enums.OrderStatusTypesEnum.Pending,
+		enums.OrderStatusTypesEnum.Canceled, ""); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := addOrderEvent(tx, enums.WebhookEventsEnum.OrderCanceled, order.ID); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := tx.
+		Unscoped().
+		Where("user_id = ?", userID).
+		First(&clientUser).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if err := addAuditEvent(tx, actor, orderAuditEvent(clientUser.ClientID.String(), order.ID),
+		orderAuditState{Status: enums.OrderStatusTypesEnum.Pending},
+		orderAuditState{Status: enums.OrderStatusTypesEnum.Canceled}); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	tx.
+		Where("order_id = ?", order.ID).
+		Find(&orderDishes)
+
+	if err := o.reservePortions(tx, userID, order.Date, orderDishes, nil); err != nil {
+		return http.StatusBadRequest, err
+	}
+
 	return 0, nil
 }
 
-// GetUserOrder returns order for provided date for certain user
+// GetByDates returns not canceled orders of user
+// for dates between from and to inclusive
+func (o OrderRepo) GetByDates(userID string, from, to time.Time) ([]domain.Order, int, error) {
+	var orders []domain.Order
+
+	if err := config.DB.
+		Model(&domain.Order{}).
+		Select("orders.*").
+		Joins("left join user_orders uo on uo.order_id = orders.id").
+		Where("uo.user_id = ? AND orders.date BETWEEN ? AND ? AND orders.status != ?",
+			userID, from, to, enums.OrderStatusTypesEnum.Canceled).
+		Order("orders.date").
+		Find(&orders).
+		Error; err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	return orders, 0, nil
+}
+
+// reservePortions changes reserved portions of limited dishes
+// in meal published for user's client on provided date
+// by difference between previous and new order dishes,
+// returns error if there are not enough portions left
+func (o OrderRepo) reservePortions(tx *gorm.DB, userID string, date time.Time, prevDishes, newDishes []domain.OrderDishes) error {
+	var dishIDs []uuid.UUID
+	amounts := make(map[uuid.UUID]int)
+	names := make(map[uuid.UUID]string)
+
+	for _, dish := range prevDishes {
+		amounts[dish.DishID] -= dish.Amount
+		names[dish.DishID] = dish.Name
+	}
+
+	for _, dish := range newDishes {
+		amounts[dish.DishID] += dish.Amount
+		names[dish.DishID] = dish.Name
+	}
+
+	for dishID, amount := range amounts {
+		if amount != 0 {
+			dishIDs = append(dishIDs, dishID)
+		}
+	}
+
+	// lock rows in the same order to avoid deadlocks
+	// between concurrent orders
+	sort.Slice(dishIDs, func(i, j int) bool {
+		return dishIDs[i].String() < dishIDs[j].String()
+	})
+
+	mealIDs := tx.
+		Table("meals as m").
+		Select("m.meal_id").
+		Joins("left join client_users cu on cu.client_id = m.client_id").
+		Where("cu.user_id = ? AND m.date = ? AND m.deleted_at IS NULL", userID, date).
+		SubQuery()
+
+	for _, dishID := range dishIDs {
+		var portion domain.MealDishPortion
+		amount := amounts[dishID]
+
+		if notLimited := tx.
+			Set("gorm:query_option", "FOR UPDATE").
+			Where("dish_id = ? AND meal_id IN ?", dishID, mealIDs).
+			First(&portion).
+			RecordNotFound(); notLimited {
+			continue
+		}
+
+		remaining := portion.Portions - portion.Reserved
+
+		if amount > remaining {
+			if remaining <= 0 {
+				return errors.New(names[dishID] + " is sold out")
+			}
+			return fmt.Errorf("only %d portions of %s left", remaining, names[dishID])
+		}
+
+		reserved := portion.Reserved + amount
+
+		if reserved < 0 {
+			reserved = 0
+		}
+
+		if err := tx.
+			Model(&portion).
+			Update("reserved", reserved).
+			Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByID returns order of provided user
+// and status code with error if it doesn't exist
+func (o OrderRepo) GetByID(userID, orderID string) (domain.Order, int, error) {
+	var order domain.Order
+
+	if err := config.DB.
+		Model(&domain.Order{}).
+		Select("orders.*").
+		Joins("left join user_orders uo on uo.order_id = orders.id").
+		Where("uo.user_id = ? AND orders.id = ?", userID, orderID).
+		First(&order).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return domain.Order{}, http.StatusNotFound, errors.New("order not found")
+		}
+		return domain.Order{}, http.StatusBadRequest, err
+	}
+
+	return order, 0, nil
+}
+
+// Update replaces dishes and comment of pending order
+// recalculates total and saves list of changes
+func (o OrderRepo) Update(userID, orderID string, newOrder models.OrderRequest) (models.UpdatedUserOrder, int, error) {
+	var order domain.Order
+	var prevDishes []domain.OrderDishes
+	var result models.UpdatedUserOrder
+
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Set("gorm:query_option", "FOR UPDATE").
+		Model(&domain.Order{}).
+		Select("orders.*").
+		Joins("left join user_orders uo on uo.order_id = orders.id").
+		Where("uo.user_id = ? AND orders.id = ?", userID, orderID).
+		First(&order).
+		Error; err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return models.UpdatedUserOrder{}, http.StatusNotFound, errors.New("order not found")
+		}
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	if *order.Status != enums.OrderStatusTypesEnum.Pending {
+		tx.Rollback()
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, errors.New("only pending order can be updated")
+	}
+
+	tx.
+		Where("order_id = ?", order.ID).
+		Find(&prevDishes)
+
+	orderDishes, total, err := o.snapshotDishes(tx, userID, order.Date, newOrder.Items)
+
+	if err != nil {
+		tx.Rollback()
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := o.reservePortions(tx, userID, order.Date, prevDishes, orderDishes); err != nil {
+		tx.Rollback()
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	companyShare, employeeShare, err := orderShares(tx, userID, orderDishes, total)
+
+	if err != nil {
+		tx.Rollback()
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := checkBudget(tx, userID, order.Date, companyShare, order.ID); err != nil {
+		tx.Rollback()
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	parsedUserID, _ := uuid.FromString(userID)
+	changes := orderChanges(prevDishes, orderDishes)
+
+	if prevComment := utils.DerefString(order.Comment); prevComment != newOrder.Comment {
+		changes = append(changes, domain.OrderChange{
+			Field:  enums.OrderChangeFieldsEnum.Comment,
+			Before: prevComment,
+			After:  newOrder.Comment,
+		})
+	}
+
+	if order.Total == nil || *order.Total != total {
+		var prevTotal float32
+		if order.Total != nil {
+			prevTotal = *order.Total
+		}
+		changes = append(changes, domain.OrderChange{
+			Field:  enums.OrderChangeFieldsEnum.Total,
+			Before: strconv.FormatFloat(float64(prevTotal), 'f', -1, 32),
+			After:  strconv.FormatFloat(float64(total), 'f', -1, 32),
+		})
+	}
+
+	if err := tx.
+		Unscoped().
+		Where("order_id = ?", order.ID).
+		Delete(&domain.OrderDishes{}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	for i := range orderDishes {
+		orderDishes[i].OrderID = order.ID
+
+		if err := tx.Create(&orderDishes[i]).Error; err != nil {
+			tx.Rollback()
+			return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+		}
+	}
+
+	if err := tx.
+		Model(&domain.Order{}).
+		Where("id = ?", order.ID).
+		Update(map[string]interface{}{
+			"total":          total,
+			"company_share":  companyShare,
+			"employee_share": employeeShare,
+			"comment":        newOrder.Comment,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	for i := range changes {
+		changes[i].OrderID = order.ID
+		changes[i].UserID = parsedUserID
+
+		if err := tx.Create(&changes[i]).Error; err != nil {
+			tx.Rollback()
+			return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+		}
+	}
+
+	if err := addOrderEvent(tx, enums.WebhookEventsEnum.OrderUpdated, order.ID); err != nil {
+		tx.Rollback()
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	publishOrderEvents(enums.WebhookEventsEnum.OrderUpdated, order.ID)
+
+	if err := o.getDishesForOrder(order.ID, &result.UserOrder); err != nil {
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	result.OrderID = order.ID
+	result.Total = total
+	result.CompanyShare = companyShare
+	result.EmployeeShare = employeeShare
+	result.Status = *order.Status
+	result.Changes = changes
+
+	if result.Changes == nil {
+		result.Changes = make([]domain.OrderChange, 0)
+	}
+
+	return result, 0, nil
+}
+
+// orderChanges compares previous and new dishes of order
+// and returns amount changes for each dish
+func orderChanges(prevDishes, newDishes []domain.OrderDishes) []domain.OrderChange {
+	var changes []domain.OrderChange
+	prevAmounts := make(map[uuid.UUID]domain.OrderDishes)
+
+	for _, dish := range prevDishes {
+		prevAmounts[dish.DishID] = dish
+	}
+
+	for _, dish := range newDishes {
+		prev, ok := prevAmounts[dish.DishID]
+		delete(prevAmounts, dish.DishID)
+
+		if ok && prev.Amount == dish.Amount {
+			continue
+		}
+
+		dishID := dish.DishID
+		changes = append(changes, domain.OrderChange{
+			Field:  enums.OrderChangeFieldsEnum.Dish,
+			DishID: &dishID,
+			Name:   dish.Name,
+			Before: strconv.Itoa(prev.Amount),
+			After:  strconv.Itoa(dish.Amount),
+		})
+	}
+
+	for _, dish := range prevDishes {
+		if _, removed := prevAmounts[dish.DishID]; !removed {
+			continue
+		}
+
+		dishID := dish.DishID
+		changes = append(changes, domain.OrderChange{
+			Field:  enums.OrderChangeFieldsEnum.Dish,
+			DishID: &dishID,
+			Name:   dish.Name,
+			Before: strconv.Itoa(dish.Amount),
+			After:  "0",
+		})
+	}
+
+	return changes
+}
+
+// GetUserOrder returns order for provided date for certain user,
+// if order was rejected and user ordered again the latest one is returned
 func (o OrderRepo) GetUserOrder(userID, date string) (models.UserOrder, int, error) {
 	var userOrder models.UserOrder
 
 	if err := config.DB.
 		Model(&domain.UserOrders{}).
-		Select("distinct on (o.id) o.id as order_id, o.total, o.status").
+		Select("o.id as order_id, o.total, o.company_share, o.employee_share, o.status, o.reason").
 		Joins("left join orders o on user_orders.order_id = o.id").
 		Where("user_orders.user_id = ? AND o.date = ? AND o.status != ?", userID, date, enums.OrderStatusTypesEnum.Canceled).
+		Order("o.created_at desc").
 		Scan(&userOrder).
 		Error; err != nil {
 		if gorm.IsRecordNotFoundError(err) {
@@ -144,31 +753,103 @@ func (o OrderRepo) GetUserOrder(userID, date string) (models.UserOrder, int, err
 		return models.UserOrder{}, http.StatusBadRequest, err
 	}
 
-	if err := o.getDishesForOrder(userOrder.OrderID, &userOrder.Items); err != nil {
+	if err := o.getDishesForOrder(userOrder.OrderID, &userOrder); err != nil {
 		return models.UserOrder{}, http.StatusBadRequest, err
 	}
 
 	return userOrder, 0, nil
 }
 
+// GetHistory returns page of orders of user for range of dates
+// filtered by statuses with totals grouped by provided period,
+// canceled and rejected orders aren't counted in totals
+func (o OrderRepo) GetHistory(userID string, from, to time.Time, statuses []string, groupBy string, query url.PaginationQuery) (models.OrderHistory, int, error) {
+	result := models.OrderHistory{
+		Items:   make([]models.UserOrderHistory, 0),
+		Periods: make([]models.OrderHistoryPeriod, 0),
+		Page:    query.Page,
+	}
+
+	orders := config.DB.
+		Model(&domain.Order{}).
+		Joins("left join user_orders uo on uo.order_id = orders.id").
+		Where("uo.user_id = ? AND orders.date BETWEEN ? AND ?", userID, from, to)
+
+	if len(statuses) != 0 {
+		orders = orders.Where("orders.status IN (?)", statuses)
+	}
+
+	orders.Count(&result.Total)
+
+	if err := orders.
+		Select("orders.id as order_id, orders.date, orders.total, orders.company_share," +
+			" orders.employee_share, orders.status, orders.reason, coalesce(orders.comment, '') as comment").
+		Order("orders.date desc, orders.created_at desc").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Scan(&result.Items).
+		Error; err != nil {
+		return models.OrderHistory{}, http.StatusBadRequest, err
+	}
+
+	for i := range result.Items {
+		if err := o.getDishesForOrder(result.Items[i].OrderID, &result.Items[i].UserOrder); err != nil {
+			return models.OrderHistory{}, http.StatusBadRequest, err
+		}
+	}
+
+	if err := orders.
+		Select("to_char(date_trunc(?, orders.date), 'YYYY-MM-DD') as period, count(*) as orders,"+
+			" coalesce(sum(orders.total), 0) as total, coalesce(sum(orders.company_share), 0) as company_share,"+
+			" coalesce(sum(orders.employee_share), 0) as employee_share, coalesce(sum(n.kcal), 0) as kcal,"+
+			" coalesce(sum(n.protein), 0) as protein, coalesce(sum(n.fat), 0) as fat,"+
+			" coalesce(sum(n.carbs), 0) as carbs", groupBy).
+		Joins(orderNutritionJoin("orders.id")).
+		Where("orders.status NOT IN (?)",
+			[]string{enums.OrderStatusTypesEnum.Canceled, enums.OrderStatusTypesEnum.Rejected}).
+		Group("period").
+		Order("period").
+		Scan(&result.Periods).
+		Error; err != nil {
+		return models.OrderHistory{}, http.StatusBadRequest, err
+	}
+
+	return result, 0, nil
+}
+
+// GetDishes returns dishes of order
+func (o OrderRepo) GetDishes(orderID string) ([]domain.OrderDishes, error) {
+	var orderDishes []domain.OrderDishes
+
+	if err := config.DB.
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&orderDishes).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return orderDishes, nil
+}
+
 // GetOrders return list of orders for catering or client
 func (o OrderRepo) GetOrders(cateringID, clientID, date, companyType string) (models.SummaryOrderResult, int, error) {
 	var result models.SummaryOrderResult
 
 	if companyType == enums.CompanyTypesEnum.Client {
+		// rejected orders are shown to client, but aren't counted in summary
+		excludedStatuses := []string{enums.OrderStatusTypesEnum.Canceled, enums.OrderStatusTypesEnum.Rejected}
 		result.Status = o.GetOrdersStatus(clientID, date)
 
 		if err := config.DB.
 			Model(&domain.User{}).
-			Select("distinct on (c.id) c.name as category_name, c.id as category_id").
+			Select("distinct on (od.category_id) od.category_name as category_name, od.category_id as category_id").
 			Joins("left join client_users cu on cu.user_id = users.id").
 			Joins("left join user_orders uo on uo.user_id = users.id").
 			Joins("left join orders o on uo.order_id = o.id").
 			Joins("left join order_dishes od on od.order_id = o.id").
-			Joins("left join dishes d on od.dish_id = d.id").
-			Joins("left join categories c on c.id = d.category_id").
 			Where("cu.client_id = ? AND users.company_type = ? AND o.date = ?"+
-				" AND o.status != ?", clientID, enums.CompanyTypesEnum.Client, date, enums.OrderStatusTypesEnum.Canceled).
+				" AND o.status NOT IN (?)", clientID, enums.CompanyTypesEnum.Client, date, excludedStatuses).
 			Scan(&result.SummaryOrders).
 			Error; err != nil {
 			return models.SummaryOrderResult{}, http.StatusBadRequest, err
@@ -177,17 +858,15 @@ func (o OrderRepo) GetOrders(cateringID, clientID, date, companyType string) (mo
 		for i := range result.SummaryOrders {
 			if err := config.DB.
 				Model(&domain.User{}).
-				Select("d.name, sum(od.amount) as amount").
+				Select("od.name, sum(od.amount) as amount").
 				Joins("left join client_users cu on cu.user_id = users.id").
 				Joins("left join user_orders uo on uo.user_id = users.id").
 				Joins("left join orders o on uo.order_id = o.id").
 				Joins("left join order_dishes od on od.order_id = o.id").
-				Joins("left join dishes d on od.dish_id = d.id").
-				Joins("left join categories c on c.id = d.category_id").
 				Where("cu.client_id = ? AND users.company_type = ? AND o.date = ?"+
-					" AND c.id = ? and o.status != ?",
-					clientID, enums.CompanyTypesEnum.Client, date, result.SummaryOrders[i].ID, enums.OrderStatusTypesEnum.Canceled).
-				Group("d.name").
+					" AND od.category_id = ? and o.status NOT IN (?)",
+					clientID, enums.CompanyTypesEnum.Client, date, result.SummaryOrders[i].ID, excludedStatuses).
+				Group("od.name").
 				Scan(&result.SummaryOrders[i].Items).
 				Error; err != nil {
 				return models.SummaryOrderResult{}, http.StatusBadRequest, err
@@ -196,10 +875,13 @@ func (o OrderRepo) GetOrders(cateringID, clientID, date, companyType string) (mo
 		if err := config.DB.
 			Model(&domain.User{}).
 			Select("concat_ws(' ', users.last_name, users.first_name) as full_name,"+
-				" cu.floor, users.id, o.total, o.comment").
+				" cu.floor, users.id, o.id as order_id, o.status, o.reason, o.total, o.company_share, o.employee_share, o.comment,"+
+				" coalesce(n.kcal, 0) as kcal, coalesce(n.protein, 0) as protein, coalesce(n.fat, 0) as fat,"+
+				" coalesce(n.carbs, 0) as carbs").
 			Joins("left join client_users cu on cu.user_id = users.id").
 			Joins("left join user_orders uo on uo.user_id = users.id").
 			Joins("left join orders o on uo.order_id = o.id").
+			Joins(orderNutritionJoin("o.id")).
 			Where("cu.client_id = ? AND users.company_type = ? AND o.date = ?"+
 				" AND o.status != ?", clientID, enums.CompanyTypesEnum.Client, date, enums.OrderStatusTypesEnum.Canceled).
 			Scan(&result.UserOrders).
@@ -209,23 +891,28 @@ func (o OrderRepo) GetOrders(cateringID, clientID, date, companyType string) (mo
 
 		for i := range result.UserOrders {
 			if err := config.DB.
-				Model(&domain.User{}).
-				Select("d.name, od.amount").
-				Joins("left join client_users cu on cu.user_id = users.id").
-				Joins("left join user_orders uo on uo.user_id = users.id").
-				Joins("left join orders o on uo.order_id = o.id").
-				Joins("left join order_dishes od on od.order_id = o.id").
-				Joins("left join dishes d on od.dish_id = d.id").
-				Where("cu.client_id = ? AND users.company_type = ? AND o.date = ?"+
-					" AND uo.user_id = ? AND o.status != ?",
-					clientID, enums.CompanyTypesEnum.Client, date, result.UserOrders[i].ID, enums.OrderStatusTypesEnum.Canceled).
+				Model(&domain.OrderDishes{}).
+				Select("name, amount").
+				Where("order_id = ?", result.UserOrders[i].OrderID).
 				Scan(&result.UserOrders[i].Items).
 				Error; err != nil {
 				return models.SummaryOrderResult{}, http.StatusBadRequest, err
 			}
-			result.Total += result.UserOrders[i].Total
+			if result.UserOrders[i].Status != enums.OrderStatusTypesEnum.Rejected {
+				result.Total += result.UserOrders[i].Total
+				result.CompanyTotal += result.UserOrders[i].CompanyShare
+				result.EmployeeTotal += result.UserOrders[i].EmployeeShare
+			}
+		}
+
+		delivery, err := o.GetDeliveryChanges(clientID, date)
+
+		if err != nil {
+			return models.SummaryOrderResult{}, http.StatusBadRequest, err
 		}
 
+		result.Delivery = delivery
+
 		return result, 0, nil
 	}
 
@@ -238,15 +925,13 @@ func (o OrderRepo) GetOrders(cateringID, clientID, date, companyType string) (mo
 
 	if err := config.DB.
 		Model(&domain.User{}).
-		Select("distinct on (c.id) c.name as category_name, c.id as category_id").
+		Select("distinct on (od.category_id) od.category_name as category_name, od.category_id as category_id").
 		Joins("left join client_users cu on cu.user_id = users.id").
 		Joins("left join user_orders uo on uo.user_id = users.id").
 		Joins("left join orders o on uo.order_id = o.id").
 		Joins("left join order_dishes od on od.order_id = o.id").
-		Joins("left join dishes d on od.dish_id = d.id").
-		Joins("left join categories c on c.id = d.category_id").
-		Where("cu.client_id = ? AND users.company_type = ? AND o.date = ? AND o.status = ?",
-			clientID, enums.CompanyTypesEnum.Client, date, enums.OrderStatusTypesEnum.Approved).
+		Where("cu.client_id = ? AND users.company_type = ? AND o.date = ? AND o.status IN (?)",
+			clientID, enums.CompanyTypesEnum.Client, date, cateringStatuses).
 		Scan(&result.SummaryOrders).
 		Error; err != nil {
 		return models.SummaryOrderResult{}, http.StatusBadRequest, err
@@ -255,17 +940,15 @@ func (o OrderRepo) GetOrders(cateringID, clientID, date, companyType string) (mo
 	for i := range result.SummaryOrders {
 		if err := config.DB.
 			Model(&domain.User{}).
-			Select("d.name, sum(od.amount) as amount").
+			Select("od.name, sum(od.amount) as amount").
 			Joins("left join client_users cu on cu.user_id = users.id").
 			Joins("left join user_orders uo on uo.user_id = users.id").
 			Joins("left join orders o on uo.order_id = o.id").
 			Joins("left join order_dishes od on od.order_id = o.id").
-			Joins("left join dishes d on od.dish_id = d.id").
-			Joins("left join categories c on c.id = d.category_id").
 			Where("cu.client_id = ? AND users.company_type = ? AND o.date = ?"+
-				" AND c.id = ? AND o.status = ?",
-				clientID, enums.CompanyTypesEnum.Client, date, result.SummaryOrders[i].ID, enums.OrderStatusTypesEnum.Approved).
-			Group("d.name").
+				" AND od.category_id = ? AND o.status IN (?)",
+				clientID, enums.CompanyTypesEnum.Client, date, result.SummaryOrders[i].ID, cateringStatuses).
+			Group("od.name").
 			Scan(&result.SummaryOrders[i].Items).
 			Error; err != nil {
 			return models.SummaryOrderResult{}, http.StatusBadRequest, err
@@ -275,12 +958,15 @@ func (o OrderRepo) GetOrders(cateringID, clientID, date, companyType string) (mo
 	if err := config.DB.
 		Model(&domain.User{}).
 		Select("concat_ws(' ', users.last_name, users.first_name) as full_name,"+
-			" cu.floor, users.id, o.total, o.comment").
+			" cu.floor, users.id, o.id as order_id, o.status, o.reason, o.total, o.company_share, o.employee_share, o.comment,"+
+			" coalesce(n.kcal, 0) as kcal, coalesce(n.protein, 0) as protein, coalesce(n.fat, 0) as fat,"+
+			" coalesce(n.carbs, 0) as carbs").
 		Joins("left join client_users cu on cu.user_id = users.id").
 		Joins("left join user_orders uo on uo.user_id = users.id").
 		Joins("left join orders o on uo.order_id = o.id").
+		Joins(orderNutritionJoin("o.id")).
 		Where("cu.client_id = ? AND users.company_type = ? AND o.date = ?"+
-			" AND o.status = ?", clientID, enums.CompanyTypesEnum.Client, date, enums.OrderStatusTypesEnum.Approved).
+			" AND o.status IN (?)", clientID, enums.CompanyTypesEnum.Client, date, cateringStatuses).
 		Scan(&result.UserOrders).
 		Error; err != nil {
 		return models.SummaryOrderResult{}, http.StatusBadRequest, err
@@ -289,89 +975,610 @@ func (o OrderRepo) GetOrders(cateringID, clientID, date, companyType string) (mo
 	for i := range result.UserOrders {
 		if err := config.DB.
 			Model(&domain.User{}).
-			Select("d.name, od.amount").
+			Select("od.name, od.amount").
 			Joins("left join client_users cu on cu.user_id = users.id").
 			Joins("left join user_orders uo on uo.user_id = users.id").
 			Joins("left join orders o on uo.order_id = o.id").
 			Joins("left join order_dishes od on od.order_id = o.id").
-			Joins("left join dishes d on od.dish_id = d.id").
 			Where("cu.client_id = ? AND users.company_type = ? AND o.date = ?"+
-				" AND uo.user_id = ? AND o.status = ?", clientID, enums.CompanyTypesEnum.Client, date, result.UserOrders[i].ID, enums.OrderStatusTypesEnum.Approved).
+				" AND uo.user_id = ? AND o.status IN (?)", clientID, enums.CompanyTypesEnum.Client, date, result.UserOrders[i].ID, cateringStatuses).
 			Scan(&result.UserOrders[i].Items).
 			Error; err != nil {
 			return models.SummaryOrderResult{}, http.StatusBadRequest, err
 		}
 		result.Total += result.UserOrders[i].Total
+		result.CompanyTotal += result.UserOrders[i].CompanyShare
+		result.EmployeeTotal += result.UserOrders[i].EmployeeShare
 	}
 
-	if len(result.SummaryOrders) != 0 {
-		result.Status = &enums.OrderStatusTypesEnum.Approved
+	counts := o.GetOrdersStatusCounts(clientID, date)
+	delete(counts, enums.OrderStatusTypesEnum.Pending)
+	delete(counts, enums.OrderStatusTypesEnum.Rejected)
+	result.Status = ordersStatus(counts)
+
+	delivery, err := o.GetDeliveryChanges(clientID, date)
+
+	if err != nil {
+		return models.SummaryOrderResult{}, http.StatusBadRequest, err
 	}
 
+	result.Delivery = delivery
+
 	return result, 0, nil
 }
 
-// ApproveOrders changes status of orders to approved
-func (o OrderRepo) ApproveOrders(clientID, date string) error {
-	var orderIDs []struct {
-		ID string
+// ApproveOrders changes status of pending orders to approved,
+// every approved user is notified, empty actor is system
+func (o OrderRepo) ApproveOrders(clientID, date string, actor domain.User) error {
+	var orders []struct {
+		ID     uuid.UUID
+		UserID string
+		Date   time.Time
 	}
 
 	if areOrdersExist := config.DB.
 		Table("orders as o").
-		Select("o.id").
+		Select("o.id, uo.user_id, o.date").
 		Joins("left join user_orders uo on uo.order_id = o.id").
 		Joins("left join users u on uo.user_id = u.id").
 		Joins("left join client_users cu on uo.user_id = cu.user_id").
 		Where("cu.client_id = ? AND u.company_type = ? AND o.date = ?"+
 			" AND o.status != ?", clientID, enums.CompanyTypesEnum.Client, date, enums.OrderStatusTypesEnum.Canceled).
-		Scan(&orderIDs).
+		Scan(&orders).
 		RowsAffected; areOrdersExist == 0 {
 		return errors.New("client id is not found or no orders to approve for provided day")
 	}
 
-	for _, order := range orderIDs {
-		config.DB.
+	var approvedIDs []uuid.UUID
+
+	tx := config.DB.Begin()
+
+	// rejected orders stay rejected, they are approved only one by one
+	for _, order := range orders {
+		approved := tx.
 			Model(&domain.Order{}).
-			Where("id = ? and status != ?", order.ID, enums.OrderStatusTypesEnum.Canceled).
+			Where("id = ? and status = ?", order.ID, enums.OrderStatusTypesEnum.Pending).
 			Update("status", enums.OrderStatusTypesEnum.Approved)
+
+		if approved.Error != nil {
+			tx.Rollback()
+			return approved.Error
+		}
+
+		if approved.RowsAffected == 0 {
+			continue
+		}
+
+		if err := addNotification(tx, order.UserID, order.ID, order.Date, enums.OrderStatusTypesEnum.Pending,
+			enums.OrderStatusTypesEnum.Approved, ""); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := addOrderEvent(tx, enums.WebhookEventsEnum.OrderApproved, order.ID); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := addAuditEvent(tx, actor, orderAuditEvent(clientID, order.ID),
+			orderAuditState{Status: enums.OrderStatusTypesEnum.Pending},
+			orderAuditState{Status: enums.OrderStatusTypesEnum.Approved}); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		approvedIDs = append(approvedIDs, order.ID)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
 	}
 
+	publishOrderEvents(enums.WebhookEventsEnum.OrderApproved, approvedIDs...)
+
 	return nil
 }
 
-func (o OrderRepo) getDishesForOrder(orderID uuid.UUID, dishes *[]models.OrderItem) error {
+// getDishesForOrder sets dishes of order
+// and total nutrition facts of its dishes
+func (o OrderRepo) getDishesForOrder(orderID uuid.UUID, order *models.UserOrder) error {
 	if err := config.DB.
 		Model(&domain.OrderDishes{}).
-		Select("distinct on (d.id) d.name, d.price, d.id as dish_id, i.path as path, order_dishes.amount").
-		Joins("left join dishes d on order_dishes.dish_id = d.id").
-		Joins("left join image_dishes id on d.id = id.dish_id").
+		Select("distinct on (order_dishes.dish_id) order_dishes.name, order_dishes.price,"+
+			" order_dishes.dish_id, i.path as path, order_dishes.amount, order_dishes.kcal,"+
+			" order_dishes.protein, order_dishes.fat, order_dishes.carbs").
+		Joins("left join image_dishes id on order_dishes.dish_id = id.dish_id").
 		Joins("left join images i on id.image_id = i.id").
 		Where("order_dishes.order_id = ?", orderID).
-		Scan(dishes).
+		Scan(&order.Items).
 		Error; err != nil {
 		return err
 	}
+
+	order.Nutrition = domain.Nutrition{}
+
+	for _, item := range order.Items {
+		order.Nutrition = order.Nutrition.Add(item.Nutrition, item.Amount)
+	}
+
 	return nil
 }
 
-// GetOrdersStatus return order status for provided client
+// orderNutritionJoin returns join of total nutrition facts of orders
+// as n table, orderColumn is column of order id in query
+func orderNutritionJoin(orderColumn string) string {
+	return "left join (select order_id, sum(kcal * amount) as kcal, sum(protein * amount) as protein," +
+		" sum(fat * amount) as fat, sum(carbs * amount) as carbs from order_dishes" +
+		" where deleted_at is null group by order_id) n on n.order_id = " + orderColumn
+}
+
+// GetOrdersStatus return order status for provided client,
+// if orders have different statuses the least progressed one is returned
 func (o OrderRepo) GetOrdersStatus(clientID, date string) *string {
-	var ordersStatus []string
+	return ordersStatus(o.GetOrdersStatusCounts(clientID, date))
+}
+
+// ordersStatus chooses one status for orders with provided
+// number of orders for every status, returns nil if there are no orders
+func ordersStatus(counts map[string]int) *string {
+	for i := range statusesPriority {
+		if counts[statusesPriority[i]] != 0 {
+			return &statusesPriority[i]
+		}
+	}
+
+	return nil
+}
+
+// GetOrdersStatusCounts returns number of not canceled orders
+// of provided client for every status
+func (o OrderRepo) GetOrdersStatusCounts(clientID, date string) map[string]int {
+	var statuses []struct {
+		Status string
+		Count  int
+	}
 
 	config.DB.
 		Model(&domain.User{}).
-		Select("o.status").
+		Select("o.status, count(*) as count").
 		Joins("left join client_users cu on cu.user_id = users.id").
 		Joins("left join user_orders uo on uo.user_id = users.id").
 		Joins("left join orders o on uo.order_id = o.id").
 		Where("cu.client_id = ? AND users.company_type = ? AND o.date = ?"+
 			" AND o.status != ?", clientID, enums.CompanyTypesEnum.Client, date, enums.OrderStatusTypesEnum.Canceled).
-		Pluck("o.status", &ordersStatus)
+		Group("o.status").
+		Scan(&statuses)
+
+	counts := make(map[string]int)
 
-	if len(ordersStatus) == 0 {
-		return nil
+	for _, status := range statuses {
+		counts[status.Status] = status.Count
 	}
 
-	return &ordersStatus[0]
+	return counts
+}
+
+// UpdateOrdersStatus approves or rejects provided orders of client users,
+// portions of rejected orders are released and reserved again on approve
+func (o OrderRepo) UpdateOrdersStatus(clientID string, orderIDs []uuid.UUID, status, reason string, actor domain.User) (int, error) {
+	var orders []struct {
+		domain.Order
+		UserID string
+	}
+	var approvedIDs []uuid.UUID
+	var rejectedIDs []uuid.UUID
+
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Table("orders as o").
+		Select("o.*, uo.user_id").
+		Joins("left join user_orders uo on uo.order_id = o.id").
+		Joins("left join client_users cu on cu.user_id = uo.user_id").
+		Where("cu.client_id = ? AND o.id IN (?) AND o.status != ? AND o.deleted_at IS NULL",
+			clientID, orderIDs, enums.OrderStatusTypesEnum.Canceled).
+		Set("gorm:query_option", "FOR UPDATE OF o").
+		Scan(&orders).
+		Error; err != nil {
+		tx.Rollback()
+		return http.StatusBadRequest, err
+	}
+
+	if len(orders) != len(orderIDs) {
+		tx.Rollback()
+		return http.StatusNotFound, errors.New("orders not found or already canceled")
+	}
+
+	for _, order := range orders {
+		if *order.Status != enums.OrderStatusTypesEnum.Pending &&
+			*order.Status != enums.OrderStatusTypesEnum.Approved &&
+			*order.Status != enums.OrderStatusTypesEnum.Rejected {
+			tx.Rollback()
+			return http.StatusBadRequest, errors.New("order is already in delivery")
+		}
+
+		if *order.Status == status {
+			continue
+		}
+
+		var orderDishes []domain.OrderDishes
+
+		tx.
+			Where("order_id = ?", order.ID).
+			Find(&orderDishes)
+
+		if status == enums.OrderStatusTypesEnum.Rejected {
+			if err := o.reservePortions(tx, order.UserID, order.Date, orderDishes, nil); err != nil {
+				tx.Rollback()
+				return http.StatusBadRequest, err
+			}
+		} else if *order.Status == enums.OrderStatusTypesEnum.Rejected {
+			var orderExist int
+
+			tx.
+				Model(&domain.UserOrders{}).
+				Joins("left join orders o on user_orders.order_id = o.id").
+				Where("user_orders.user_id = ? AND o.date = ? AND o.status NOT IN (?)", order.UserID, order.Date,
+					[]string{enums.OrderStatusTypesEnum.Canceled, enums.OrderStatusTypesEnum.Rejected}).
+				Count(&orderExist)
+
+			if orderExist != 0 {
+				tx.Rollback()
+				return http.StatusBadRequest, errors.New("user already has another order for that day")
+			}
+
+			if err := o.reservePortions(tx, order.UserID, order.Date, nil, orderDishes); err != nil {
+				tx.Rollback()
+				return http.StatusBadRequest, err
+			}
+
+			var companyShare float32
+			if order.CompanyShare != nil {
+				companyShare = *order.CompanyShare
+			}
+
+			if err := checkBudget(tx, order.UserID, order.Date, companyShare, order.ID); err != nil {
+				tx.Rollback()
+				return http.StatusBadRequest, err
+			}
+		}
+
+		var newReason *string
+
+		if status == enums.OrderStatusTypesEnum.Rejected {
+			newReason = &reason
+		}
+
+		if err := tx.
+			Model(&domain.Order{}).
+			Where("id = ?", order.ID).
+			Updates(map[string]interface{}{
+				"status": status,
+				"reason": newReason,
+			}).
+			Error; err != nil {
+			tx.Rollback()
+			return http.StatusBadRequest, err
+		}
+
+		if err := addNotification(tx, order.UserID, order.ID, order.Date, *order.Status, status,
+			utils.DerefString(newReason)); err != nil {
+			tx.Rollback()
+			return http.StatusBadRequest, err
+		}
+
+		if err := addAuditEvent(tx, actor, orderAuditEvent(clientID, order.ID),
+			orderAuditState{Status: *order.Status, Reason: order.Reason},
+			orderAuditState{Status: status, Reason: newReason}); err != nil {
+			tx.Rollback()
+			return http.StatusBadRequest, err
+		}
+
+		if status == enums.OrderStatusTypesEnum.Approved {
+			if err := addOrderEvent(tx, enums.WebhookEventsEnum.OrderApproved, order.ID); err != nil {
+				tx.Rollback()
+				return http.StatusBadRequest, err
+			}
+			approvedIDs = append(approvedIDs, order.ID)
+		} else {
+			rejectedIDs = append(rejectedIDs, order.ID)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	publishOrderEvents(enums.WebhookEventsEnum.OrderApproved, approvedIDs...)
+	publishOrderEvents(enums.WebhookEventsEnum.OrderRejected, rejectedIDs...)
+
+	return 0, nil
+}
+
+// UpdateDeliveryStatus changes status of client orders for date
+// which have one of provided statuses, change is saved with acting user,
+// note of failed delivery is saved as reason of orders
+func (o OrderRepo) UpdateDeliveryStatus(cateringID, clientID, date string, fromStatuses []string, status, note string, actor domain.User) (int, error) {
+	var orders []struct {
+		ID     uuid.UUID
+		Status string
+		UserID string
+	}
+
+	if clientExist := config.DB.
+		Where("id = ? AND catering_id = ?", clientID, cateringID).
+		Find(&domain.Client{}).
+		RowsAffected; clientExist == 0 {
+		return http.StatusNotFound, errors.New("client not found")
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Table("orders as o").
+		Select("o.id, o.status, uo.user_id").
+		Joins("left join user_orders uo on uo.order_id = o.id").
+		Joins("left join client_users cu on cu.user_id = uo.user_id").
+		Where("cu.client_id = ? AND o.date = ? AND o.status IN (?) AND o.deleted_at IS NULL",
+			clientID, date, fromStatuses).
+		Set("gorm:query_option", "FOR UPDATE OF o").
+		Scan(&orders).
+		Error; err != nil {
+		tx.Rollback()
+		return http.StatusBadRequest, err
+	}
+
+	if len(orders) == 0 {
+		tx.Rollback()
+		return http.StatusBadRequest, errors.New("no orders can be moved to " + status + " for provided day")
+	}
+
+	var orderIDs []uuid.UUID
+	changed := make(map[string]bool)
+	parsedDate, _ := time.Parse(time.RFC3339, date)
+	parsedClientID, _ := uuid.FromString(clientID)
+
+	var reason string
+	var auditReason *string
+
+	if status == enums.OrderStatusTypesEnum.Failed {
+		reason = note
+		auditReason = &reason
+	}
+
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+
+		if err := addNotification(tx, order.UserID, order.ID, parsedDate, order.Status, status, reason); err != nil {
+			tx.Rollback()
+			return http.StatusBadRequest, err
+		}
+
+		if err := addAuditEvent(tx, actor, orderAuditEvent(clientID, order.ID),
+			orderAuditState{Status: order.Status},
+			orderAuditState{Status: status, Reason: auditReason}); err != nil {
+			tx.Rollback()
+			return http.StatusBadRequest, err
+		}
+
+		if changed[order.Status] {
+			continue
+		}
+		changed[order.Status] = true
+
+		if err := tx.
+			Create(&domain.OrderStatusChange{
+				ClientID:   parsedClientID,
+				Date:       parsedDate,
+				UserID:     actor.ID,
+				FromStatus: order.Status,
+				ToStatus:   status,
+				Note:       note,
+			}).
+			Error; err != nil {
+			tx.Rollback()
+			return http.StatusBadRequest, err
+		}
+	}
+
+	changes := map[string]interface{}{
+		"status": status,
+	}
+
+	if status == enums.OrderStatusTypesEnum.Failed {
+		changes["reason"] = note
+	}
+
+	if err := tx.
+		Model(&domain.Order{}).
+		Where("id IN (?)", orderIDs).
+		Updates(changes).
+		Error; err != nil {
+		tx.Rollback()
+		return http.StatusBadRequest, err
+	}
+
+	event := enums.WebhookEventsEnum.OrderDeliveryUpdated
+
+	if status == enums.OrderStatusTypesEnum.Delivered {
+		event = enums.WebhookEventsEnum.OrderDelivered
+
+		for _, orderID := range orderIDs {
+			if err := addOrderEvent(tx, enums.WebhookEventsEnum.OrderDelivered, orderID); err != nil {
+				tx.Rollback()
+				return http.StatusBadRequest, err
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	publishOrderEvents(event, orderIDs...)
+
+	return 0, nil
+}
+
+// GetDeliveryChanges returns delivery status changes
+// of client orders for date from the oldest one
+func (o OrderRepo) GetDeliveryChanges(clientID, date string) ([]models.OrderStatusChange, error) {
+	changes := make([]models.OrderStatusChange, 0)
+
+	if err := config.DB.
+		Model(&domain.OrderStatusChange{}).
+		Select("order_status_changes.from_status, order_status_changes.to_status, order_status_changes.note,"+
+			" order_status_changes.created_at as changed_at,"+
+			" concat_ws(' ', u.first_name, u.last_name) as user_name").
+		Joins("left join users u on u.id = order_status_changes.user_id").
+		Where("order_status_changes.client_id = ? AND order_status_changes.date = ?", clientID, date).
+		Order("order_status_changes.created_at").
+		Scan(&changes).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// GetProduction returns portions of dishes of approved orders
+// of all clients of catering for provided date,
+// grouped by category and split by client and floor
+func (o OrderRepo) GetProduction(cateringID string, date time.Time) (models.ProductionSheet, int, error) {
+	var dishes []struct {
+		ClientID     uuid.UUID
+		ClientName   string
+		CategoryID   uuid.UUID
+		CategoryName string
+		DishID       uuid.UUID
+		Name         string
+		Amount       int
+	}
+	var floors []struct {
+		ClientID   uuid.UUID
+		ClientName string
+		Floor      int
+		Orders     int
+		Portions   int
+	}
+	var addresses []domain.Address
+
+	if cateringExist := config.DB.
+		Where("id = ?", cateringID).
+		Find(&domain.Catering{}).
+		RowsAffected; cateringExist == 0 {
+		return models.ProductionSheet{}, http.StatusNotFound, errors.New("catering not found")
+	}
+
+	orders := config.DB.
+		Table("orders as o").
+		Joins("left join user_orders uo on uo.order_id = o.id").
+		Joins("left join client_users cu on cu.user_id = uo.user_id").
+		Joins("left join clients c on c.id = cu.client_id").
+		Joins("left join order_dishes od on od.order_id = o.id").
+		Where("c.catering_id = ? AND o.date = ? AND o.status IN (?) AND o.deleted_at IS NULL"+
+			" AND c.deleted_at IS NULL AND od.deleted_at IS NULL", cateringID, date, cateringStatuses)
+
+	if err := orders.
+		Select("c.id as client_id, c.name as client_name, od.category_id, od.category_name," +
+			" od.dish_id, od.name, sum(od.amount) as amount").
+		Group("c.id, c.name, od.category_id, od.category_name, od.dish_id, od.name").
+		Order("od.category_name, od.category_id, od.name, od.dish_id, c.name").
+		Scan(&dishes).
+		Error; err != nil {
+		return models.ProductionSheet{}, http.StatusBadRequest, err
+	}
+
+	if err := orders.
+		Select("c.id as client_id, c.name as client_name, cu.floor," +
+			" count(distinct o.id) as orders, sum(od.amount) as portions").
+		Group("c.id, c.name, cu.floor").
+		Order("c.name, cu.floor").
+		Scan(&floors).
+		Error; err != nil {
+		return models.ProductionSheet{}, http.StatusBadRequest, err
+	}
+
+	if err := config.DB.
+		Where("client_id IN (?)", config.DB.
+			Model(&domain.Client{}).
+			Select("id").
+			Where("catering_id = ?", cateringID).
+			SubQuery()).
+		Find(&addresses).
+		Error; err != nil {
+		return models.ProductionSheet{}, http.StatusBadRequest, err
+	}
+
+	result := models.ProductionSheet{
+		Date:       date,
+		Categories: make([]models.ProductionCategory, 0),
+		Clients:    make([]models.ProductionClient, 0),
+	}
+
+	for _, dish := range dishes {
+		categories := len(result.Categories)
+		if categories == 0 || result.Categories[categories-1].CategoryID != dish.CategoryID {
+			result.Categories = append(result.Categories, models.ProductionCategory{
+				CategoryID:   dish.CategoryID,
+				CategoryName: dish.CategoryName,
+			})
+			categories++
+		}
+		category := &result.Categories[categories-1]
+
+		categoryDishes := len(category.Dishes)
+		if categoryDishes == 0 || category.Dishes[categoryDishes-1].DishID != dish.DishID {
+			category.Dishes = append(category.Dishes, models.ProductionDish{
+				DishID: dish.DishID,
+				Name:   dish.Name,
+			})
+			categoryDishes++
+		}
+		productionDish := &category.Dishes[categoryDishes-1]
+
+		productionDish.Clients = append(productionDish.Clients, models.ProductionClientAmount{
+			ClientID:   dish.ClientID,
+			ClientName: dish.ClientName,
+			Amount:     dish.Amount,
+		})
+		productionDish.Amount += dish.Amount
+		category.Amount += dish.Amount
+		result.Portions += dish.Amount
+	}
+
+	floorAddresses := make(map[string]string)
+
+	for _, address := range addresses {
+		floorAddresses[fmt.Sprintf("%s_%d", address.ClientID, address.Floor)] =
+			fmt.Sprintf("%s, %s, %s", address.City, address.Street, address.House)
+	}
+
+	for _, floor := range floors {
+		clients := len(result.Clients)
+		if clients == 0 || result.Clients[clients-1].ClientID != floor.ClientID {
+			result.Clients = append(result.Clients, models.ProductionClient{
+				ClientID:   floor.ClientID,
+				ClientName: floor.ClientName,
+			})
+			clients++
+		}
+		client := &result.Clients[clients-1]
+
+		productionFloor := models.ProductionFloor{
+			Floor:    floor.Floor,
+			Orders:   floor.Orders,
+			Portions: floor.Portions,
+		}
+
+		if address, ok := floorAddresses[fmt.Sprintf("%s_%d", floor.ClientID, floor.Floor)]; ok {
+			productionFloor.Address = &address
+		}
+
+		client.Floors = append(client.Floors, productionFloor)
+		client.Orders += floor.Orders
+		client.Portions += floor.Portions
+	}
+
+	return result, 0, nil
 }
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/events"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	uuid "github.com/satori/go.uuid"
+)
+
+// boardExcludedStatuses are statuses of orders
+// which aren't counted on live order board
+var boardExcludedStatuses = []string{
+	enums.OrderStatusTypesEnum.Canceled,
+	enums.OrderStatusTypesEnum.Rejected,
+}
+
+// publishOrderEvents publishes committed changes of orders to live
+// order board, orders are loaded only if their company is watched
+func publishOrderEvents(event string, orderIDs ...uuid.UUID) {
+	dishes := make(map[string][]models.OrderBoardDish)
+
+	for _, orderID := range orderIDs {
+		order, err := getOrderEvent(config.DB, orderID)
+
+		if err != nil || order.ClientID == nil || order.CateringID == nil {
+			continue
+		}
+
+		if !events.Orders.HasSubscribers(*order.CateringID, *order.ClientID) {
+			continue
+		}
+
+		key := order.ClientID.String() + order.Date.Format(time.RFC3339)
+
+		if _, ok := dishes[key]; !ok {
+			if dishes[key], err = getBoardDishes(*order.ClientID, order.Date); err != nil {
+				continue
+			}
+		}
+
+		events.Orders.Publish(events.Event{
+			Type:       event,
+			CateringID: *order.CateringID,
+			ClientID:   *order.ClientID,
+			Data: models.OrderBoardEvent{
+				Order:  order,
+				Dishes: dishes[key],
+			},
+		})
+	}
+}
+
+// getBoardDishes returns amounts of dishes ordered by client for date
+func getBoardDishes(clientID uuid.UUID, date time.Time) ([]models.OrderBoardDish, error) {
+	dishes := make([]models.OrderBoardDish, 0)
+
+	err := config.DB.
+		Table("orders as o").
+		Select("od.dish_id, od.name, sum(od.amount) as amount").
+		Joins("left join user_orders uo on uo.order_id = o.id").
+		Joins("left join client_users cu on cu.user_id = uo.user_id AND cu.deleted_at IS NULL").
+		Joins("left join order_dishes od on od.order_id = o.id").
+		Where("cu.client_id = ? AND o.date = ? AND o.status NOT IN (?) AND o.deleted_at IS NULL"+
+			" AND od.deleted_at IS NULL", clientID, date, boardExcludedStatuses).
+		Group("od.dish_id, od.name").
+		Order("od.name").
+		Scan(&dishes).
+		Error
+
+	return dishes, err
+}
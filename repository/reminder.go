@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ReminderRepo struct
+type ReminderRepo struct{}
+
+// NewReminderRepo returns pointer to reminder repository
+// with all methods
+func NewReminderRepo() *ReminderRepo {
+	return &ReminderRepo{}
+}
+
+// defaultReminderOffset is number of minutes before cutoff
+// used for client which hasn't set up reminders yet
+const defaultReminderOffset = 60
+
+// Get returns reminder settings of client,
+// reminders are disabled for client without settings
+func (r ReminderRepo) Get(clientID string) (models.ClientReminder, int, error) {
+	if clientExist := config.DB.
+		Where("id = ?", clientID).
+		Find(&domain.Client{}).
+		RowsAffected; clientExist == 0 {
+		return models.ClientReminder{}, http.StatusNotFound, errors.New("client not found")
+	}
+
+	var reminder domain.ClientReminder
+
+	if err := config.DB.
+		Where("client_id = ?", clientID).
+		First(&reminder).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return models.ClientReminder{Offset: defaultReminderOffset}, 0, nil
+		}
+		return models.ClientReminder{}, http.StatusBadRequest, err
+	}
+
+	return models.ClientReminder{
+		Enabled:  reminder.Enabled,
+		Offset:   reminder.Offset,
+		LastDate: reminder.LastDate,
+	}, 0, nil
+}
+
+// Update enables or disables reminders of client and changes offset,
+// last date is kept, so reminders aren't sent twice for the same date
+func (r ReminderRepo) Update(clientID string, enabled bool, offset int) (models.ClientReminder, int, error) {
+	var reminder domain.ClientReminder
+
+	err := config.DB.
+		Where("client_id = ?", clientID).
+		First(&reminder).
+		Error
+
+	switch {
+	case gorm.IsRecordNotFoundError(err):
+		reminder = domain.ClientReminder{
+			ClientID: uuid.FromStringOrNil(clientID),
+			Enabled:  enabled,
+			Offset:   offset,
+		}
+
+		if err := config.DB.Create(&reminder).Error; err != nil {
+			return models.ClientReminder{}, http.StatusBadRequest, err
+		}
+	case err != nil:
+		return models.ClientReminder{}, http.StatusBadRequest, err
+	default:
+		if err := config.DB.
+			Model(&reminder).
+			Updates(map[string]interface{}{
+				"enabled": enabled,
+				"offset":  offset,
+			}).
+			Error; err != nil {
+			return models.ClientReminder{}, http.StatusBadRequest, err
+		}
+	}
+
+	return r.Get(clientID)
+}
+
+// GetEnabled returns settings of all clients with enabled reminders
+func (r ReminderRepo) GetEnabled() ([]domain.ClientReminder, error) {
+	var reminders []domain.ClientReminder
+
+	if err := config.DB.
+		Where("enabled = ?", true).
+		Find(&reminders).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// GetRecipients returns active users of client who have no order
+// for provided date, haven't opted out of reminders
+// and weren't reminded about that date yet
+func (r ReminderRepo) GetRecipients(clientID string, date time.Time) ([]models.ReminderRecipient, int, error) {
+	var recipients []models.ReminderRecipient
+
+	if err := config.DB.
+		Model(&domain.User{}).
+		Select("users.id as user_id, users.first_name, users.last_name, users.email, cu.floor").
+		Joins("left join client_users cu on cu.user_id = users.id").
+		Where("cu.client_id = ? AND cu.deleted_at IS NULL AND users.status = ? AND users.company_type = ?",
+			clientID, enums.StatusTypesEnum.Active, enums.CompanyTypesEnum.Client).
+		Where("users.id NOT IN (?)", config.DB.
+			Model(&domain.ReminderOptOut{}).
+			Select("user_id").
+			SubQuery()).
+		Where("users.id NOT IN (?)", config.DB.
+			Model(&domain.ReminderDelivery{}).
+			Select("user_id").
+			Where("date = ?", date).
+			SubQuery()).
+		Where("users.id NOT IN (?)", config.DB.
+			Table("user_orders uo").
+			Select("uo.user_id").
+			Joins("left join orders o on o.id = uo.order_id").
+			Where("o.date = ? AND o.status != ? AND o.deleted_at IS NULL", date, enums.OrderStatusTypesEnum.Canceled).
+			SubQuery()).
+		Order("users.last_name, users.first_name").
+		Scan(&recipients).
+		Error; err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if recipients == nil {
+		recipients = make([]models.ReminderRecipient, 0)
+	}
+
+	return recipients, 0, nil
+}
+
+// Claim marks user as reminded about provided date,
+// returns false if user was already reminded, so every user
+// is reminded once even when several instances run cron
+func (r ReminderRepo) Claim(userID uuid.UUID, date time.Time) (bool, error) {
+	result := config.DB.
+		Set("gorm:insert_option", "ON CONFLICT DO NOTHING").
+		Create(&domain.ReminderDelivery{
+			UserID: userID,
+			Date:   date,
+		})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected != 0, nil
+}
+
+// Release returns claim of user for provided date,
+// so user is reminded again on the next run
+func (r ReminderRepo) Release(userID uuid.UUID, date time.Time) error {
+	return config.DB.
+		Unscoped().
+		Where("user_id = ? AND date = ?", userID, date).
+		Delete(&domain.ReminderDelivery{}).
+		Error
+}
+
+// SetLastDate saves date of orders for which
+// reminders of client were sent last time
+func (r ReminderRepo) SetLastDate(clientID uuid.UUID, date time.Time) error {
+	return config.DB.
+		Model(&domain.ClientReminder{}).
+		Where("client_id = ?", clientID).
+		Update("last_date", date).
+		Error
+}
+
+// GetUserReminder returns whether user receives reminders
+func (r ReminderRepo) GetUserReminder(userID string) (models.UserReminder, int, error) {
+	if userExist := config.DB.
+		Where("id = ?", userID).
+		Find(&domain.User{}).
+		RowsAffected; userExist == 0 {
+		return models.UserReminder{}, http.StatusNotFound, errors.New("user not found")
+	}
+
+	var optOuts int
+
+	config.DB.
+		Model(&domain.ReminderOptOut{}).
+		Where("user_id = ?", userID).
+		Count(&optOuts)
+
+	return models.UserReminder{Enabled: optOuts == 0}, 0, nil
+}
+
+// UpdateUserReminder opts user in or out of reminders
+func (r ReminderRepo) UpdateUserReminder(userID string, enabled bool) (models.UserReminder, int, error) {
+	if _, code, err := r.GetUserReminder(userID); err != nil {
+		return models.UserReminder{}, code, err
+	}
+
+	if err := config.DB.
+		Unscoped().
+		Where("user_id = ?", userID).
+		Delete(&domain.ReminderOptOut{}).
+		Error; err != nil {
+		return models.UserReminder{}, http.StatusBadRequest, err
+	}
+
+	if !enabled {
+		if err := config.DB.
+			Create(&domain.ReminderOptOut{UserID: uuid.FromStringOrNil(userID)}).
+			Error; err != nil {
+			return models.UserReminder{}, http.StatusBadRequest, err
+		}
+	}
+
+	return models.UserReminder{Enabled: enabled}, 0, nil
+}
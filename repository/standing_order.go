@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrderRepo struct
+type StandingOrderRepo struct{}
+
+// NewStandingOrderRepo returns pointer to standing order repository
+// with all methods
+func NewStandingOrderRepo() *StandingOrderRepo {
+	return &StandingOrderRepo{}
+}
+
+// Add creates standing order with its items in one transaction
+func (s StandingOrderRepo) Add(standingOrder domain.StandingOrder, items []domain.StandingOrderItem) (models.StandingOrder, int, error) {
+	tx := config.DB.Begin()
+
+	if err := tx.Create(&standingOrder).Error; err != nil {
+		tx.Rollback()
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := s.createItems(tx, standingOrder.ID, items); err != nil {
+		tx.Rollback()
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	return s.GetByID(standingOrder.UserID.String(), standingOrder.ID.String())
+}
+
+// createItems creates items for provided standing order
+func (s StandingOrderRepo) createItems(tx *gorm.DB, standingOrderID uuid.UUID, items []domain.StandingOrderItem) error {
+	for i := range items {
+		items[i].StandingOrderID = standingOrderID
+
+		if err := tx.Create(&items[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get returns list of standing orders of provided user
+func (s StandingOrderRepo) Get(userID string) ([]models.StandingOrder, int, error) {
+	var standingOrders []domain.StandingOrder
+
+	if err := config.DB.
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&standingOrders).
+		Error; err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	result, err := s.withItems(standingOrders)
+
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	return result, 0, nil
+}
+
+// GetByID returns standing order of provided user
+func (s StandingOrderRepo) GetByID(userID, id string) (models.StandingOrder, int, error) {
+	var standingOrder domain.StandingOrder
+
+	if err := config.DB.
+		Where("user_id = ? AND id = ?", userID, id).
+		First(&standingOrder).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return models.StandingOrder{}, http.StatusNotFound, errors.New("standing order not found")
+		}
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	result, err := s.withItems([]domain.StandingOrder{standingOrder})
+
+	if err != nil {
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	return result[0], 0, nil
+}
+
+// GetToPlace returns not paused standing orders which apply
+// to provided date and weren't skipped or placed for it yet
+func (s StandingOrderRepo) GetToPlace(date time.Time) ([]models.StandingOrder, error) {
+	var standingOrders []domain.StandingOrder
+
+	if err := config.DB.
+		Where("paused = ? AND (end_date IS NULL OR end_date >= ?) AND ? = ANY(weekdays)",
+			false, date, utils.GetDayOfWeek(date)).
+		Where("id NOT IN (?)", config.DB.
+			Model(&domain.StandingOrderSkip{}).
+			Select("standing_order_id").
+			Where("date = ?", date).
+			SubQuery()).
+		Where("id NOT IN (?)", config.DB.
+			Model(&domain.StandingOrderPlacement{}).
+			Select("standing_order_id").
+			Where("date = ?", date).
+			SubQuery()).
+		Find(&standingOrders).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return s.withItems(standingOrders)
+}
+
+// withItems adds items, skipped dates and upcoming dates
+// which couldn't be placed to provided standing orders
+func (s StandingOrderRepo) withItems(standingOrders []domain.StandingOrder) ([]models.StandingOrder, error) {
+	result := make([]models.StandingOrder, 0, len(standingOrders))
+
+	for _, standingOrder := range standingOrders {
+		var items []domain.StandingOrderItem
+		var skips []domain.StandingOrderSkip
+
+		if err := config.DB.
+			Where("standing_order_id = ?", standingOrder.ID).
+			Order("created_at").
+			Find(&items).
+			Error; err != nil {
+			return nil, err
+		}
+
+		if err := config.DB.
+			Where("standing_order_id = ? AND date >= ?", standingOrder.ID, time.Now().UTC().Truncate(time.Hour*24)).
+			Order("date").
+			Find(&skips).
+			Error; err != nil {
+			return nil, err
+		}
+
+		failures := make([]models.StandingOrderFailure, 0)
+
+		if err := config.DB.
+			Model(&domain.StandingOrderPlacement{}).
+			Select("date, reason").
+			Where("standing_order_id = ? AND date >= ? AND reason IS NOT NULL", standingOrder.ID, time.Now().UTC().Truncate(time.Hour*24)).
+			Order("date").
+			Scan(&failures).
+			Error; err != nil {
+			return nil, err
+		}
+
+		skipDates := make([]time.Time, 0, len(skips))
+
+		for _, skip := range skips {
+			skipDates = append(skipDates, skip.Date)
+		}
+
+		result = append(result, models.StandingOrder{
+			StandingOrder: standingOrder,
+			Items:         items,
+			Skips:         skipDates,
+			Failures:      failures,
+		})
+	}
+
+	return result, nil
+}
+
+// Update replaces settings and items of standing order
+func (s StandingOrderRepo) Update(userID, id string, standingOrder domain.StandingOrder, items []domain.StandingOrderItem) (models.StandingOrder, int, error) {
+	var prevStandingOrder domain.StandingOrder
+
+	tx := config.DB.Begin()
+
+	if err := tx.
+		Set("gorm:query_option", "FOR UPDATE").
+		Where("user_id = ? AND id = ?", userID, id).
+		First(&prevStandingOrder).
+		Error; err != nil {
+		tx.Rollback()
+		if gorm.IsRecordNotFoundError(err) {
+			return models.StandingOrder{}, http.StatusNotFound, errors.New("standing order not found")
+		}
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.
+		Model(&prevStandingOrder).
+		Updates(map[string]interface{}{
+			"weekdays": standingOrder.Weekdays,
+			"end_date": standingOrder.EndDate,
+			"paused":   standingOrder.Paused,
+			"comment":  standingOrder.Comment,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.
+		Unscoped().
+		Where("standing_order_id = ?", prevStandingOrder.ID).
+		Delete(&domain.StandingOrderItem{}).
+		Error; err != nil {
+		tx.Rollback()
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := s.createItems(tx, prevStandingOrder.ID, items); err != nil {
+		tx.Rollback()
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return models.StandingOrder{}, http.StatusBadRequest, err
+	}
+
+	return s.GetByID(userID, id)
+}
+
+// Delete soft deletes standing order of provided user
+func (s StandingOrderRepo) Delete(userID, id string) (int, error) {
+	if isExist := config.DB.
+		Where("user_id = ? AND id = ?", userID, id).
+		Delete(&domain.StandingOrder{}).
+		RowsAffected; isExist == 0 {
+		return http.StatusNotFound, errors.New("standing order not found")
+	}
+
+	return 0, nil
+}
+
+// AddSkip marks date as skipped for standing order
+func (s StandingOrderRepo) AddSkip(userID, id string, date time.Time) (int, error) {
+	standingOrder, code, err := s.GetByID(userID, id)
+
+	if err != nil {
+		return code, err
+	}
+
+	var skipExist int
+
+	config.DB.
+		Model(&domain.StandingOrderSkip{}).
+		Where("standing_order_id = ? AND date = ?", standingOrder.ID, date).
+		Count(&skipExist)
+
+	if skipExist != 0 {
+		return http.StatusBadRequest, errors.New("date is already skipped")
+	}
+
+	if err := config.DB.
+		Create(&domain.StandingOrderSkip{
+			StandingOrderID: standingOrder.ID,
+			Date:            date,
+		}).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return 0, nil
+}
+
+// DeleteSkip removes skip of date for standing order
+func (s StandingOrderRepo) DeleteSkip(userID, id string, date time.Time) (int, error) {
+	standingOrder, code, err := s.GetByID(userID, id)
+
+	if err != nil {
+		return code, err
+	}
+
+	if isExist := config.DB.
+		Unscoped().
+		Where("standing_order_id = ? AND date = ?", standingOrder.ID, date).
+		Delete(&domain.StandingOrderSkip{}).
+		RowsAffected; isExist == 0 {
+		return http.StatusNotFound, errors.New("date isn't skipped")
+	}
+
+	return 0, nil
+}
+
+// AddPlacement marks date as handled for standing order,
+// orderID is nil if order wasn't placed by standing order
+func (s StandingOrderRepo) AddPlacement(standingOrderID uuid.UUID, date time.Time, orderID *uuid.UUID) error {
+	return config.DB.
+		Create(&domain.StandingOrderPlacement{
+			StandingOrderID: standingOrderID,
+			Date:            date,
+			OrderID:         orderID,
+		}).
+		Error
+}
+
+// AddFailedPlacement marks date as handled for standing order
+// without order, so the date isn't retried and user sees the reason
+func (s StandingOrderRepo) AddFailedPlacement(standingOrderID uuid.UUID, date time.Time, reason string) error {
+	return config.DB.
+		Create(&domain.StandingOrderPlacement{
+			StandingOrderID: standingOrderID,
+			Date:            date,
+			Reason:          &reason,
+		}).
+		Error
+}
@@ -0,0 +1,414 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// WebhookRepo struct
+type WebhookRepo struct{}
+
+// NewWebhookRepo returns pointer to webhook repository
+// with all methods
+func NewWebhookRepo() *WebhookRepo {
+	return &WebhookRepo{}
+}
+
+// webhookLease is time for which taken delivery is hidden
+// from other runs of cron while it's being sent
+const webhookLease = 5 * time.Minute
+
+// webhookOwner returns condition on owner of webhooks,
+// webhooks belong to catering or to client
+func webhookOwner(companyType string) string {
+	if companyType == enums.CompanyTypesEnum.Catering {
+		return "webhooks.catering_id = ?"
+	}
+
+	return "webhooks.client_id = ?"
+}
+
+// webhookResponse returns webhook without secret
+func webhookResponse(webhook domain.Webhook) models.Webhook {
+	return models.Webhook{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+// Add creates webhook of catering or client
+func (w WebhookRepo) Add(companyType, ownerID string, webhook domain.Webhook) (models.Webhook, int, error) {
+	parsedOwnerID := uuid.FromStringOrNil(ownerID)
+
+	if companyType == enums.CompanyTypesEnum.Catering {
+		if cateringExist := config.DB.
+			Where("id = ?", ownerID).
+			Find(&domain.Catering{}).
+			RowsAffected; cateringExist == 0 {
+			return models.Webhook{}, http.StatusNotFound, errors.New("catering not found")
+		}
+		webhook.CateringID = &parsedOwnerID
+	} else {
+		if clientExist := config.DB.
+			Where("id = ?", ownerID).
+			Find(&domain.Client{}).
+			RowsAffected; clientExist == 0 {
+			return models.Webhook{}, http.StatusNotFound, errors.New("client not found")
+		}
+		webhook.ClientID = &parsedOwnerID
+	}
+
+	if err := config.DB.Create(&webhook).Error; err != nil {
+		return models.Webhook{}, http.StatusBadRequest, err
+	}
+
+	return webhookResponse(webhook), 0, nil
+}
+
+// Get returns webhooks of catering or client
+func (w WebhookRepo) Get(companyType, ownerID string) ([]models.Webhook, int, error) {
+	var webhooks []domain.Webhook
+
+	if err := config.DB.
+		Where(webhookOwner(companyType), ownerID).
+		Order("created_at").
+		Find(&webhooks).
+		Error; err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	result := make([]models.Webhook, len(webhooks))
+
+	for i, webhook := range webhooks {
+		result[i] = webhookResponse(webhook)
+	}
+
+	return result, 0, nil
+}
+
+// getByID returns webhook of catering or client
+func (w WebhookRepo) getByID(companyType, ownerID, id string) (domain.Webhook, int, error) {
+	var webhook domain.Webhook
+
+	if err := config.DB.
+		Where(webhookOwner(companyType), ownerID).
+		Where("id = ?", id).
+		First(&webhook).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return domain.Webhook{}, http.StatusNotFound, errors.New("webhook not found")
+		}
+		return domain.Webhook{}, http.StatusBadRequest, err
+	}
+
+	return webhook, 0, nil
+}
+
+// Update replaces url, events and state of webhook,
+// secret is changed only if new one is provided
+func (w WebhookRepo) Update(companyType, ownerID, id string, body domain.Webhook) (models.Webhook, int, error) {
+	webhook, code, err := w.getByID(companyType, ownerID, id)
+
+	if err != nil {
+		return models.Webhook{}, code, err
+	}
+
+	updates := map[string]interface{}{
+		"url":    body.URL,
+		"events": body.Events,
+		"active": body.Active,
+	}
+
+	if body.Secret != "" {
+		updates["secret"] = body.Secret
+	}
+
+	if err := config.DB.
+		Model(&webhook).
+		Updates(updates).
+		Error; err != nil {
+		return models.Webhook{}, http.StatusBadRequest, err
+	}
+
+	return webhookResponse(webhook), 0, nil
+}
+
+// Delete removes webhook with its deliveries
+func (w WebhookRepo) Delete(companyType, ownerID, id string) (int, error) {
+	webhook, code, err := w.getByID(companyType, ownerID, id)
+
+	if err != nil {
+		return code, err
+	}
+
+	if err := config.DB.
+		Unscoped().
+		Delete(&webhook).
+		Error; err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return 0, nil
+}
+
+// GetDeliveries returns page of deliveries of webhook from the newest one
+func (w WebhookRepo) GetDeliveries(companyType, ownerID, webhookID string, query url.PaginationQuery) (models.WebhookDeliveries, int, error) {
+	if _, code, err := w.getByID(companyType, ownerID, webhookID); err != nil {
+		return models.WebhookDeliveries{}, code, err
+	}
+
+	result := models.WebhookDeliveries{
+		Items: make([]models.WebhookDelivery, 0),
+		Page:  query.Page,
+	}
+
+	deliveries := config.DB.
+		Model(&domain.WebhookDelivery{}).
+		Where("webhook_id = ?", webhookID)
+
+	deliveries.Count(&result.Total)
+
+	if err := deliveries.
+		Select("*").
+		Order("created_at desc").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Scan(&result.Items).
+		Error; err != nil {
+		return models.WebhookDeliveries{}, http.StatusBadRequest, err
+	}
+
+	return result, 0, nil
+}
+
+// GetDelivery returns delivery of webhook
+func (w WebhookRepo) GetDelivery(webhookID, id string) (models.WebhookDelivery, int, error) {
+	var delivery models.WebhookDelivery
+
+	if err := config.DB.
+		Model(&domain.WebhookDelivery{}).
+		Where("webhook_id = ? AND id = ?", webhookID, id).
+		Scan(&delivery).
+		Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return models.WebhookDelivery{}, http.StatusNotFound, errors.New("delivery not found")
+		}
+		return models.WebhookDelivery{}, http.StatusBadRequest, err
+	}
+
+	return delivery, 0, nil
+}
+
+// Redeliver takes delivery of webhook for sending again,
+// attempts are counted from the beginning
+func (w WebhookRepo) Redeliver(companyType, ownerID, webhookID, id string) (models.WebhookDeliveryTarget, int, error) {
+	if _, code, err := w.getByID(companyType, ownerID, webhookID); err != nil {
+		return models.WebhookDeliveryTarget{}, code, err
+	}
+
+	if _, code, err := w.GetDelivery(webhookID, id); err != nil {
+		return models.WebhookDeliveryTarget{}, code, err
+	}
+
+	nextAttemptAt := time.Now().UTC().Add(webhookLease)
+
+	if err := config.DB.
+		Model(&domain.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          enums.WebhookDeliveryStatusTypesEnum.Pending,
+			"attempts":        0,
+			"next_attempt_at": nextAttemptAt,
+		}).
+		Error; err != nil {
+		return models.WebhookDeliveryTarget{}, http.StatusBadRequest, err
+	}
+
+	var target models.WebhookDeliveryTarget
+
+	if err := deliveryTargets(config.DB).
+		Where("wd.id = ?", id).
+		Scan(&target).
+		Error; err != nil {
+		return models.WebhookDeliveryTarget{}, http.StatusBadRequest, err
+	}
+
+	return target, 0, nil
+}
+
+// TakeDue takes up to limit pending deliveries of active webhooks
+// which next attempt time has come, taken deliveries are leased,
+// so they aren't sent twice when several instances run cron
+func (w WebhookRepo) TakeDue(limit int) ([]models.WebhookDeliveryTarget, error) {
+	var targets []models.WebhookDeliveryTarget
+
+	now := time.Now().UTC()
+	tx := config.DB.Begin()
+
+	if err := deliveryTargets(tx).
+		Where("wd.status = ? AND wd.next_attempt_at <= ? AND w.active = ? AND w.deleted_at IS NULL",
+			enums.WebhookDeliveryStatusTypesEnum.Pending, now, true).
+		Order("wd.next_attempt_at").
+		Limit(limit).
+		Set("gorm:query_option", "FOR UPDATE OF wd SKIP LOCKED").
+		Scan(&targets).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(targets) == 0 {
+		tx.Rollback()
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(targets))
+
+	for i, target := range targets {
+		ids[i] = target.ID
+	}
+
+	if err := tx.
+		Model(&domain.WebhookDelivery{}).
+		Where("id IN (?)", ids).
+		Update("next_attempt_at", now.Add(webhookLease)).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// SaveAttempt saves result of attempt to send delivery
+func (w WebhookRepo) SaveAttempt(id uuid.UUID, status string, attempts int, responseCode *int, errMessage *string, nextAttemptAt *time.Time) error {
+	updates := map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"response_code":   responseCode,
+		"error":           errMessage,
+		"next_attempt_at": nextAttemptAt,
+	}
+
+	if status == enums.WebhookDeliveryStatusTypesEnum.Delivered {
+		updates["delivered_at"] = time.Now().UTC()
+	}
+
+	return config.DB.
+		Model(&domain.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(updates).
+		Error
+}
+
+// AddEvent saves deliveries of event for active webhooks
+// of catering and client which are subscribed to it
+func (w WebhookRepo) AddEvent(event string, cateringID, clientID uuid.UUID, data interface{}) error {
+	return addWebhookEvent(config.DB, event, cateringID, clientID, data)
+}
+
+// deliveryTargets returns query of deliveries joined with their webhooks
+func deliveryTargets(db *gorm.DB) *gorm.DB {
+	return db.
+		Table("webhook_deliveries as wd").
+		Select("wd.id, wd.event, wd.payload, wd.attempts, w.url, w.secret").
+		Joins("left join webhooks w on w.id = wd.webhook_id").
+		Where("wd.deleted_at IS NULL")
+}
+
+// addWebhookEvent saves deliveries of event in provided transaction,
+// so deliveries exist only if change of event is saved
+func addWebhookEvent(db *gorm.DB, event string, cateringID, clientID uuid.UUID, data interface{}) error {
+	var webhooks []domain.Webhook
+
+	if err := db.
+		Where("active = ? AND (catering_id = ? OR client_id = ?) AND ? = ANY(events)",
+			true, cateringID, clientID, event).
+		Find(&webhooks).
+		Error; err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	payload, err := json.Marshal(models.WebhookEvent{
+		Event:     event,
+		CreatedAt: now,
+		Data:      data,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if err := db.
+			Create(&domain.WebhookDelivery{
+				WebhookID:     webhook.ID,
+				Event:         event,
+				Payload:       string(payload),
+				Status:        enums.WebhookDeliveryStatusTypesEnum.Pending,
+				NextAttemptAt: &now,
+			}).
+			Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getOrderEvent returns order with its user, client and catering
+func getOrderEvent(db *gorm.DB, orderID uuid.UUID) (models.OrderEvent, error) {
+	var order models.OrderEvent
+
+	err := db.
+		Table("orders as o").
+		Select("o.id as order_id, uo.user_id, cu.client_id, c.catering_id, o.date, o.status,"+
+			" coalesce(o.total, 0) as total, coalesce(o.company_share, 0) as company_share,"+
+			" coalesce(o.employee_share, 0) as employee_share").
+		Joins("left join user_orders uo on uo.order_id = o.id").
+		Joins("left join client_users cu on cu.user_id = uo.user_id AND cu.deleted_at IS NULL").
+		Joins("left join clients c on c.id = cu.client_id").
+		Where("o.id = ?", orderID).
+		Scan(&order).
+		Error
+
+	return order, err
+}
+
+// addOrderEvent saves deliveries of event of order in provided
+// transaction, orders of users without client have no webhooks
+func addOrderEvent(tx *gorm.DB, event string, orderID uuid.UUID) error {
+	order, err := getOrderEvent(tx, orderID)
+
+	if err != nil {
+		return err
+	}
+
+	if order.ClientID == nil || order.CateringID == nil {
+		return nil
+	}
+
+	return addWebhookEvent(tx, event, *order.CateringID, *order.ClientID, order)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+)
+
+// AuditService struct
+type AuditService struct{}
+
+// NewAuditService return pointer to audit struct
+// with all methods
+func NewAuditService() *AuditService {
+	return &AuditService{}
+}
+
+var auditRepo = repository.NewAuditRepo()
+
+// Get returns page of audit events of catering or client
+func (a *AuditService) Get(companyType string, path url.PathID, query url.AuditQuery, pagination url.PaginationQuery) (models.AuditEvents, int, error) {
+	var from, to time.Time
+	var err error
+
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+
+	if query.From != "" {
+		if from, err = time.Parse(time.RFC3339, query.From); err != nil {
+			return models.AuditEvents{}, http.StatusBadRequest, errors.New("can't parse the date")
+		}
+	}
+
+	if query.To != "" {
+		if to, err = time.Parse(time.RFC3339, query.To); err != nil {
+			return models.AuditEvents{}, http.StatusBadRequest, errors.New("can't parse the date")
+		}
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return models.AuditEvents{}, http.StatusBadRequest, errors.New("from date can't be after to date")
+	}
+
+	return auditRepo.Get(companyType, path.ID, query, from, to, pagination)
+}
@@ -20,7 +20,7 @@ func NewCateringUserService() *CateringUserService {
 	return &CateringUserService{}
 }
 
-func (cu *CateringUserService) Add(path url.PathID, user domain.User) (models.UserClientCatering, domain.User, string, error, error) {
+func (cu *CateringUserService) Add(path url.PathID, user domain.User, ctxUser interface{}) (models.UserClientCatering, domain.User, string, error, error) {
 	parsedID, err := uuid.FromString(path.ID)
 	if err != nil {
 		return models.UserClientCatering{}, user, "", err, nil
@@ -43,7 +43,7 @@ func (cu *CateringUserService) Add(path url.PathID, user domain.User) (models.Us
 			CateringID: parsedID,
 		}
 
-		if err := cateringUserRepo.Add(cateringUser); err != nil {
+		if err := cateringUserRepo.Add(cateringUser, ctxUser.(domain.User)); err != nil {
 			return models.UserClientCatering{}, user, password, err, nil
 		}
 
@@ -65,7 +65,7 @@ func (cu *CateringUserService) Add(path url.PathID, user domain.User) (models.Us
 		CateringID: parsedID,
 	}
 
-	if err := cateringUserRepo.Add(cateringUser); err != nil {
+	if err := cateringUserRepo.Add(cateringUser, ctxUser.(domain.User)); err != nil {
 		return models.UserClientCatering{}, user, password, err, nil
 	}
 
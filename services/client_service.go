@@ -1,8 +1,12 @@
 package services
 
 import (
+	"errors"
+
 	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/Aiscom-LLC/meals-api/repository/models"
 	"github.com/dgrijalva/jwt-go"
 	uuid "github.com/satori/go.uuid"
@@ -20,6 +24,8 @@ func NewClient() *Client {
 
 var cateringUserRepo = repository.NewCateringUserRepo()
 var clientRepo = repository.NewClientRepo()
+var clientSubsidyRepo = repository.NewClientSubsidyRepo()
+var clientBudgetRepo = repository.NewClientBudgetRepo()
 
 func (cl *Client) Get(query url.PaginationQuery, claims jwt.MapClaims) ([]models.Client, int, url.PaginationQuery, int, error) {
 	var cateringID string
@@ -47,3 +53,93 @@ func (cl *Client) Get(query url.PaginationQuery, claims jwt.MapClaims) ([]models
 
 	return result, total, query, 0, err
 }
+
+// GetSubsidy returns subsidy of client
+func (cl *Client) GetSubsidy(path url.PathID) (models.ClientSubsidy, int, error) {
+	return clientSubsidyRepo.Get(path.ID)
+}
+
+// UpdateSubsidy validates and replaces subsidy of client
+func (cl *Client) UpdateSubsidy(path url.PathID, body models.UpdateSubsidy) (models.ClientSubsidy, int, error) {
+	client, err := clientRepo.GetByKey("id", path.ID)
+
+	if err != nil {
+		return models.ClientSubsidy{}, http.StatusNotFound, errors.New("client not found")
+	}
+
+	switch body.Type {
+	case enums.SubsidyTypesEnum.Full,
+		enums.SubsidyTypesEnum.Fixed,
+		enums.SubsidyTypesEnum.Percent,
+		enums.SubsidyTypesEnum.Category:
+	default:
+		return models.ClientSubsidy{}, http.StatusBadRequest, errors.New("type must be full, fixed, percent or category")
+	}
+
+	if body.Amount < 0 {
+		return models.ClientSubsidy{}, http.StatusBadRequest, errors.New("amount can't be negative")
+	}
+
+	if body.Type == enums.SubsidyTypesEnum.Percent && body.Amount > 100 {
+		return models.ClientSubsidy{}, http.StatusBadRequest, errors.New("percent can't be more than 100")
+	}
+
+	var categories []domain.ClientSubsidyCategory
+
+	if body.Type == enums.SubsidyTypesEnum.Category {
+		if len(body.Categories) == 0 {
+			return models.ClientSubsidy{}, http.StatusBadRequest, errors.New("subsidy must contain at least one category")
+		}
+
+		usedCategories := make(map[uuid.UUID]bool)
+
+		for _, category := range body.Categories {
+			if category.Amount < 0 {
+				return models.ClientSubsidy{}, http.StatusBadRequest, errors.New("amount can't be negative")
+			}
+
+			if usedCategories[category.CategoryID] {
+				return models.ClientSubsidy{}, http.StatusBadRequest, errors.New("can't add 2 same categories")
+			}
+			usedCategories[category.CategoryID] = true
+
+			if _, err := categoryRepo.GetByKey("id", category.CategoryID.String(), client.CateringID.String()); err != nil {
+				return models.ClientSubsidy{}, http.StatusNotFound, errors.New("category with that id not found")
+			}
+
+			categories = append(categories, domain.ClientSubsidyCategory{
+				CategoryID: category.CategoryID,
+				Amount:     category.Amount,
+			})
+		}
+	}
+
+	return clientSubsidyRepo.Update(path.ID, domain.ClientSubsidy{
+		Type:   body.Type,
+		Amount: body.Amount,
+	}, categories)
+}
+
+// GetBudget returns budget of client with spending of current month
+func (cl *Client) GetBudget(path url.PathID) (models.ClientBudget, int, error) {
+	return clientBudgetRepo.Get(path.ID)
+}
+
+// UpdateBudget validates and replaces budget of client
+func (cl *Client) UpdateBudget(path url.PathID, body models.UpdateBudget) (models.ClientBudget, int, error) {
+	if _, err := clientRepo.GetByKey("id", path.ID); err != nil {
+		return models.ClientBudget{}, http.StatusNotFound, errors.New("client not found")
+	}
+
+	for _, limit := range []*float32{body.MonthlyBudget, body.UserMonthlyLimit, body.UserDailyLimit} {
+		if limit != nil && *limit < 0 {
+			return models.ClientBudget{}, http.StatusBadRequest, errors.New("budget can't be negative")
+		}
+	}
+
+	return clientBudgetRepo.Update(path.ID, domain.ClientBudget{
+		MonthlyBudget:    body.MonthlyBudget,
+		UserMonthlyLimit: body.UserMonthlyLimit,
+		UserDailyLimit:   body.UserDailyLimit,
+	})
+}
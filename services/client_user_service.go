@@ -28,7 +28,7 @@ func NewClientUser() *ClientUser {
 var userRepo = repository.NewUserRepo()
 var clientUserRepo = repository.NewClientUserRepo()
 
-func (cu *ClientUser) Add(path url.PathID, body models.ClientUser, user domain.User) (models.UserClientCatering, int, string, error, error) {
+func (cu *ClientUser) Add(path url.PathID, body models.ClientUser, user domain.User, ctxUser interface{}) (models.UserClientCatering, int, string, error, error) {
 	parsedID, _ := uuid.FromString(path.ID)
 	user.CompanyType = &enums.CompanyTypesEnum.Client
 	user.Status = &enums.StatusTypesEnum.Invited
@@ -46,7 +46,7 @@ func (cu *ClientUser) Add(path url.PathID, body models.ClientUser, user domain.U
 			Floor:    body.Floor,
 		}
 
-		if err := clientUserRepo.Add(clientUser); err != nil {
+		if err := clientUserRepo.Add(clientUser, ctxUser.(domain.User)); err != nil {
 			return models.UserClientCatering{}, http.StatusBadRequest, password, err, nil
 		}
 
@@ -69,7 +69,7 @@ func (cu *ClientUser) Add(path url.PathID, body models.ClientUser, user domain.U
 		Floor:    body.Floor,
 	}
 
-	if err := clientUserRepo.Add(clientUser); err != nil {
+	if err := clientUserRepo.Add(clientUser, ctxUser.(domain.User)); err != nil {
 		return models.UserClientCatering{}, http.StatusBadRequest, password, err, userErr
 	}
 
@@ -78,23 +78,21 @@ func (cu *ClientUser) Add(path url.PathID, body models.ClientUser, user domain.U
 	return userClientCatering, 0, password, err, userErr
 }
 
-func (cu *ClientUser) Delete(path url.PathUser, user domain.User, userRole string, userID string) (int, error) {
+func (cu *ClientUser) Delete(path url.PathUser, user domain.User, ctxUser interface{}) (int, error) {
 	parsedUserID, _ := uuid.FromString(path.UserID)
 	user.ID = parsedUserID
 	user.Status = &enums.StatusTypesEnum.Deleted
 	deleteAt := time.Now().AddDate(0, 0, 21).Truncate(time.Hour * 24)
 	user.DeletedAt = &deleteAt
 
-	if user.ID.String() == userID {
+	if user.ID == ctxUser.(domain.User).ID {
 		return http.StatusBadRequest, errors.New("can't delete yourself")
 	}
 
-	code, err := clientUserRepo.Delete(path.ID, userRole, user)
-
-	return code, err
+	return clientUserRepo.Delete(path.ID, user, ctxUser.(domain.User))
 }
 
-func (cu *ClientUser) Update(path url.PathUser, body models.ClientUserUpdate, user domain.User) (int, error) {
+func (cu *ClientUser) Update(path url.PathUser, body models.ClientUserUpdate, user domain.User, ctxUser interface{}) (int, error) {
 	if body.Email != "" {
 		if ok := utils.IsEmailValid(body.Email); !ok {
 			return http.StatusBadRequest, errors.New("email is not valid")
@@ -109,7 +107,5 @@ func (cu *ClientUser) Update(path url.PathUser, body models.ClientUserUpdate, us
 	user.CompanyType = &enums.CompanyTypesEnum.Client
 	user.ID = parsedID
 
-	code, err := clientUserRepo.Update(&user, body.Floor)
-
-	return code, err
+	return clientUserRepo.Update(path.ID, &user, body.Floor, ctxUser.(domain.User))
 }
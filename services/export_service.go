@@ -0,0 +1,193 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+)
+
+// ExportSheet is table of export, every format
+// writes the same sheets with the same columns
+type ExportSheet struct {
+	Name    string
+	Headers []string
+	Rows    [][]interface{}
+}
+
+// Exporter writes sheets of export in certain format
+type Exporter interface {
+	ContentType() string
+	Extension() string
+	Export(w io.Writer, sheets []ExportSheet) error
+}
+
+// exporters contains exporters by format name,
+// new format is added by registering its exporter here
+var exporters = map[string]Exporter{
+	"xlsx": xlsxExporter{},
+	"csv":  csvExporter{},
+	"json": jsonExporter{},
+}
+
+// ExportFile is file which is streamed to response,
+// data is loaded before, so Write fails only on writing
+type ExportFile struct {
+	Name        string
+	ContentType string
+	Write       func(w io.Writer) error
+}
+
+// newExportFile returns file of sheets in provided format
+func newExportFile(name, format string, sheets []ExportSheet) (ExportFile, int, error) {
+	if format == "" {
+		format = "xlsx"
+	}
+
+	exporter, ok := exporters[format]
+
+	if !ok {
+		formats := make([]string, 0, len(exporters))
+		for name := range exporters {
+			formats = append(formats, name)
+		}
+		sort.Strings(formats)
+
+		return ExportFile{}, http.StatusBadRequest, fmt.Errorf("format must be one of %s", strings.Join(formats, ", "))
+	}
+
+	return ExportFile{
+		Name:        name + "." + exporter.Extension(),
+		ContentType: exporter.ContentType(),
+		Write: func(w io.Writer) error {
+			return exporter.Export(w, sheets)
+		},
+	}, 0, nil
+}
+
+type xlsxExporter struct{}
+
+func (e xlsxExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (e xlsxExporter) Extension() string {
+	return "xlsx"
+}
+
+// Export writes every sheet to its own worksheet
+func (e xlsxExporter) Export(w io.Writer, sheets []ExportSheet) error {
+	f := excelize.NewFile()
+	style, _ := f.NewStyle(`{"alignment":{"horizontal": "center", "vertical": "center"}}`)
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			f.SetSheetName("Sheet1", sheet.Name)
+		} else {
+			f.NewSheet(sheet.Name)
+		}
+
+		for col, header := range sheet.Headers {
+			axis := excelize.ToAlphaString(col) + "1"
+			f.SetCellValue(sheet.Name, axis, header)
+			f.SetCellStyle(sheet.Name, axis, axis, style)
+			f.SetColWidth(sheet.Name, excelize.ToAlphaString(col), excelize.ToAlphaString(col), 20)
+		}
+
+		for row, values := range sheet.Rows {
+			for col, value := range values {
+				f.SetCellValue(sheet.Name, fmt.Sprintf("%s%d", excelize.ToAlphaString(col), row+2), value)
+			}
+		}
+	}
+
+	return f.Write(w)
+}
+
+type csvExporter struct{}
+
+func (e csvExporter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (e csvExporter) Extension() string {
+	return "csv"
+}
+
+// Export writes sheets one after another,
+// every sheet starts with its name and is followed by empty line
+func (e csvExporter) Export(w io.Writer, sheets []ExportSheet) error {
+	writer := csv.NewWriter(w)
+
+	for i, sheet := range sheets {
+		if i != 0 {
+			if err := writer.Write([]string{}); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Write([]string{sheet.Name}); err != nil {
+			return err
+		}
+
+		if err := writer.Write(sheet.Headers); err != nil {
+			return err
+		}
+
+		for _, values := range sheet.Rows {
+			record := make([]string, len(values))
+			for col, value := range values {
+				record[col] = fmt.Sprint(value)
+			}
+
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+type jsonExporter struct{}
+
+func (e jsonExporter) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (e jsonExporter) Extension() string {
+	return "json"
+}
+
+// Export writes list of sheets, every row
+// is object with headers as keys
+func (e jsonExporter) Export(w io.Writer, sheets []ExportSheet) error {
+	type jsonSheet struct {
+		Name string                   `json:"name"`
+		Rows []map[string]interface{} `json:"rows"`
+	}
+
+	result := make([]jsonSheet, 0, len(sheets))
+
+	for _, sheet := range sheets {
+		rows := make([]map[string]interface{}, 0, len(sheet.Rows))
+
+		for _, values := range sheet.Rows {
+			row := make(map[string]interface{}, len(values))
+			for col, value := range values {
+				row[sheet.Headers[col]] = value
+			}
+			rows = append(rows, row)
+		}
+
+		result = append(result, jsonSheet{Name: sheet.Name, Rows: rows})
+	}
+
+	return json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/utils"
+)
+
+// InvoiceService struct
+type InvoiceService struct{}
+
+// NewInvoiceService return pointer to invoice struct
+// with all methods
+func NewInvoiceService() *InvoiceService {
+	return &InvoiceService{}
+}
+
+var invoiceRepo = repository.NewInvoiceRepo()
+
+// Add creates draft invoice of client for period
+func (i *InvoiceService) Add(path url.PathClient, body models.CreateInvoice, user interface{}) (models.Invoice, int, error) {
+	from, err := time.Parse(time.RFC3339, body.From)
+
+	if err != nil {
+		return models.Invoice{}, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	to, err := time.Parse(time.RFC3339, body.To)
+
+	if err != nil {
+		return models.Invoice{}, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	if to.Before(from) {
+		return models.Invoice{}, http.StatusBadRequest, errors.New("from date can't be after to date")
+	}
+
+	if body.Discount < 0 {
+		return models.Invoice{}, http.StatusBadRequest, errors.New("discount can't be negative")
+	}
+
+	return invoiceRepo.Add(path.ID, path.ClientID, from, to, body.Discount, user.(domain.User))
+}
+
+// Get returns page of invoices of client
+func (i *InvoiceService) Get(path url.PathClient, query url.PaginationQuery) ([]models.Invoice, int, url.PaginationQuery, int, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	result, total, err := invoiceRepo.Get(path.ID, path.ClientID, query)
+
+	if err != nil {
+		return nil, 0, query, http.StatusBadRequest, err
+	}
+
+	return result, total, query, 0, nil
+}
+
+// GetByID returns invoice with its items
+func (i *InvoiceService) GetByID(path url.PathInvoice) (models.Invoice, int, error) {
+	return invoiceRepo.GetByID(path.ID, path.ClientID, path.InvoiceID)
+}
+
+// Update issues, pays or changes discount of invoice
+func (i *InvoiceService) Update(path url.PathInvoice, body models.UpdateInvoice, user interface{}) (models.Invoice, int, error) {
+	if body.Status != nil &&
+		*body.Status != enums.InvoiceStatusTypesEnum.Draft &&
+		*body.Status != enums.InvoiceStatusTypesEnum.Issued &&
+		*body.Status != enums.InvoiceStatusTypesEnum.Paid {
+		return models.Invoice{}, http.StatusBadRequest, errors.New("status must be draft, issued or paid")
+	}
+
+	if body.Discount != nil && *body.Discount < 0 {
+		return models.Invoice{}, http.StatusBadRequest, errors.New("discount can't be negative")
+	}
+
+	return invoiceRepo.Update(path.ID, path.ClientID, path.InvoiceID, body, user.(domain.User))
+}
+
+// Delete removes draft invoice
+func (i *InvoiceService) Delete(path url.PathInvoice, user interface{}) (int, error) {
+	return invoiceRepo.Delete(path.ID, path.ClientID, path.InvoiceID, user.(domain.User))
+}
+
+// GetFile returns invoice rendered to xlsx or pdf file
+func (i *InvoiceService) GetFile(path url.PathInvoice, query url.FileFormatQuery) (ExportFile, int, error) {
+	if query.Format == "" {
+		query.Format = "xlsx"
+	}
+
+	if query.Format != "xlsx" && query.Format != "pdf" {
+		return ExportFile{}, http.StatusBadRequest, errors.New("format must be xlsx or pdf")
+	}
+
+	invoice, code, err := invoiceRepo.GetByID(path.ID, path.ClientID, path.InvoiceID)
+
+	if err != nil {
+		return ExportFile{}, code, err
+	}
+
+	file := ExportFile{Name: "invoice_" + invoice.ID.String() + "." + query.Format}
+
+	if query.Format == "pdf" {
+		file.ContentType = "application/pdf"
+		file.Write = func(w io.Writer) error {
+			return invoicePDF(invoice, w)
+		}
+	} else {
+		file.ContentType = exporters["xlsx"].ContentType()
+		file.Write = func(w io.Writer) error {
+			return invoiceExcel(invoice, w)
+		}
+	}
+
+	return file, 0, nil
+}
+
+// invoiceHeaders are columns of invoice items, xlsx
+// and pdf files of invoice share labels and layout
+var invoiceHeaders = []string{"Дата", "Блюдо", "Цена", "Количество", "Сумма"}
+
+// invoiceTitle returns title of invoice, draft invoice has no number yet
+func invoiceTitle(invoice models.Invoice) string {
+	if invoice.Number == nil {
+		return "Черновик счета"
+	}
+
+	return "Счет № " + strconv.Itoa(*invoice.Number)
+}
+
+// invoiceDetails returns client and period lines of invoice
+func invoiceDetails(invoice models.Invoice) [][2]string {
+	return [][2]string{
+		{"Клиент", invoice.ClientName},
+		{"Период", invoice.PeriodFrom.Format("02.01.2006") + " - " + invoice.PeriodTo.Format("02.01.2006")},
+	}
+}
+
+// invoiceTotal is named total line of invoice
+type invoiceTotal struct {
+	name  string
+	value float32
+}
+
+// invoiceTotals returns total lines of invoice
+func invoiceTotals(invoice models.Invoice) []invoiceTotal {
+	return []invoiceTotal{
+		{"Итого", invoice.Subtotal},
+		{"Скидка", invoice.Discount},
+		{"К оплате", invoice.Total},
+		{"В том числе дотация компании", invoice.CompanyShare},
+		{"В том числе оплата сотрудников", invoice.EmployeeShare},
+	}
+}
+
+// invoiceExcel writes invoice as excel file
+func invoiceExcel(invoice models.Invoice, w io.Writer) error {
+	f := excelize.NewFile()
+	sheet := "Sheet1"
+
+	style, _ := f.NewStyle(`{"alignment":{"horizontal": "center", "vertical": "center"}}`)
+
+	f.SetCellValue(sheet, "A1", invoiceTitle(invoice))
+
+	for i, detail := range invoiceDetails(invoice) {
+		st := strconv.Itoa(i + 2)
+		f.SetCellValue(sheet, "A"+st, detail[0])
+		f.SetCellValue(sheet, "B"+st, detail[1])
+	}
+
+	for col, header := range invoiceHeaders {
+		axis := excelize.ToAlphaString(col) + "5"
+		f.SetCellValue(sheet, axis, header)
+		f.SetCellStyle(sheet, axis, axis, style)
+	}
+
+	f.SetColWidth(sheet, "A", "A", 15)
+	f.SetColWidth(sheet, "B", "B", 30)
+	f.SetColWidth(sheet, "C", "E", 15)
+
+	line := 6
+	for _, item := range invoice.Items {
+		st := strconv.Itoa(line)
+		f.SetCellValue(sheet, "A"+st, item.Date.Format("02.01.2006"))
+		f.SetCellValue(sheet, "B"+st, item.Name)
+		f.SetCellValue(sheet, "C"+st, item.Price)
+		f.SetCellValue(sheet, "D"+st, item.Amount)
+		f.SetCellValue(sheet, "E"+st, item.Total)
+		line++
+	}
+
+	line++
+	for _, total := range invoiceTotals(invoice) {
+		st := strconv.Itoa(line)
+		f.SetCellValue(sheet, "D"+st, total.name)
+		f.SetCellValue(sheet, "E"+st, total.value)
+		line++
+	}
+
+	return f.Write(w)
+}
+
+// invoicePDF writes invoice as pdf file,
+// cyrillic text is transliterated by pdf writer
+func invoicePDF(invoice models.Invoice, w io.Writer) error {
+	pdf := utils.NewPDF()
+	columns := []float64{40, 110, 360, 430, 500}
+
+	pdf.Text(40, 50, 18, invoiceTitle(invoice))
+
+	y := 64.0
+	for _, detail := range invoiceDetails(invoice) {
+		y += 16
+		pdf.Text(40, y, 11, detail[0]+": "+detail[1])
+	}
+
+	header := func(y float64) {
+		for col, name := range invoiceHeaders {
+			pdf.Text(columns[col], y, 10, name)
+		}
+	}
+
+	y = 130.0
+	header(y)
+
+	for _, item := range invoice.Items {
+		y += 16
+		if y > utils.PDFPageHeight-60 {
+			pdf.AddPage()
+			y = 50
+			header(y)
+			y += 16
+		}
+
+		pdf.Text(columns[0], y, 10, item.Date.Format("02.01.2006"))
+		pdf.Text(columns[1], y, 10, item.Name)
+		pdf.Text(columns[2], y, 10, fmt.Sprintf("%.2f", item.Price))
+		pdf.Text(columns[3], y, 10, strconv.Itoa(item.Amount))
+		pdf.Text(columns[4], y, 10, fmt.Sprintf("%.2f", item.Total))
+	}
+
+	y += 16
+	for _, total := range invoiceTotals(invoice) {
+		y += 16
+		if y > utils.PDFPageHeight-40 {
+			pdf.AddPage()
+			y = 50
+		}
+
+		pdf.Text(250, y, 10, total.name)
+		pdf.Text(columns[4], y, 10, fmt.Sprintf("%.2f", total.value))
+	}
+
+	return pdf.Write(w)
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	uuid "github.com/satori/go.uuid"
+)
+
+// LabelService struct
+type LabelService struct{}
+
+// NewLabelService return pointer to label struct
+// with all methods
+func NewLabelService() *LabelService {
+	return &LabelService{}
+}
+
+var labelRepo = repository.NewLabelRepo()
+
+// Add creates allergen or dietary tag of catering
+func (l *LabelService) Add(path url.PathID, label domain.Label, user interface{}) (domain.Label, int, error) {
+	label.Name = strings.TrimSpace(label.Name)
+
+	if label.Name == "" {
+		return domain.Label{}, http.StatusBadRequest, errors.New("name of label can't be empty")
+	}
+
+	label.CateringID = uuid.FromStringOrNil(path.ID)
+
+	if code, err := labelRepo.Add(&label, user.(domain.User)); err != nil {
+		return domain.Label{}, code, err
+	}
+
+	return label, 0, nil
+}
+
+// Get returns allergens and dietary tags of catering
+func (l *LabelService) Get(path url.PathID, query url.LabelTypeQuery) ([]domain.Label, int, error) {
+	return labelRepo.Get(path.ID, query.Type)
+}
+
+// Delete removes label from catering, its dishes and dietary profiles
+func (l *LabelService) Delete(path url.PathLabel, user interface{}) (int, error) {
+	return labelRepo.Delete(path, user.(domain.User))
+}
+
+// GetProfile returns dietary profile of user
+func (l *LabelService) GetProfile(path url.PathID) (models.DietaryProfile, int, error) {
+	if user, err := userRepo.GetByKey("id", path.ID); err != nil || user.ID == uuid.Nil {
+		return models.DietaryProfile{}, http.StatusNotFound, errors.New("user not found")
+	}
+
+	return labelRepo.GetDietaryProfile(path.ID)
+}
+
+// UpdateProfile replaces dietary profile of user,
+// profile consists of labels of catering of user
+func (l *LabelService) UpdateProfile(path url.PathID, body models.UpdateDietaryProfile) (models.DietaryProfile, int, error) {
+	user, err := userRepo.GetByID(path.ID)
+
+	if err != nil || user.ID == uuid.Nil {
+		return models.DietaryProfile{}, http.StatusNotFound, errors.New("user not found")
+	}
+
+	if user.CateringID == nil {
+		return models.DietaryProfile{}, http.StatusBadRequest, errors.New("user doesn't belong to catering")
+	}
+
+	return labelRepo.UpdateDietaryProfile(user.ID, uuid.FromStringOrNil(*user.CateringID), body.LabelIDs)
+}
@@ -2,13 +2,16 @@ package services
 
 import (
 	"errors"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/Aiscom-LLC/meals-api/repository/models"
 	uuid "github.com/satori/go.uuid"
 )
@@ -27,26 +30,60 @@ var dishRepo = repository.NewDishRepo()
 var mealDishRepo = repository.NewMealDishesRepo()
 
 func (m *MealService) Add(path url.PathClient, body models.AddMeal, user interface{}) ([]models.GetMeal, int, error) {
+	if err := validateMealDate(body.Date); err != nil {
+		return []models.GetMeal{}, http.StatusBadRequest, err
+	}
+
+	mealDishes := make([]domain.MealDish, 0, len(body.Dishes))
+
+	for _, dishID := range body.Dishes {
+		dish, code, err := dishRepo.FindByID(path.ID, dishID)
+		if err != nil {
+			return []models.GetMeal{}, code, err
+		}
+		mealDishes = append(mealDishes, domain.MealDish{
+			DishID: dish.ID,
+			Price:  dish.Price,
+		})
+	}
+
+	for dishID, portions := range body.Portions {
+		if portions < 0 {
+			return []models.GetMeal{}, http.StatusBadRequest, errors.New("portions can't be negative")
+		}
+		if !containsString(body.Dishes, dishID) {
+			return []models.GetMeal{}, http.StatusBadRequest, errors.New("can't limit portions of dish which isn't in the meal")
+		}
+	}
+
+	return m.publish(path, body.Date, mealDishes, body.Portions, user)
+}
+
+// validateMealDate returns error if meal is published for previous date
+func validateMealDate(date time.Time) error {
+	if date.Sub(time.Now().Truncate(24*time.Hour)).Hours() < 0 {
+		return errors.New("item has wrong date (can't use previous dates)")
+	}
+
+	return nil
+}
 
+// publish creates new version of meal of client for date with provided
+// dishes and their prices, sets portion limits of dishes and returns meals of date
+func (m *MealService) publish(path url.PathClient, date time.Time, mealDishes []domain.MealDish,
+	portionLimits map[string]int, user interface{}) ([]models.GetMeal, int, error) {
 	userName := user.(domain.User).FirstName + " " + user.(domain.User).LastName
 
 	parsedCateringID, _ := uuid.FromString(path.ID)
 	parsedClientID, _ := uuid.FromString(path.ClientID)
 	meal := &domain.Meal{
-		Date:       body.Date,
+		Date:       date,
 		CateringID: parsedCateringID,
 		ClientID:   parsedClientID,
 		Person:     userName,
 	}
 
-	t := 24 * time.Hour
-	difference := body.Date.Sub(time.Now().Truncate(t)).Hours()
-
-	if difference < 0 {
-		return []models.GetMeal{}, http.StatusBadRequest, errors.New("item has wrong date (can't use previous dates)")
-	}
-
-	meals, code, err := mealRepo.Get(body.Date, path.ID, path.ClientID)
+	meals, code, err := mealRepo.Get(date, path.ID, path.ClientID, url.LabelFilterQuery{})
 
 	if err != nil {
 		return []models.GetMeal{}, code, err
@@ -61,36 +98,325 @@ func (m *MealService) Add(path url.PathClient, body models.AddMeal, user interfa
 		meal.Version = "V.1"
 	}
 
-	for _, dishID := range body.Dishes {
-		_, code, err := dishRepo.FindByID(path.ID, dishID)
-		if err != nil {
+	if err := mealRepo.Add(meal, user.(domain.User)); err != nil {
+		return []models.GetMeal{}, code, err
+	}
+
+	dishes := make([]uuid.UUID, 0, len(mealDishes))
+
+	for _, mealDish := range mealDishes {
+		mealDish.MealID = meal.ID
+		if err := mealDishRepo.Add(mealDish); err != nil {
+			return []models.GetMeal{}, http.StatusBadRequest, err
+		}
+		dishes = append(dishes, mealDish.DishID)
+	}
+
+	for dishID, portions := range portionLimits {
+		portions := portions
+		mealDish := url.PathMealDish{
+			ID:       path.ID,
+			ClientID: path.ClientID,
+			MealID:   meal.MealID.String(),
+			DishID:   dishID,
+		}
+		if _, code, err := mealDishRepo.SetPortions(mealDish, &portions); err != nil {
 			return []models.GetMeal{}, code, err
 		}
 	}
 
-	if err := mealRepo.Add(meal); err != nil {
+	mealEvent := models.MealEvent{
+		ID:         meal.ID,
+		MealID:     meal.MealID,
+		Version:    meal.Version,
+		Date:       meal.Date,
+		CateringID: parsedCateringID,
+		ClientID:   parsedClientID,
+		Dishes:     dishes,
+	}
+
+	if err := webhookRepo.AddEvent(enums.WebhookEventsEnum.MealPublished, parsedCateringID, parsedClientID, mealEvent); err != nil {
+		log.Printf("meal %s: can't add webhook event: %v", meal.ID, err)
+	}
+
+	result, code, err := mealRepo.Get(date, path.ID, path.ClientID, url.LabelFilterQuery{})
+
+	return result, code, err
+}
+
+// Copy copies latest version of meal of client from path to provided dates and clients,
+// dishes which were deleted or whose category is deleted on target date are skipped,
+// portion limits are copied without reservations. Targets are validated before copying,
+// every target is copied separately and failed one gets error in its result
+func (m *MealService) Copy(path url.PathClient, body models.CopyMeal, user interface{}) ([]models.CopiedMeal, int, error) {
+	clientIDs := body.ClientIDs
+
+	if len(clientIDs) == 0 {
+		clientIDs = []string{path.ClientID}
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+
+	for _, date := range body.Dates {
+		if date.Before(today) {
+			return nil, http.StatusBadRequest, errors.New("item has wrong date (can't use previous dates)")
+		}
+	}
+
+	for _, clientID := range clientIDs {
+		client, err := clientRepo.GetByKey("id", clientID)
+		if err != nil || client.CateringID.String() != path.ID {
+			return nil, http.StatusNotFound, errors.New("client not found")
+		}
+
+		for _, date := range body.Dates {
+			if clientID == path.ClientID && date.Equal(body.Date) {
+				return nil, http.StatusBadRequest, errors.New("can't copy meal to the same date and client")
+			}
+		}
+	}
+
+	source, code, err := mealRepo.GetLatest(path.ID, path.ClientID, body.Date)
+
+	if err != nil {
+		return nil, code, err
+	}
+
+	dishes, err := mealDishRepo.GetForCopy(source.ID)
+
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	var result []models.CopiedMeal
+	copied := make(map[string]bool)
+
+	for _, clientID := range clientIDs {
+		for _, date := range body.Dates {
+			key := clientID + date.String()
+			if copied[key] {
+				continue
+			}
+			copied[key] = true
+
+			parsedClientID, _ := uuid.FromString(clientID)
+			copiedMeal := models.CopiedMeal{
+				ClientID: parsedClientID,
+				Date:     date,
+				Skipped:  make([]models.SkippedDish, 0),
+			}
+			addMeal := models.AddMeal{
+				Date:     date,
+				Dishes:   make([]string, 0, len(dishes)),
+				Portions: make(map[string]int),
+			}
+
+			for _, dish := range dishes {
+				reason := ""
+				if dish.DeletedAt != nil {
+					reason = "dish deleted"
+				} else if dish.CategoryDeletedAt != nil && !dish.CategoryDeletedAt.After(date) {
+					reason = "category deleted"
+				}
+
+				if reason != "" {
+					copiedMeal.Skipped = append(copiedMeal.Skipped, models.SkippedDish{
+						DishID: dish.DishID,
+						Name:   dish.Name,
+						Reason: reason,
+					})
+					continue
+				}
+
+				addMeal.Dishes = append(addMeal.Dishes, dish.DishID.String())
+
+				if dish.Portions != nil {
+					addMeal.Portions[dish.DishID.String()] = *dish.Portions
+				}
+			}
+
+			if len(addMeal.Dishes) != 0 {
+				target := url.PathClient{
+					ID:       path.ID,
+					ClientID: clientID,
+				}
+
+				meals, _, err := m.Add(target, addMeal, user)
+				if err != nil {
+					log.Printf("meal %s: can't copy to client %s for %s: %v", source.ID, clientID, date.Format("2006-01-02"), err)
+					copiedMeal.Error = err.Error()
+				} else {
+					copiedMeal.MealID = &meals[0].MealID
+					copiedMeal.Version = meals[0].Version
+					copiedMeal.Dishes = len(addMeal.Dishes)
+				}
+			}
+
+			result = append(result, copiedMeal)
+		}
+	}
+
+	return result, 0, nil
+}
+
+// mealVersion returns name of meal version
+// for provided version name or number
+func mealVersion(value string) string {
+	if strings.HasPrefix(value, "V.") {
+		return value
+	}
+
+	return "V." + value
+}
+
+// Diff returns dishes which were added, removed
+// and whose price was changed between versions of meal
+func (m *MealService) Diff(path url.PathMealVersionDiff) (models.MealDiff, int, error) {
+	from, code, err := mealRepo.GetVersion(path.ID, path.ClientID, path.MealID, mealVersion(path.A))
+
+	if err != nil {
+		return models.MealDiff{}, code, err
+	}
+
+	to, code, err := mealRepo.GetVersion(path.ID, path.ClientID, path.MealID, mealVersion(path.B))
+
+	if err != nil {
+		return models.MealDiff{}, code, err
+	}
+
+	fromDishes, err := mealDishRepo.GetByMeal(from.ID)
+
+	if err != nil {
+		return models.MealDiff{}, http.StatusBadRequest, err
+	}
+
+	toDishes, err := mealDishRepo.GetByMeal(to.ID)
+
+	if err != nil {
+		return models.MealDiff{}, http.StatusBadRequest, err
+	}
+
+	result := models.MealDiff{
+		MealID:       from.MealID,
+		From:         from.Version,
+		To:           to.Version,
+		Added:        make([]models.MealVersionDish, 0),
+		Removed:      make([]models.MealVersionDish, 0),
+		PriceChanges: make([]models.MealPriceChange, 0),
+	}
+
+	fromPrices := make(map[uuid.UUID]float32, len(fromDishes))
+	toPrices := make(map[uuid.UUID]float32, len(toDishes))
+
+	for _, dish := range fromDishes {
+		fromPrices[dish.DishID] = dish.Price
+	}
+
+	for _, dish := range toDishes {
+		toPrices[dish.DishID] = dish.Price
+		oldPrice, exist := fromPrices[dish.DishID]
+
+		if !exist {
+			result.Added = append(result.Added, dish)
+			continue
+		}
+
+		if oldPrice != dish.Price {
+			result.PriceChanges = append(result.PriceChanges, models.MealPriceChange{
+				DishID:   dish.DishID,
+				Name:     dish.Name,
+				OldPrice: oldPrice,
+				NewPrice: dish.Price,
+			})
+		}
+	}
+
+	for _, dish := range fromDishes {
+		if _, exist := toPrices[dish.DishID]; !exist {
+			result.Removed = append(result.Removed, dish)
+		}
+	}
+
+	return result, 0, nil
+}
+
+// Rollback publishes new version of meal with the same dishes
+// and prices as provided older version, current portion limits
+// of its dishes are kept
+func (m *MealService) Rollback(path url.PathClient, body models.RollbackMeal, user interface{}) ([]models.GetMeal, int, error) {
+	version, code, err := mealRepo.GetVersion(path.ID, path.ClientID, body.MealID, mealVersion(body.Version))
+
+	if err != nil {
 		return []models.GetMeal{}, code, err
 	}
 
-	for _, dishID := range body.Dishes {
-		dishIDParsed, _ := uuid.FromString(dishID)
-		mealDish := domain.MealDish{
-			MealID: meal.ID,
-			DishID: dishIDParsed,
+	latest, code, err := mealRepo.GetLatest(path.ID, path.ClientID, version.Date)
+
+	if err != nil {
+		return []models.GetMeal{}, code, err
+	}
+
+	if latest.ID == version.ID {
+		return []models.GetMeal{}, http.StatusBadRequest, errors.New("version is already the latest one")
+	}
+
+	if err := validateMealDate(version.Date); err != nil {
+		return []models.GetMeal{}, http.StatusBadRequest, err
+	}
+
+	dishes, err := mealDishRepo.GetByMeal(version.ID)
+
+	if err != nil {
+		return []models.GetMeal{}, http.StatusBadRequest, err
+	}
+
+	copyDishes, err := mealDishRepo.GetForCopy(version.ID)
+
+	if err != nil {
+		return []models.GetMeal{}, http.StatusBadRequest, err
+	}
+
+	mealDishes := make([]domain.MealDish, len(dishes))
+	portionLimits := make(map[string]int)
+
+	for i, dish := range dishes {
+		mealDishes[i] = domain.MealDish{
+			DishID: dish.DishID,
+			Price:  dish.Price,
 		}
-		if err := mealDishRepo.Add(mealDish); err != nil {
-			return []models.GetMeal{}, http.StatusBadRequest, err
+	}
+
+	for _, dish := range copyDishes {
+		if dish.Portions != nil {
+			portionLimits[dish.DishID.String()] = *dish.Portions
 		}
 	}
 
-	result, code, err := mealRepo.Get(body.Date, path.ID, path.ClientID)
+	return m.publish(path, version.Date, mealDishes, portionLimits, user)
+}
 
-	return result, code, err
+// UpdatePortions sets or removes portions limit of dish in meal
+func (m *MealService) UpdatePortions(path url.PathMealDish, body models.UpdatePortions) (domain.MealDishPortion, int, error) {
+	if body.Portions != nil && *body.Portions < 0 {
+		return domain.MealDishPortion{}, http.StatusBadRequest, errors.New("portions can't be negative")
+	}
+
+	return mealDishRepo.SetPortions(path, body.Portions)
+}
+
+// containsString returns true if slice contains value
+func containsString(slice []string, value string) bool {
+	for _, item := range slice {
+		if item == value {
+			return true
+		}
+	}
+	return false
 }
 
 var cateringRepo = repository.NewCateringRepo()
 
-func (m *MealService) Get(query url.DateQuery, path url.PathClient) ([]models.GetMeal, int, error) {
+func (m *MealService) Get(query url.DateQuery, filter url.LabelFilterQuery, path url.PathClient) ([]models.GetMeal, int, error) {
 	_, err := cateringRepo.GetByKey("id", path.ID)
 
 	if err != nil {
@@ -105,7 +431,7 @@ func (m *MealService) Get(query url.DateQuery, path url.PathClient) ([]models.Ge
 		return []models.GetMeal{}, http.StatusBadRequest, errors.New("can't parse the date")
 	}
 
-	result, code, err := mealRepo.Get(mealDate, path.ID, path.ClientID)
+	result, code, err := mealRepo.Get(mealDate, path.ID, path.ClientID, filter)
 
 	return result, code, err
 }
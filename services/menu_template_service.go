@@ -0,0 +1,499 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/utils"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MenuTemplateService struct
+type MenuTemplateService struct{}
+
+// NewMenuTemplateService return pointer to menu template struct
+// with all methods
+func NewMenuTemplateService() *MenuTemplateService {
+	return &MenuTemplateService{}
+}
+
+var menuTemplateRepo = repository.NewMenuTemplateRepo()
+var cateringScheduleRepo = repository.NewCateringScheduleRepo()
+
+// menuTemplateDays is number of days ahead
+// for which meals of menu templates are published
+const menuTemplateDays = 7
+
+// maxPreviewDays limits number of days in one preview of menu template
+const maxPreviewDays = 62
+
+// Add creates menu template for provided catering
+func (m *MenuTemplateService) Add(path url.PathID, body models.MenuTemplateRequest, user interface{}) (models.MenuTemplate, int, error) {
+	menuTemplate, items, code, err := m.validate(path.ID, "", body)
+
+	if err != nil {
+		return models.MenuTemplate{}, code, err
+	}
+
+	return menuTemplateRepo.Add(menuTemplate, items, user.(domain.User))
+}
+
+// Get returns menu templates of provided catering
+func (m *MenuTemplateService) Get(path url.PathID) ([]models.MenuTemplate, int, error) {
+	return menuTemplateRepo.Get(path.ID)
+}
+
+// GetByID returns menu template of provided catering
+func (m *MenuTemplateService) GetByID(path url.PathMenuTemplate) (models.MenuTemplate, int, error) {
+	return menuTemplateRepo.GetByID(path.ID, path.MenuTemplateID)
+}
+
+// Update replaces settings and items of menu template,
+// menu template is enabled or disabled with enabled field
+func (m *MenuTemplateService) Update(path url.PathMenuTemplate, body models.MenuTemplateRequest, user interface{}) (models.MenuTemplate, int, error) {
+	menuTemplate, items, code, err := m.validate(path.ID, path.MenuTemplateID, body)
+
+	if err != nil {
+		return models.MenuTemplate{}, code, err
+	}
+
+	return menuTemplateRepo.Update(path.ID, path.MenuTemplateID, menuTemplate, items, user.(domain.User))
+}
+
+// Delete removes menu template, meals
+// which are already published aren't changed
+func (m *MenuTemplateService) Delete(path url.PathMenuTemplate, user interface{}) (int, error) {
+	return menuTemplateRepo.Delete(path.ID, path.MenuTemplateID, user.(domain.User))
+}
+
+// AddOverride replaces dishes of menu template for single date of client,
+// day of cycle of other dates isn't changed
+func (m *MenuTemplateService) AddOverride(path url.PathMenuTemplate, body models.MenuTemplateOverride) (domain.MenuTemplateOverride, int, error) {
+	menuTemplate, code, err := menuTemplateRepo.GetByID(path.ID, path.MenuTemplateID)
+
+	if err != nil {
+		return domain.MenuTemplateOverride{}, code, err
+	}
+
+	date := body.Date.UTC().Truncate(time.Hour * 24)
+
+	if isPreviousDate(date) {
+		return domain.MenuTemplateOverride{}, http.StatusBadRequest, errors.New("can't override previous date")
+	}
+
+	if code, err := m.validateClient(path.ID, body.ClientID.String()); err != nil {
+		return domain.MenuTemplateOverride{}, code, err
+	}
+
+	placements, err := menuTemplateRepo.GetPlacements(menuTemplate.ID, date, date)
+
+	if err != nil {
+		return domain.MenuTemplateOverride{}, http.StatusBadRequest, err
+	}
+
+	for _, placement := range placements {
+		if placement.ClientID == body.ClientID {
+			return domain.MenuTemplateOverride{}, http.StatusBadRequest, errors.New("meal for that date is already published")
+		}
+	}
+
+	dishes := make(pq.StringArray, 0, len(body.Dishes))
+	usedDishes := make(map[uuid.UUID]bool)
+
+	for _, dishID := range body.Dishes {
+		if usedDishes[dishID] {
+			continue
+		}
+		usedDishes[dishID] = true
+
+		if _, code, err := dishRepo.FindByID(path.ID, dishID.String()); err != nil {
+			return domain.MenuTemplateOverride{}, code, err
+		}
+
+		dishes = append(dishes, dishID.String())
+	}
+
+	return menuTemplateRepo.AddOverride(domain.MenuTemplateOverride{
+		MenuTemplateID: menuTemplate.ID,
+		ClientID:       body.ClientID,
+		Date:           date,
+		Dishes:         dishes,
+	})
+}
+
+// DeleteOverride cancels override of single date of client
+func (m *MenuTemplateService) DeleteOverride(path url.PathMenuTemplate, query url.MenuTemplateOverrideQuery) (int, error) {
+	date, err := time.Parse(time.RFC3339, query.Date)
+
+	if err != nil {
+		return http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	menuTemplate, code, err := menuTemplateRepo.GetByID(path.ID, path.MenuTemplateID)
+
+	if err != nil {
+		return code, err
+	}
+
+	return menuTemplateRepo.DeleteOverride(menuTemplate.ID.String(), query.ClientID, date)
+}
+
+// Preview returns meals which menu template generates
+// for working days of catering in provided range of dates
+func (m *MenuTemplateService) Preview(path url.PathMenuTemplate, query url.DateRangeQuery) ([]models.MenuTemplateDay, int, error) {
+	from, err := time.Parse(time.RFC3339, query.From)
+
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	to, err := time.Parse(time.RFC3339, query.To)
+
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	if to.Before(from) {
+		return nil, http.StatusBadRequest, errors.New("from date can't be after to date")
+	}
+
+	if to.Sub(from).Hours() >= maxPreviewDays*24 {
+		return nil, http.StatusBadRequest, fmt.Errorf("can't preview more than %d days at once", maxPreviewDays)
+	}
+
+	menuTemplate, code, err := menuTemplateRepo.GetByID(path.ID, path.MenuTemplateID)
+
+	if err != nil {
+		return nil, code, err
+	}
+
+	days, err := m.generate(menuTemplate, from, to)
+
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	return days, 0, nil
+}
+
+// validate checks menu template request and returns
+// menu template with items ready to be saved
+func (m *MenuTemplateService) validate(cateringID, menuTemplateID string, body models.MenuTemplateRequest) (domain.MenuTemplate, []domain.MenuTemplateItem, int, error) {
+	if _, err := cateringRepo.GetByKey("id", cateringID); err != nil {
+		return domain.MenuTemplate{}, nil, http.StatusNotFound, errors.New("catering not found")
+	}
+
+	if body.Length <= 0 {
+		return domain.MenuTemplate{}, nil, http.StatusBadRequest, errors.New("length of cycle must be positive")
+	}
+
+	if len(body.Items) == 0 {
+		return domain.MenuTemplate{}, nil, http.StatusBadRequest, errors.New("menu template must contain at least one item")
+	}
+
+	var items []domain.MenuTemplateItem
+	usedItems := make(map[string]bool)
+	usedClients := make(map[uuid.UUID]bool)
+
+	for _, item := range body.Items {
+		if item.Day < 0 || item.Day >= body.Length {
+			return domain.MenuTemplate{}, nil, http.StatusBadRequest, errors.New("day must be between 0 and length of cycle")
+		}
+
+		if (item.DishID == nil) == (item.CategoryID == nil) {
+			return domain.MenuTemplate{}, nil, http.StatusBadRequest, errors.New("item must contain either dish or category")
+		}
+
+		if !usedClients[item.ClientID] {
+			if code, err := m.validateClient(cateringID, item.ClientID.String()); err != nil {
+				return domain.MenuTemplate{}, nil, code, err
+			}
+			usedClients[item.ClientID] = true
+		}
+
+		var itemID uuid.UUID
+
+		if item.DishID != nil {
+			itemID = *item.DishID
+			if _, code, err := dishRepo.FindByID(cateringID, itemID.String()); err != nil {
+				return domain.MenuTemplate{}, nil, code, err
+			}
+		} else {
+			itemID = *item.CategoryID
+			if _, err := categoryRepo.GetByKey("id", itemID.String(), cateringID); err != nil {
+				return domain.MenuTemplate{}, nil, http.StatusNotFound, errors.New("category with that id not found")
+			}
+		}
+
+		key := fmt.Sprintf("%d %s %s", item.Day, item.ClientID, itemID)
+
+		if usedItems[key] {
+			return domain.MenuTemplate{}, nil, http.StatusBadRequest, errors.New("can't add 2 same items to one day of client")
+		}
+		usedItems[key] = true
+
+		items = append(items, domain.MenuTemplateItem{
+			Day:        item.Day,
+			ClientID:   item.ClientID,
+			DishID:     item.DishID,
+			CategoryID: item.CategoryID,
+		})
+	}
+
+	if body.Enabled {
+		menuTemplates, err := menuTemplateRepo.GetEnabled()
+
+		if err != nil {
+			return domain.MenuTemplate{}, nil, http.StatusBadRequest, err
+		}
+
+		for _, menuTemplate := range menuTemplates {
+			if menuTemplate.ID.String() == menuTemplateID {
+				continue
+			}
+			for _, item := range menuTemplate.Items {
+				if usedClients[item.ClientID] {
+					return domain.MenuTemplate{}, nil, http.StatusBadRequest, errors.New("another enabled menu template already covers that client")
+				}
+			}
+		}
+	}
+
+	parsedCateringID, _ := uuid.FromString(cateringID)
+
+	return domain.MenuTemplate{
+		Name:       body.Name,
+		CateringID: parsedCateringID,
+		StartDate:  body.StartDate.UTC().Truncate(time.Hour * 24),
+		Length:     body.Length,
+		Enabled:    body.Enabled,
+	}, items, 0, nil
+}
+
+// validateClient checks that client belongs to catering
+func (m *MenuTemplateService) validateClient(cateringID, clientID string) (int, error) {
+	client, err := clientRepo.GetByKey("id", clientID)
+
+	if err != nil || client.CateringID.String() != cateringID {
+		return http.StatusNotFound, errors.New("client not found")
+	}
+
+	return 0, nil
+}
+
+// menuTemplateDay returns day of cycle of menu template for date,
+// false is returned for dates before start of menu template
+func menuTemplateDay(menuTemplate domain.MenuTemplate, date time.Time) (int, bool) {
+	if date.Before(menuTemplate.StartDate) {
+		return 0, false
+	}
+
+	days := int(date.Sub(menuTemplate.StartDate).Hours() / 24)
+
+	return days % menuTemplate.Length, true
+}
+
+// generate returns meals of clients which menu template
+// generates for working days of catering in range of dates,
+// overridden dates get dishes of override instead of day of cycle
+func (m *MenuTemplateService) generate(menuTemplate models.MenuTemplate, from, to time.Time) ([]models.MenuTemplateDay, error) {
+	from = from.UTC().Truncate(time.Hour * 24)
+	to = to.UTC().Truncate(time.Hour * 24)
+	days := make([]models.MenuTemplateDay, 0)
+
+	schedules, _, err := cateringScheduleRepo.Get(menuTemplate.CateringID.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	workingDays := make(map[int]bool)
+
+	for _, schedule := range schedules {
+		workingDays[schedule.Day] = schedule.IsWorking
+	}
+
+	var clientIDs []uuid.UUID
+	usedClients := make(map[uuid.UUID]bool)
+
+	for _, item := range menuTemplate.Items {
+		if !usedClients[item.ClientID] {
+			usedClients[item.ClientID] = true
+			clientIDs = append(clientIDs, item.ClientID)
+		}
+	}
+
+	overrides := make(map[string]domain.MenuTemplateOverride)
+
+	for _, override := range menuTemplate.Overrides {
+		overrides[override.ClientID.String()+override.Date.UTC().Format(time.RFC3339)] = override
+
+		if !usedClients[override.ClientID] {
+			usedClients[override.ClientID] = true
+			clientIDs = append(clientIDs, override.ClientID)
+		}
+	}
+
+	placements, err := menuTemplateRepo.GetPlacements(menuTemplate.ID, from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	published := make(map[string]bool)
+
+	for _, placement := range placements {
+		published[placement.ClientID.String()+placement.Date.UTC().Format(time.RFC3339)] = true
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day, ok := menuTemplateDay(menuTemplate.MenuTemplate, date)
+
+		if !ok || !workingDays[utils.GetDayOfWeek(date)] {
+			continue
+		}
+
+		for _, clientID := range clientIDs {
+			key := clientID.String() + date.Format(time.RFC3339)
+			override, isOverride := overrides[key]
+
+			var dishes []models.MenuTemplateDish
+
+			if isOverride {
+				dishes, err = menuTemplateRepo.GetDishesByIDs(override.Dishes, date)
+			} else {
+				dishes, err = menuTemplateRepo.GetDayDishes(menuTemplate.ID, clientID, day, date)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if len(dishes) == 0 && !isOverride {
+				continue
+			}
+
+			if dishes == nil {
+				dishes = make([]models.MenuTemplateDish, 0)
+			}
+
+			days = append(days, models.MenuTemplateDay{
+				Date:      date,
+				ClientID:  clientID,
+				Day:       day,
+				Override:  isOverride,
+				Published: published[key],
+				Dishes:    dishes,
+			})
+		}
+	}
+
+	return days, nil
+}
+
+// PublishMeals publishes meals of enabled menu templates for the next days,
+// dates for which client already has meal aren't changed
+func (m *MenuTemplateService) PublishMeals() {
+	today := time.Now().UTC().Truncate(time.Hour * 24)
+	menuTemplates, err := menuTemplateRepo.GetEnabled()
+
+	if err != nil {
+		log.Printf("menu templates: can't get enabled templates: %v", err)
+		return
+	}
+
+	for _, menuTemplate := range menuTemplates {
+		days, err := m.generate(menuTemplate, today.AddDate(0, 0, 1), today.AddDate(0, 0, menuTemplateDays))
+
+		if err != nil {
+			log.Printf("menu template %s: can't generate meals: %v", menuTemplate.ID, err)
+			continue
+		}
+
+		for _, day := range days {
+			if !day.Published {
+				m.publish(menuTemplate.MenuTemplate, day)
+			}
+		}
+	}
+}
+
+// publish creates meal of generated day of menu template,
+// date is claimed before meal is published, so two instances
+// running cron don't publish two versions of meal
+func (m *MenuTemplateService) publish(menuTemplate domain.MenuTemplate, day models.MenuTemplateDay) {
+	cateringID := menuTemplate.CateringID.String()
+	clientID := day.ClientID.String()
+	date := day.Date.Format("2006-01-02")
+
+	claimed, err := menuTemplateRepo.ClaimPlacement(menuTemplate.ID, day.ClientID, day.Date)
+
+	if err != nil {
+		log.Printf("menu template %s: can't claim %s of client %s: %v", menuTemplate.ID, date, clientID, err)
+		return
+	}
+
+	if !claimed {
+		return
+	}
+
+	meals, _, err := mealRepo.Get(day.Date, cateringID, clientID, url.LabelFilterQuery{})
+
+	if err != nil {
+		log.Printf("menu template %s: can't get meals of %s of client %s: %v", menuTemplate.ID, date, clientID, err)
+		m.release(menuTemplate, day)
+		return
+	}
+
+	// client already has meal that day or override removes meal of that day,
+	// so menu template doesn't publish it and date stays claimed without meal
+	if len(meals) != 0 || len(day.Dishes) == 0 {
+		return
+	}
+
+	dishes := make([]string, len(day.Dishes))
+
+	for i, dish := range day.Dishes {
+		dishes[i] = dish.ID.String()
+	}
+
+	path := url.PathClient{
+		ID:       cateringID,
+		ClientID: clientID,
+	}
+	person := domain.User{
+		FirstName: "Menu template",
+		LastName:  menuTemplate.Name,
+	}
+
+	result, _, err := NewMealService().Add(path, models.AddMeal{
+		Date:   day.Date,
+		Dishes: dishes,
+	}, person)
+
+	if err != nil || len(result) == 0 {
+		log.Printf("menu template %s: can't publish meal of %s for client %s: %v", menuTemplate.ID, date, clientID, err)
+		m.release(menuTemplate, day)
+		return
+	}
+
+	if err := menuTemplateRepo.SetPlacementMeal(menuTemplate.ID, day.ClientID, day.Date, result[0].MealID); err != nil {
+		log.Printf("menu template %s: can't save meal of %s for client %s: %v", menuTemplate.ID, date, clientID, err)
+	}
+}
+
+// release returns claim of date of client, so meal is published on the next run
+func (m *MenuTemplateService) release(menuTemplate domain.MenuTemplate, day models.MenuTemplateDay) {
+	if err := menuTemplateRepo.ReleasePlacement(menuTemplate.ID, day.ClientID, day.Date); err != nil {
+		log.Printf("menu template %s: can't release %s of client %s: %v",
+			menuTemplate.ID, day.Date.Format("2006-01-02"), day.ClientID, err)
+	}
+}
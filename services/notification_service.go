@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/mailer"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+)
+
+// NotificationService struct
+type NotificationService struct{}
+
+// NewNotificationService return pointer to notification struct
+// with all methods
+func NewNotificationService() *NotificationService {
+	return &NotificationService{}
+}
+
+var notificationRepo = repository.NewNotificationRepo()
+
+// notificationEmailsLimit limits number of emails sent by one run of cron
+const notificationEmailsLimit = 100
+
+// maxNotificationEmailAttempts limits number of attempts to email
+// notification, attempts are made when lease of the failed one is over
+const maxNotificationEmailAttempts = 5
+
+// Get returns page of notifications of user
+func (n *NotificationService) Get(path url.PathID, query url.NotificationQuery, pagination url.PaginationQuery) (models.Notifications, int, error) {
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+
+	if _, err := userRepo.GetByID(path.ID); err != nil {
+		return models.Notifications{}, http.StatusNotFound, errors.New("user not found")
+	}
+
+	return notificationRepo.Get(path.ID, query.Unread, pagination)
+}
+
+// Read marks notifications of user as read
+func (n *NotificationService) Read(path url.PathID, body models.ReadNotifications) (int, error) {
+	return notificationRepo.Read(path.ID, body.IDs)
+}
+
+// SendEmails emails notifications which aren't emailed yet,
+// is run by cron every minute, notification is marked as emailed
+// only after its email is sent, failed ones are retried later
+// until attempts are over, then notification is marked as failed
+func (n *NotificationService) SendEmails() {
+	notifications, err := notificationRepo.TakeToEmail(notificationEmailsLimit)
+
+	if err != nil {
+		log.Printf("notifications: can't take notifications to email: %v", err)
+		return
+	}
+
+	for _, notification := range notifications {
+		if err := mailer.SendNotification(notification.FirstName, notification.Email, notification.Title, notification.Message); err != nil {
+			log.Printf("notification %s: can't send email to %s: %v", notification.ID, notification.Email, err)
+
+			if notification.EmailAttempts < maxNotificationEmailAttempts {
+				continue
+			}
+
+			if err := notificationRepo.MarkEmailFailed(notification.ID); err != nil {
+				log.Printf("notification %s: can't mark email as failed: %v", notification.ID, err)
+			}
+			continue
+		}
+
+		if err := notificationRepo.MarkEmailed(notification.ID); err != nil {
+			log.Printf("notification %s: can't mark as emailed: %v", notification.ID, err)
+		}
+	}
+}
@@ -2,18 +2,21 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
-	"os"
-	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Aiscom-LLC/meals-api/api/url"
 
-	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/events"
 	"github.com/Aiscom-LLC/meals-api/repository"
 	"github.com/Aiscom-LLC/meals-api/repository/enums"
 	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/Aiscom-LLC/meals-api/utils"
 	"github.com/dgrijalva/jwt-go"
 	uuid "github.com/satori/go.uuid"
 )
@@ -28,6 +31,7 @@ func NewOrderService() *OrderService {
 }
 
 var orderRepo = repository.NewOrderRepo()
+var clientScheduleRepo = repository.NewClientScheduleRepo()
 
 func (o *OrderService) Add(query string, order models.OrderRequest, claims jwt.MapClaims) (models.UserOrder, int, error) {
 	userRepo := repository.NewUserRepo()
@@ -42,16 +46,6 @@ func (o *OrderService) Add(query string, order models.OrderRequest, claims jwt.M
 	} else {
 		userID = user.ID.String()
 	}
-	for i, dish := range order.Items {
-		if dish.Amount == 0 {
-			return models.UserOrder{}, http.StatusBadRequest, errors.New("can't add dish with 0 amount")
-		}
-		for j := i + 1; j < len(order.Items); j++ {
-			if dish.DishID == order.Items[j].DishID {
-				return models.UserOrder{}, http.StatusBadRequest, errors.New("can't add 2 same dishes, please increment amount field instead")
-			}
-		}
-	}
 
 	date, err := time.Parse(time.RFC3339, query)
 
@@ -59,149 +53,758 @@ func (o *OrderService) Add(query string, order models.OrderRequest, claims jwt.M
 		return models.UserOrder{}, http.StatusBadRequest, err
 	}
 
-	difference := date.Sub(time.Now().Truncate(time.Hour * 24)).Hours()
+	return o.AddForUser(userID, date, order)
+}
+
+// AddForUser creates order of provided user for date
+// with the same validation as order created by user
+func (o *OrderService) AddForUser(userID string, date time.Time, order models.OrderRequest) (models.UserOrder, int, error) {
+	if err := validateOrderItems(order.Items); err != nil {
+		return models.UserOrder{}, http.StatusBadRequest, err
+	}
 
-	if difference < 0 {
+	if isPreviousDate(date) {
 		return models.UserOrder{}, http.StatusBadRequest, errors.New("can't add order to previous date")
 	}
 
+	if code, err := o.validateOrder(userID, date, order.Items); err != nil {
+		return models.UserOrder{}, code, err
+	}
+
 	userOrder, err := orderRepo.Add(userID, date, order)
 
 	if err != nil {
 		return models.UserOrder{}, http.StatusBadRequest, err
 	}
 
+	dishIDs := make([]uuid.UUID, len(order.Items))
+
+	for i := range order.Items {
+		dishIDs[i] = order.Items[i].DishID
+	}
+
+	// conflicts with dietary profile only warn user, order is already placed
+	userOrder.Warnings, _ = labelRepo.GetDietaryWarnings(userID, dishIDs)
+
 	return userOrder, 0, nil
 }
 
-func (o *OrderService) GetClientOrdersExcel(path url.PathID, query url.DateQuery) (string, int, error) {
-	client := enums.CompanyTypesEnum.Client
-	result, code, err := orderRepo.GetOrders("", path.ID, query.Date, client)
+// Update replaces dishes and comment of pending order
+func (o *OrderService) Update(path url.PathOrder, order models.OrderRequest) (models.UpdatedUserOrder, int, error) {
+	if err := validateOrderItems(order.Items); err != nil {
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, err
+	}
+
+	prevOrder, code, err := orderRepo.GetByID(path.ID, path.OrderID)
 
 	if err != nil {
-		return "", code, err
+		return models.UpdatedUserOrder{}, code, err
 	}
 
-	f := excelize.NewFile()
+	if isPreviousDate(prevOrder.Date) {
+		return models.UpdatedUserOrder{}, http.StatusBadRequest, errors.New("can't update order of previous date")
+	}
 
-	dir, _ := os.Getwd()
-	style, _ := f.NewStyle(`{"alignment":{"horizontal": "center", "vertical": "center"}}`)
-	commentStyle, _ := f.NewStyle(`{"alignment":{"horizontal": "left", "vertical": "top", "wrap_text": true}}`)
-	headers := map[string]string{"A1": "Имя", "B1": "Этаж", "C1": "Заказ", "D1": "Комментарий", "E1": "Сумма"}
-	for k, v := range headers {
-		f.SetCellValue("Sheet1", k, v)
-		f.SetCellStyle("Sheet1", k, k, style)
+	if code, err := o.validateOrder(path.ID, prevOrder.Date, order.Items); err != nil {
+		return models.UpdatedUserOrder{}, code, err
 	}
 
-	f.SetColWidth("Sheet1", "A", "A", 25)
-	f.SetColWidth("Sheet1", "D", "D", 25)
-	f.SetColWidth("Sheet1", "B", "B", 10)
-	f.SetColWidth("Sheet1", "C", "C", 25)
-	f.SetColWidth("Sheet1", "E", "E", 15)
-	f.SetColWidth("Sheet1", "G", "G", 15)
-	f.SetColWidth("Sheet1", "H", "H", 25)
-	f.SetColWidth("Sheet1", "I", "I", 10)
-	f.SetColWidth("Sheet1", "K", "K", 30)
-	f.SetCellValue("Sheet1", "K1", "Общая сумма заказов")
-	f.SetCellValue("Sheet1", "K2", result.Total)
-	f.SetCellStyle("Sheet1", "K1", "K2", style)
+	return orderRepo.Update(path.ID, path.OrderID, order)
+}
 
-	for index, order := range result.UserOrders {
-		var startLine int
-		for i, ord := range result.UserOrders {
-			if i < index {
-				startLine += len(ord.Items)
-			}
+// CancelOrder changes status of pending order to canceled
+// if ordering for its date isn't closed yet
+func (o *OrderService) CancelOrder(path url.PathOrder, user interface{}) (int, error) {
+	order, code, err := orderRepo.GetByID(path.ID, path.OrderID)
+
+	if err != nil {
+		return code, err
+	}
+
+	if code, err := o.validateOrder(path.ID, order.Date, nil); err != nil {
+		return code, err
+	}
+
+	return orderRepo.CancelOrder(path.ID, path.OrderID, user.(domain.User))
+}
+
+// historyPeriods are periods by which totals of order history are grouped
+var historyPeriods = []string{"day", "week", "month"}
+
+// orderStatuses are statuses by which order history can be filtered
+var orderStatuses = []string{
+	enums.OrderStatusTypesEnum.Pending,
+	enums.OrderStatusTypesEnum.Approved,
+	enums.OrderStatusTypesEnum.Canceled,
+	enums.OrderStatusTypesEnum.Rejected,
+	enums.OrderStatusTypesEnum.Preparing,
+	enums.OrderStatusTypesEnum.OutForDelivery,
+	enums.OrderStatusTypesEnum.Delivered,
+	enums.OrderStatusTypesEnum.Failed,
+}
+
+// GetHistory returns orders of user for range of dates
+// with totals grouped by day, week or month
+func (o *OrderService) GetHistory(path url.PathID, query url.OrderHistoryQuery, pagination url.PaginationQuery) (models.OrderHistory, int, error) {
+	from, err := time.Parse(time.RFC3339, query.From)
+
+	if err != nil {
+		return models.OrderHistory{}, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	to, err := time.Parse(time.RFC3339, query.To)
+
+	if err != nil {
+		return models.OrderHistory{}, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	if to.Before(from) {
+		return models.OrderHistory{}, http.StatusBadRequest, errors.New("from date can't be after to date")
+	}
+
+	if query.GroupBy == "" {
+		query.GroupBy = "month"
+	}
+
+	if !containsString(historyPeriods, query.GroupBy) {
+		return models.OrderHistory{}, http.StatusBadRequest, errors.New("groupBy must be day, week or month")
+	}
+
+	for _, status := range query.Status {
+		if !containsString(orderStatuses, status) {
+			return models.OrderHistory{}, http.StatusBadRequest, fmt.Errorf("unknown order status %s", status)
 		}
-		if index > 0 {
-			start := startLine + 2
-			for idx, dish := range order.Items {
-				f.SetCellValue("Sheet1", "C"+strconv.Itoa(start+idx), dish.Name+" "+strconv.Itoa(dish.Amount))
-				f.SetCellStyle("Sheet1", "C"+strconv.Itoa(start+idx), "C"+strconv.Itoa(start+idx), style)
-			}
-			st := strconv.Itoa(start)
-			end := strconv.Itoa(start + len(order.Items) - 1)
-			f.SetCellValue("Sheet1", "A"+st, order.Name)
-			f.SetCellValue("Sheet1", "B"+st, order.Floor)
-
-			f.SetCellValue("Sheet1", "E"+st, order.Total)
-			if order.Comment != "" {
-				f.SetCellValue("Sheet1", "D"+st, order.Comment)
-			} else {
-				f.SetCellValue("Sheet1", "D"+st, "Нет комментария к заказу")
-			}
-			f.MergeCell("Sheet1", "A"+st, "A"+end)
-			f.MergeCell("Sheet1", "B"+st, "B"+end)
-			f.MergeCell("Sheet1", "D"+st, "D"+end)
-			f.MergeCell("Sheet1", "E"+st, "E"+end)
-			f.SetCellStyle("Sheet1", "A"+st, "A"+end, style)
-			f.SetCellStyle("Sheet1", "B"+st, "B"+end, style)
-			f.SetCellStyle("Sheet1", "D"+st, "D"+end, commentStyle)
-			f.SetCellStyle("Sheet1", "E"+st, "E"+end, style)
-		} else {
-			for idx, dish := range order.Items {
-				f.SetCellValue("Sheet1", "C"+strconv.Itoa(2+idx), dish.Name+" "+strconv.Itoa(dish.Amount))
-				f.SetCellStyle("Sheet1", "C"+strconv.Itoa(2+idx), "C"+strconv.Itoa(2+idx), style)
-			}
-			f.SetCellValue("Sheet1", "A2", order.Name)
-			f.SetCellValue("Sheet1", "B2", order.Floor)
-			f.SetCellValue("Sheet1", "E2", order.Total)
-			if order.Comment != "" {
-				f.SetCellValue("Sheet1", "D2", order.Comment)
-			} else {
-				f.SetCellValue("Sheet1", "D2", "Нет комментария к заказу")
+	}
+
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+
+	return orderRepo.GetHistory(path.ID, from, to, query.Status, query.GroupBy, pagination)
+}
+
+// Repeat creates order with dishes and comment of past order
+// for provided date, it's validated against menu of that date
+func (o *OrderService) Repeat(path url.PathOrder, query url.DateQuery) (models.UserOrder, int, error) {
+	date, err := time.Parse(time.RFC3339, query.Date)
+
+	if err != nil {
+		return models.UserOrder{}, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	order, code, err := orderRepo.GetByID(path.ID, path.OrderID)
+
+	if err != nil {
+		return models.UserOrder{}, code, err
+	}
+
+	orderDishes, err := orderRepo.GetDishes(path.OrderID)
+
+	if err != nil {
+		return models.UserOrder{}, http.StatusBadRequest, err
+	}
+
+	items := make([]models.Order, 0, len(orderDishes))
+
+	for _, dish := range orderDishes {
+		items = append(items, models.Order{
+			DishID: dish.DishID,
+			Amount: dish.Amount,
+		})
+	}
+
+	return o.AddForUser(path.ID, date, models.OrderRequest{
+		Items:   items,
+		Comment: utils.DerefString(order.Comment),
+	})
+}
+
+// maxBulkDays limits number of days in one bulk request
+const maxBulkDays = 31
+
+// AddBulk creates orders of user for several dates at once,
+// every day is validated first and orders are created only
+// if all of them are valid, result is returned for every day
+func (o *OrderService) AddBulk(path url.PathID, orders map[string]models.OrderRequest) ([]models.BulkOrderResult, int, error) {
+	if len(orders) == 0 {
+		return nil, http.StatusBadRequest, errors.New("orders must contain at least one day")
+	}
+
+	if len(orders) > maxBulkDays {
+		return nil, http.StatusBadRequest, fmt.Errorf("can't order more than %d days at once", maxBulkDays)
+	}
+
+	type bulkDay struct {
+		key  string
+		date time.Time
+	}
+
+	days := make([]bulkDay, 0, len(orders))
+	usedDays := make(map[string]string)
+
+	for key := range orders {
+		date, err := time.Parse(time.RFC3339, key)
+
+		if err != nil {
+			return nil, http.StatusBadRequest, errors.New("can't parse the date " + key)
+		}
+
+		day := date.UTC().Format("2006-01-02")
+
+		if usedKey, ok := usedDays[day]; ok {
+			return nil, http.StatusBadRequest, errors.New("dates " + usedKey + " and " + key + " are the same day")
+		}
+		usedDays[day] = key
+
+		days = append(days, bulkDay{key: key, date: date})
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].date.Before(days[j].date)
+	})
+
+	dates := make([]time.Time, len(days))
+	requests := make([]models.OrderRequest, len(days))
+	results := make([]models.BulkOrderResult, len(days))
+	valid := true
+
+	for i, day := range days {
+		order := orders[day.key]
+		dates[i] = day.date
+		requests[i] = order
+		results[i].Date = day.key
+
+		if err := validateOrderItems(order.Items); err != nil {
+			setBulkError(&results[i], err)
+		} else if isPreviousDate(day.date) {
+			setBulkError(&results[i], errors.New("can't add order to previous date"))
+		} else if _, err := o.validateOrder(path.ID, day.date, order.Items); err != nil {
+			setBulkError(&results[i], err)
+		}
+
+		valid = valid && results[i].Error == ""
+	}
+
+	if !valid {
+		return results, http.StatusBadRequest, errors.New("some days can't be ordered")
+	}
+
+	userOrders, index, err := orderRepo.AddBulk(path.ID, dates, requests)
+
+	if err != nil {
+		if index == -1 {
+			return nil, http.StatusBadRequest, err
+		}
+		setBulkError(&results[index], err)
+		return results, http.StatusBadRequest, errors.New("some days can't be ordered")
+	}
+
+	for i := range results {
+		results[i].Success = true
+		results[i].Order = &userOrders[i]
+	}
+
+	return results, 0, nil
+}
+
+// CancelBulk cancels all orders of user for range of dates at once,
+// orders are canceled only if all of them can be canceled
+func (o *OrderService) CancelBulk(path url.PathID, query url.DateRangeQuery, user interface{}) ([]models.BulkOrderResult, int, error) {
+	from, err := time.Parse(time.RFC3339, query.From)
+
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	to, err := time.Parse(time.RFC3339, query.To)
+
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	if to.Before(from) {
+		return nil, http.StatusBadRequest, errors.New("from date can't be after to date")
+	}
+
+	if to.Sub(from).Hours() >= maxBulkDays*24 {
+		return nil, http.StatusBadRequest, fmt.Errorf("can't cancel more than %d days at once", maxBulkDays)
+	}
+
+	orders, code, err := orderRepo.GetByDates(path.ID, from, to)
+
+	if err != nil {
+		return nil, code, err
+	}
+
+	results := make([]models.BulkOrderResult, len(orders))
+	orderIDs := make([]string, 0, len(orders))
+	valid := true
+
+	for i, order := range orders {
+		results[i].Date = order.Date.UTC().Format(time.RFC3339)
+		orderIDs = append(orderIDs, order.ID.String())
+
+		if *order.Status != enums.OrderStatusTypesEnum.Pending {
+			setBulkError(&results[i], errors.New("only pending order can be canceled"))
+		} else if _, err := o.validateOrder(path.ID, order.Date, nil); err != nil {
+			setBulkError(&results[i], err)
+		}
+
+		valid = valid && results[i].Error == ""
+	}
+
+	if !valid {
+		return results, http.StatusBadRequest, errors.New("some days can't be canceled")
+	}
+
+	if code, err := orderRepo.CancelBulk(path.ID, orderIDs, user.(domain.User)); err != nil {
+		return nil, code, err
+	}
+
+	for i := range results {
+		results[i].Success = true
+	}
+
+	return results, 0, nil
+}
+
+// setBulkError sets error of single day of bulk request
+func setBulkError(result *models.BulkOrderResult, err error) {
+	var dishesErr *DishesNotInMealError
+
+	result.Error = err.Error()
+
+	if errors.As(err, &dishesErr) {
+		result.DishIDs = dishesErr.DishIDs
+	}
+}
+
+// UpdateOrdersStatus approves or rejects selected orders of client,
+// reason is required for rejection and is shown to user
+func (o *OrderService) UpdateOrdersStatus(path url.PathID, body models.UpdateOrdersStatus, user interface{}) (int, error) {
+	if body.Status != enums.OrderStatusTypesEnum.Approved && body.Status != enums.OrderStatusTypesEnum.Rejected {
+		return http.StatusBadRequest, errors.New("status must be approved or rejected")
+	}
+
+	if body.Status == enums.OrderStatusTypesEnum.Rejected && body.Reason == "" {
+		return http.StatusBadRequest, errors.New("reason is required to reject order")
+	}
+
+	var orderIDs []uuid.UUID
+	used := make(map[uuid.UUID]bool)
+
+	for _, id := range body.OrderIDs {
+		if !used[id] {
+			used[id] = true
+			orderIDs = append(orderIDs, id)
+		}
+	}
+
+	if len(orderIDs) == 0 {
+		return http.StatusBadRequest, errors.New("orderIds must contain at least one order")
+	}
+
+	return orderRepo.UpdateOrdersStatus(path.ID, orderIDs, body.Status, body.Reason, user.(domain.User))
+}
+
+// deliveryTransitions contains statuses from which
+// client orders can be moved to delivery status
+var deliveryTransitions = map[string][]string{
+	enums.OrderStatusTypesEnum.Preparing: {
+		enums.OrderStatusTypesEnum.Approved,
+	},
+	enums.OrderStatusTypesEnum.OutForDelivery: {
+		enums.OrderStatusTypesEnum.Preparing,
+	},
+	enums.OrderStatusTypesEnum.Delivered: {
+		enums.OrderStatusTypesEnum.OutForDelivery,
+	},
+	enums.OrderStatusTypesEnum.Failed: {
+		enums.OrderStatusTypesEnum.Approved,
+		enums.OrderStatusTypesEnum.Preparing,
+		enums.OrderStatusTypesEnum.OutForDelivery,
+	},
+}
+
+// UpdateDeliveryStatus moves approved orders of client for date
+// through delivery statuses, note is required for failed delivery
+func (o *OrderService) UpdateDeliveryStatus(path url.PathClient, query url.DateQuery, body models.UpdateDeliveryStatus, user interface{}) (int, error) {
+	if _, err := time.Parse(time.RFC3339, query.Date); err != nil {
+		return http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	fromStatuses, ok := deliveryTransitions[body.Status]
+
+	if !ok {
+		return http.StatusBadRequest, errors.New("status must be preparing, out_for_delivery, delivered or failed")
+	}
+
+	if body.Status == enums.OrderStatusTypesEnum.Failed && body.Note == "" {
+		return http.StatusBadRequest, errors.New("note is required for failed delivery")
+	}
+
+	return orderRepo.UpdateDeliveryStatus(path.ID, path.ClientID, query.Date, fromStatuses,
+		body.Status, body.Note, user.(domain.User))
+}
+
+// OrderCutoff returns time until which orders for provided date
+// can be created, updated or canceled by users of client,
+// it's the end of the last working day before that date
+func (o *OrderService) OrderCutoff(clientID string, date time.Time) (time.Time, int, error) {
+	_, cutoff, code, err := o.orderWindow(clientID, date)
+
+	return cutoff, code, err
+}
+
+// orderWindow returns schedule of the last working day
+// before provided date, start of it is the time of day from which
+// orders for that date are accepted and end of it is the cutoff
+func (o *OrderService) orderWindow(clientID string, date time.Time) (time.Time, time.Time, int, error) {
+	schedules, code, err := clientScheduleRepo.GetWithCatering(clientID)
+
+	if err != nil {
+		return time.Time{}, time.Time{}, code, err
+	}
+
+	workingDays := make(map[int]models.ClientSchedulesCatering)
+
+	for _, schedule := range schedules {
+		if schedule.IsWorking && schedule.CateringIsWorking {
+			workingDays[schedule.Day] = schedule
+		}
+	}
+
+	if _, ok := workingDays[utils.GetDayOfWeek(date)]; !ok {
+		return time.Time{}, time.Time{}, http.StatusBadRequest, errors.New("client doesn't work on " + date.Format("2006-01-02"))
+	}
+
+	for i := 1; i <= 7; i++ {
+		day := date.AddDate(0, 0, -i)
+		schedule, ok := workingDays[utils.GetDayOfWeek(day)]
+
+		if !ok {
+			continue
+		}
+
+		startTime, err := time.Parse("15:04", schedule.Start)
+
+		if err != nil {
+			return time.Time{}, time.Time{}, http.StatusBadRequest, err
+		}
+
+		endTime, err := time.Parse("15:04", schedule.End)
+
+		if err != nil {
+			return time.Time{}, time.Time{}, http.StatusBadRequest, err
+		}
+
+		cutoff := time.Date(day.Year(), day.Month(), day.Day(), endTime.Hour(), endTime.Minute(), 0, 0, time.Local)
+
+		return startTime, cutoff, 0, nil
+	}
+
+	return time.Time{}, time.Time{}, http.StatusBadRequest, errors.New("client doesn't have working days")
+}
+
+// OrderingClosedError is returned when orders are changed
+// before the start of client's working hours, they can
+// be changed later the same day
+type OrderingClosedError struct {
+	Opens time.Time
+}
+
+func (e *OrderingClosedError) Error() string {
+	return "orders can't be changed before " + e.Opens.Format(time.RFC3339)
+}
+
+// DishesNotInMealError is returned when order contains dishes
+// which are not in the latest meal published for order date
+type DishesNotInMealError struct {
+	DishIDs []uuid.UUID
+}
+
+func (e *DishesNotInMealError) Error() string {
+	return "dishes are not in the menu for that day"
+}
+
+// validateOrder checks that orders for provided date
+// can still be changed by provided user and that
+// items are in the menu published for that date,
+// users without client can't order at all
+func (o *OrderService) validateOrder(userID string, date time.Time, items []models.Order) (int, error) {
+	user, err := userRepo.GetByID(userID)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if user.ClientID == nil {
+		return http.StatusForbidden, errors.New("user doesn't belong to any client")
+	}
+
+	start, cutoff, code, err := o.orderWindow(*user.ClientID, date)
+
+	if err != nil {
+		return code, err
+	}
+
+	now := time.Now()
+	opens := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.Local)
+
+	if now.Before(opens) {
+		return http.StatusBadRequest, &OrderingClosedError{Opens: opens}
+	}
+
+	if now.After(cutoff) {
+		return http.StatusBadRequest, fmt.Errorf("orders for %s can't be changed after %s",
+			date.Format("2006-01-02"), cutoff.Format(time.RFC3339))
+	}
+
+	if items == nil || user.CateringID == nil {
+		return 0, nil
+	}
+
+	return o.checkMealDishes(*user.CateringID, *user.ClientID, date, items)
+}
+
+// checkMealDishes returns DishesNotInMealError if some of items
+// are not in the latest meal version published for client on provided date
+func (o *OrderService) checkMealDishes(cateringID, clientID string, date time.Time, items []models.Order) (int, error) {
+	meals, code, err := mealRepo.Get(date, cateringID, clientID, url.LabelFilterQuery{})
+
+	if err != nil {
+		return code, err
+	}
+
+	if len(meals) == 0 {
+		return http.StatusBadRequest, errors.New("menu for " + date.Format("2006-01-02") + " isn't published yet")
+	}
+
+	mealDishes := make(map[uuid.UUID]bool)
+
+	for _, dish := range meals[0].Result {
+		mealDishes[dish.ID] = true
+	}
+
+	var invalidDishes []uuid.UUID
+
+	for _, item := range items {
+		if !mealDishes[item.DishID] {
+			invalidDishes = append(invalidDishes, item.DishID)
+		}
+	}
+
+	if len(invalidDishes) != 0 {
+		return http.StatusBadRequest, &DishesNotInMealError{DishIDs: invalidDishes}
+	}
+
+	return 0, nil
+}
+
+// validateOrderItems checks that order has dishes
+// with non zero amount and without duplicates
+func validateOrderItems(items []models.Order) error {
+	if len(items) == 0 {
+		return errors.New("order must contain at least one dish")
+	}
+
+	for i, dish := range items {
+		if dish.Amount <= 0 {
+			return errors.New("can't add dish with 0 amount")
+		}
+		for j := i + 1; j < len(items); j++ {
+			if dish.DishID == items[j].DishID {
+				return errors.New("can't add 2 same dishes, please increment amount field instead")
 			}
-			end := strconv.Itoa(1 + len(order.Items))
-			f.MergeCell("Sheet1", "A2", "A"+end)
-			f.MergeCell("Sheet1", "B2", "B"+end)
-			f.MergeCell("Sheet1", "D2", "D"+end)
-			f.MergeCell("Sheet1", "E2", "E"+end)
-			f.SetCellStyle("Sheet1", "A2", "A"+end, style)
-			f.SetCellStyle("Sheet1", "B2", "B"+end, style)
-			f.SetCellStyle("Sheet1", "D2", "D"+end, commentStyle)
-			f.SetCellStyle("Sheet1", "E2", "E"+end, style)
-		}
-	}
-
-	for index, order := range result.SummaryOrders {
-		var startLine int
-		for i, ord := range result.SummaryOrders {
-			if i < index {
-				startLine += len(ord.Items)
+		}
+	}
+
+	return nil
+}
+
+// isPreviousDate returns true if date is before today
+func isPreviousDate(date time.Time) bool {
+	return date.Sub(time.Now().Truncate(time.Hour*24)).Hours() < 0
+}
+
+// maxExportDays limits number of days in one export
+const maxExportDays = 31
+
+// ExportClientOrders returns file of orders of client for date or range of dates
+// with orders, summary and totals sheets in requested format
+func (o *OrderService) ExportClientOrders(path url.PathID, query url.OrderExportQuery) (ExportFile, int, error) {
+	if query.Date != "" {
+		query.From, query.To = query.Date, query.Date
+	}
+
+	from, err := time.Parse(time.RFC3339, query.From)
+
+	if err != nil {
+		return ExportFile{}, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	to, err := time.Parse(time.RFC3339, query.To)
+
+	if err != nil {
+		return ExportFile{}, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	if to.Before(from) {
+		return ExportFile{}, http.StatusBadRequest, errors.New("from date can't be after to date")
+	}
+
+	if to.Sub(from).Hours() >= maxExportDays*24 {
+		return ExportFile{}, http.StatusBadRequest, fmt.Errorf("can't export more than %d days at once", maxExportDays)
+	}
+
+	orders := ExportSheet{
+		Name: "Заказы",
+		Headers: []string{"Дата", "Имя", "Этаж", "Заказ", "Комментарий", "Сумма",
+			"Оплачивает компания", "Удерживается", "Статус", "Ккал", "Белки, г", "Жиры, г", "Углеводы, г"},
+	}
+	summary := ExportSheet{
+		Name:    "Сводка",
+		Headers: []string{"Дата", "Категория", "Блюдо", "Количество"},
+	}
+	totals := ExportSheet{
+		Name:    "Итого",
+		Headers: []string{"Дата", "Общая сумма заказов", "Оплачивает компания", "Удерживается с сотрудников"},
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		result, code, err := orderRepo.GetOrders("", path.ID, date.Format(time.RFC3339), enums.CompanyTypesEnum.Client)
+
+		if err != nil {
+			return ExportFile{}, code, err
+		}
+
+		day := date.Format("2006-01-02")
+
+		for _, order := range result.UserOrders {
+			dishes := make([]string, len(order.Items))
+			for i, dish := range order.Items {
+				dishes[i] = dish.Name + " " + strconv.Itoa(dish.Amount)
 			}
+
+			orders.Rows = append(orders.Rows, []interface{}{day, order.Name, order.Floor, strings.Join(dishes, ", "),
+				order.Comment, order.Total, order.CompanyShare, order.EmployeeShare, orderStatusName(order),
+				order.Nutrition.Kcal, order.Nutrition.Protein, order.Nutrition.Fat, order.Nutrition.Carbs})
 		}
-		if index > 0 {
-			start := startLine + 1
-			for idx, dish := range order.Items {
-				f.SetCellValue("Sheet1", "H"+strconv.Itoa(start+idx), dish.Name)
-				f.SetCellValue("Sheet1", "I"+strconv.Itoa(start+idx), dish.Amount)
-				f.SetCellStyle("Sheet1", "H"+strconv.Itoa(start+idx), "H"+strconv.Itoa(start+idx), style)
-				f.SetCellStyle("Sheet1", "I"+strconv.Itoa(start+idx), "I"+strconv.Itoa(start+idx), style)
+
+		for _, category := range result.SummaryOrders {
+			for _, dish := range category.Items {
+				summary.Rows = append(summary.Rows, []interface{}{day, category.CategorySummaryOrder.Name, dish.Name, dish.Amount})
 			}
-			st := strconv.Itoa(start)
-			end := strconv.Itoa(start + len(order.Items) - 1)
-			f.SetCellValue("Sheet1", "G"+st, order.CategorySummaryOrder.Name)
-			f.MergeCell("Sheet1", "G"+st, "G"+end)
-			f.SetCellStyle("Sheet1", "G"+st, "G"+end, style)
-		} else {
-			for idx, dish := range order.Items {
-				f.SetCellValue("Sheet1", "H"+strconv.Itoa(1+idx), dish.Name)
-				f.SetCellValue("Sheet1", "I"+strconv.Itoa(1+idx), dish.Amount)
-				f.SetCellStyle("Sheet1", "H"+strconv.Itoa(1+idx), "H"+strconv.Itoa(1+idx), style)
-				f.SetCellStyle("Sheet1", "I"+strconv.Itoa(1+idx), "I"+strconv.Itoa(1+idx), style)
+		}
+
+		if len(result.UserOrders) != 0 {
+			totals.Rows = append(totals.Rows, []interface{}{day, result.Total, result.CompanyTotal, result.EmployeeTotal})
+		}
+	}
+
+	name := "orders_" + from.Format("2006-01-02") + "_" + to.Format("2006-01-02")
+
+	return newExportFile(name, query.Format, []ExportSheet{orders, summary, totals})
+}
+
+// GetProduction returns production sheet of catering for provided date
+func (o *OrderService) GetProduction(path url.PathID, query url.DateQuery) (models.ProductionSheet, int, error) {
+	date, err := time.Parse(time.RFC3339, query.Date)
+
+	if err != nil {
+		return models.ProductionSheet{}, http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	return orderRepo.GetProduction(path.ID, date)
+}
+
+// SubscribeOrders returns subscription to live order board
+// of catering or client, caller has to close it
+func (o *OrderService) SubscribeOrders(companyType string, path url.PathID) (*events.Subscription, int, error) {
+	if companyType == enums.CompanyTypesEnum.Catering {
+		catering, err := cateringRepo.GetByKey("id", path.ID)
+
+		if err != nil {
+			return nil, http.StatusNotFound, errors.New("catering not found")
+		}
+
+		return events.Orders.Subscribe(catering.ID, uuid.Nil), 0, nil
+	}
+
+	client, err := clientRepo.GetByKey("id", path.ID)
+
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("client not found")
+	}
+
+	return events.Orders.Subscribe(uuid.Nil, client.ID), 0, nil
+}
+
+// ExportProduction returns file of production sheet
+// with summary, per client and delivery sheets in requested format
+func (o *OrderService) ExportProduction(path url.PathID, query url.DateQuery, file url.FileFormatQuery) (ExportFile, int, error) {
+	result, code, err := o.GetProduction(path, query)
+
+	if err != nil {
+		return ExportFile{}, code, err
+	}
+
+	summary := ExportSheet{
+		Name:    "Сводка",
+		Headers: []string{"Категория", "Блюдо", "Порций"},
+	}
+	byClient := ExportSheet{
+		Name:    "По клиентам",
+		Headers: []string{"Клиент", "Категория", "Блюдо", "Порций"},
+	}
+	delivery := ExportSheet{
+		Name:    "Доставка",
+		Headers: []string{"Клиент", "Адрес", "Этаж", "Заказов", "Порций"},
+	}
+
+	for _, category := range result.Categories {
+		for _, dish := range category.Dishes {
+			summary.Rows = append(summary.Rows, []interface{}{category.CategoryName, dish.Name, dish.Amount})
+
+			for _, client := range dish.Clients {
+				byClient.Rows = append(byClient.Rows, []interface{}{client.ClientName, category.CategoryName, dish.Name, client.Amount})
 			}
-			end := strconv.Itoa(len(order.Items))
-			f.SetCellValue("Sheet1", "G1", order.CategorySummaryOrder.Name)
-			f.MergeCell("Sheet1", "G1", "G"+end)
-			f.SetCellStyle("Sheet1", "G1", "G"+end, style)
 		}
 	}
+	summary.Rows = append(summary.Rows, []interface{}{"", "Всего порций", result.Portions})
 
-	fileName := "order_" + time.Now().Format("2006-01-02") + ".xlsx"
-	pathDir := filepath.Join(dir, "static", fileName)
+	for _, client := range result.Clients {
+		for _, floor := range client.Floors {
+			delivery.Rows = append(delivery.Rows, []interface{}{client.ClientName,
+				utils.DerefString(floor.Address), floor.Floor, floor.Orders, floor.Portions})
+		}
+	}
+
+	name := "production_" + result.Date.Format("2006-01-02")
 
-	// nolint:errcheck
-	f.SaveAs(pathDir)
+	return newExportFile(name, file.Format, []ExportSheet{summary, byClient, delivery})
+}
 
-	return pathDir, code, err
+// orderStatusName returns status of order for export file
+func orderStatusName(order models.SummaryUserOrder) string {
+	switch order.Status {
+	case enums.OrderStatusTypesEnum.Approved:
+		return "Подтвержден"
+	case enums.OrderStatusTypesEnum.Rejected:
+		return "Отклонен: " + utils.DerefString(order.Reason)
+	case enums.OrderStatusTypesEnum.Preparing:
+		return "Готовится"
+	case enums.OrderStatusTypesEnum.OutForDelivery:
+		return "Доставляется"
+	case enums.OrderStatusTypesEnum.Delivered:
+		return "Доставлен"
+	case enums.OrderStatusTypesEnum.Failed:
+		return "Не доставлен: " + utils.DerefString(order.Reason)
+	default:
+		return "Ожидает подтверждения"
+	}
 }
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/config"
+	"github.com/Aiscom-LLC/meals-api/mailer"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+)
+
+// ReminderService struct
+type ReminderService struct{}
+
+// NewReminderService return pointer to reminder struct
+// with all methods
+func NewReminderService() *ReminderService {
+	return &ReminderService{}
+}
+
+var reminderRepo = repository.NewReminderRepo()
+
+// maxReminderOffset limits offset of reminders to one day
+const maxReminderOffset = 24 * 60
+
+// Get returns reminder settings of client
+func (r *ReminderService) Get(path url.PathID) (models.ClientReminder, int, error) {
+	return reminderRepo.Get(path.ID)
+}
+
+// Update enables or disables reminders of client,
+// current offset is kept if new one isn't provided
+func (r *ReminderService) Update(path url.PathID, body models.UpdateReminder) (models.ClientReminder, int, error) {
+	reminder, code, err := reminderRepo.Get(path.ID)
+
+	if err != nil {
+		return models.ClientReminder{}, code, err
+	}
+
+	if body.Offset == 0 {
+		body.Offset = reminder.Offset
+	}
+
+	if body.Offset < 1 || body.Offset > maxReminderOffset {
+		return models.ClientReminder{}, http.StatusBadRequest,
+			fmt.Errorf("offset must be between 1 and %d minutes", maxReminderOffset)
+	}
+
+	return reminderRepo.Update(path.ID, *body.Enabled, body.Offset)
+}
+
+// Preview returns users who would be reminded about the next
+// working day of client, nothing is sent
+func (r *ReminderService) Preview(path url.PathID) (models.ReminderPreview, int, error) {
+	reminder, code, err := reminderRepo.Get(path.ID)
+
+	if err != nil {
+		return models.ReminderPreview{}, code, err
+	}
+
+	date, cutoff, code, err := nextOrderDate(path.ID, time.Now())
+
+	if err != nil {
+		return models.ReminderPreview{}, code, err
+	}
+
+	recipients, code, err := reminderRepo.GetRecipients(path.ID, date)
+
+	if err != nil {
+		return models.ReminderPreview{}, code, err
+	}
+
+	return models.ReminderPreview{
+		Enabled:    reminder.Enabled,
+		Date:       date,
+		Cutoff:     cutoff,
+		SendAt:     cutoff.Add(-time.Duration(reminder.Offset) * time.Minute),
+		Recipients: recipients,
+	}, 0, nil
+}
+
+// GetUser returns whether user receives reminders
+func (r *ReminderService) GetUser(path url.PathID) (models.UserReminder, int, error) {
+	return reminderRepo.GetUserReminder(path.ID)
+}
+
+// UpdateUser opts user in or out of reminders
+func (r *ReminderService) UpdateUser(path url.PathID, body models.UpdateUserReminder) (models.UserReminder, int, error) {
+	return reminderRepo.UpdateUserReminder(path.ID, *body.Enabled)
+}
+
+// SendReminders emails users without order for the next working day
+// of client, when offset of client before cutoff of orders is reached,
+// is run by cron every minute, users whose reminder couldn't be sent
+// are released and retried on the next run until cutoff
+func (r *ReminderService) SendReminders() {
+	reminders, err := reminderRepo.GetEnabled()
+
+	if err != nil {
+		log.Printf("reminders: can't get enabled reminders: %v", err)
+		return
+	}
+
+	now := time.Now()
+
+	for _, reminder := range reminders {
+		clientID := reminder.ClientID.String()
+		date, cutoff, _, err := nextOrderDate(clientID, now)
+
+		if err != nil || now.Before(cutoff.Add(-time.Duration(reminder.Offset)*time.Minute)) {
+			continue
+		}
+
+		recipients, _, err := reminderRepo.GetRecipients(clientID, date)
+
+		if err != nil {
+			log.Printf("reminders of client %s: can't get recipients for %s: %v", clientID, date.Format("2006-01-02"), err)
+			continue
+		}
+
+		sent := 0
+
+		for _, recipient := range recipients {
+			if r.send(recipient, date, cutoff) {
+				sent++
+			}
+		}
+
+		if sent == 0 {
+			continue
+		}
+
+		if err := reminderRepo.SetLastDate(reminder.ClientID, date); err != nil {
+			log.Printf("reminders of client %s: can't save last date %s: %v", clientID, date.Format("2006-01-02"), err)
+		}
+	}
+}
+
+// send claims recipient for date and emails reminder,
+// claim is released if reminder couldn't be sent
+func (r *ReminderService) send(recipient models.ReminderRecipient, date, cutoff time.Time) bool {
+	claimed, err := reminderRepo.Claim(recipient.UserID, date)
+
+	if err != nil {
+		log.Printf("reminder of user %s: can't claim %s: %v", recipient.UserID, date.Format("2006-01-02"), err)
+		return false
+	}
+
+	if !claimed {
+		return false
+	}
+
+	if err := mailer.SendOrderReminder(recipient.FirstName, recipient.Email, date, cutoff, config.Env.ClientURL); err != nil {
+		log.Printf("reminder of user %s: can't send to %s: %v", recipient.UserID, recipient.Email, err)
+
+		if err := reminderRepo.Release(recipient.UserID, date); err != nil {
+			log.Printf("reminder of user %s: can't release %s: %v", recipient.UserID, date.Format("2006-01-02"), err)
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// nextOrderDate returns the nearest working day of client
+// which orders are still accepted for, with its cutoff
+func nextOrderDate(clientID string, now time.Time) (time.Time, time.Time, int, error) {
+	today := now.UTC().Truncate(time.Hour * 24)
+
+	for i := 1; i <= 7; i++ {
+		date := today.AddDate(0, 0, i)
+		cutoff, code, err := orderService.OrderCutoff(clientID, date)
+
+		if code == http.StatusNotFound {
+			return time.Time{}, time.Time{}, code, err
+		}
+
+		if err == nil && cutoff.After(now) {
+			return date, cutoff, 0, nil
+		}
+	}
+
+	return time.Time{}, time.Time{}, http.StatusBadRequest, errors.New("client doesn't have working days")
+}
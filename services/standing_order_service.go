@@ -0,0 +1,319 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/lib/pq"
+	uuid "github.com/satori/go.uuid"
+)
+
+// StandingOrderService struct
+type StandingOrderService struct{}
+
+// NewStandingOrderService return pointer to standing order struct
+// with all methods
+func NewStandingOrderService() *StandingOrderService {
+	return &StandingOrderService{}
+}
+
+var standingOrderRepo = repository.NewStandingOrderRepo()
+var categoryRepo = repository.NewCategoryRepo()
+var orderService = NewOrderService()
+
+// standingOrderDays is number of days ahead
+// for which standing orders are placed
+const standingOrderDays = 7
+
+// Add creates standing order for provided user
+func (s *StandingOrderService) Add(path url.PathID, body models.StandingOrderRequest) (models.StandingOrder, int, error) {
+	standingOrder, items, code, err := s.validate(path.ID, "", body)
+
+	if err != nil {
+		return models.StandingOrder{}, code, err
+	}
+
+	return standingOrderRepo.Add(standingOrder, items)
+}
+
+// Get returns standing orders of provided user
+func (s *StandingOrderService) Get(path url.PathID) ([]models.StandingOrder, int, error) {
+	return standingOrderRepo.Get(path.ID)
+}
+
+// Update replaces settings and items of standing order,
+// standing order is paused or resumed with paused field
+func (s *StandingOrderService) Update(path url.PathStandingOrder, body models.StandingOrderRequest) (models.StandingOrder, int, error) {
+	standingOrder, items, code, err := s.validate(path.ID, path.StandingOrderID, body)
+
+	if err != nil {
+		return models.StandingOrder{}, code, err
+	}
+
+	return standingOrderRepo.Update(path.ID, path.StandingOrderID, standingOrder, items)
+}
+
+// Delete removes standing order, orders
+// which are already placed aren't changed
+func (s *StandingOrderService) Delete(path url.PathStandingOrder) (int, error) {
+	return standingOrderRepo.Delete(path.ID, path.StandingOrderID)
+}
+
+// AddSkip skips single date of standing order
+func (s *StandingOrderService) AddSkip(path url.PathStandingOrder, query url.DateQuery) (int, error) {
+	date, err := time.Parse(time.RFC3339, query.Date)
+
+	if err != nil {
+		return http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	if isPreviousDate(date) {
+		return http.StatusBadRequest, errors.New("can't skip previous date")
+	}
+
+	return standingOrderRepo.AddSkip(path.ID, path.StandingOrderID, date)
+}
+
+// DeleteSkip cancels skip of single date of standing order
+func (s *StandingOrderService) DeleteSkip(path url.PathStandingOrder, query url.DateQuery) (int, error) {
+	date, err := time.Parse(time.RFC3339, query.Date)
+
+	if err != nil {
+		return http.StatusBadRequest, errors.New("can't parse the date")
+	}
+
+	return standingOrderRepo.DeleteSkip(path.ID, path.StandingOrderID, date)
+}
+
+// validate checks standing order request and returns
+// standing order with items ready to be saved
+func (s *StandingOrderService) validate(userID, standingOrderID string, body models.StandingOrderRequest) (domain.StandingOrder, []domain.StandingOrderItem, int, error) {
+	user, err := userRepo.GetByID(userID)
+
+	if err != nil {
+		return domain.StandingOrder{}, nil, http.StatusBadRequest, err
+	}
+
+	if user.ClientID == nil || user.CateringID == nil {
+		return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("user doesn't belong to any client")
+	}
+
+	if len(body.Weekdays) == 0 {
+		return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("standing order must contain at least one weekday")
+	}
+
+	weekdays := make(pq.Int64Array, 0, len(body.Weekdays))
+	usedWeekdays := make(map[int]bool)
+
+	for _, day := range body.Weekdays {
+		if day < 0 || day > 6 {
+			return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("weekday must be between 0 and 6")
+		}
+		if usedWeekdays[day] {
+			return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("can't add 2 same weekdays")
+		}
+		usedWeekdays[day] = true
+		weekdays = append(weekdays, int64(day))
+	}
+
+	standingOrders, code, err := standingOrderRepo.Get(userID)
+
+	if err != nil {
+		return domain.StandingOrder{}, nil, code, err
+	}
+
+	for _, standingOrder := range standingOrders {
+		if standingOrder.ID.String() == standingOrderID {
+			continue
+		}
+		for _, day := range standingOrder.Weekdays {
+			if usedWeekdays[int(day)] {
+				return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("another standing order already covers that weekday")
+			}
+		}
+	}
+
+	if body.EndDate != nil && isPreviousDate(*body.EndDate) {
+		return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("end date can't be previous date")
+	}
+
+	if len(body.Items) == 0 {
+		return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("standing order must contain at least one item")
+	}
+
+	var items []domain.StandingOrderItem
+	usedItems := make(map[uuid.UUID]bool)
+
+	for _, item := range body.Items {
+		if item.Amount <= 0 {
+			return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("can't add item with 0 amount")
+		}
+
+		if (item.DishID == nil) == (item.CategoryID == nil) {
+			return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("item must contain either dish or category")
+		}
+
+		var itemID uuid.UUID
+
+		if item.DishID != nil {
+			itemID = *item.DishID
+			if _, code, err := dishRepo.FindByID(*user.CateringID, itemID.String()); err != nil {
+				return domain.StandingOrder{}, nil, code, err
+			}
+		} else {
+			itemID = *item.CategoryID
+			if _, err := categoryRepo.GetByKey("id", itemID.String(), *user.CateringID); err != nil {
+				return domain.StandingOrder{}, nil, http.StatusNotFound, errors.New("category with that id not found")
+			}
+		}
+
+		if usedItems[itemID] {
+			return domain.StandingOrder{}, nil, http.StatusBadRequest, errors.New("can't add 2 same items, please increment amount field instead")
+		}
+		usedItems[itemID] = true
+
+		items = append(items, domain.StandingOrderItem{
+			DishID:     item.DishID,
+			CategoryID: item.CategoryID,
+			Amount:     item.Amount,
+		})
+	}
+
+	parsedUserID, _ := uuid.FromString(userID)
+
+	return domain.StandingOrder{
+		UserID:   parsedUserID,
+		Weekdays: weekdays,
+		EndDate:  body.EndDate,
+		Paused:   body.Paused,
+		Comment:  body.Comment,
+	}, items, 0, nil
+}
+
+// PlaceOrders places orders of standing orders for the next days
+// which menu is already published, days which menu isn't published
+// yet are retried on the next run, days which can't be ordered
+// are recorded with the reason and aren't retried
+func (s *StandingOrderService) PlaceOrders() {
+	today := time.Now().UTC().Truncate(time.Hour * 24)
+
+	for i := 1; i <= standingOrderDays; i++ {
+		date := today.AddDate(0, 0, i)
+		standingOrders, err := standingOrderRepo.GetToPlace(date)
+
+		if err != nil {
+			log.Printf("standing orders: can't get standing orders for %s: %v", date.Format("2006-01-02"), err)
+			continue
+		}
+
+		for _, standingOrder := range standingOrders {
+			s.place(standingOrder, date)
+		}
+	}
+}
+
+// place creates order of standing order for provided date
+func (s *StandingOrderService) place(standingOrder models.StandingOrder, date time.Time) {
+	userID := standingOrder.UserID.String()
+	user, err := userRepo.GetByID(userID)
+
+	if err != nil {
+		log.Printf("standing order %s: can't get user: %v", standingOrder.ID, err)
+		return
+	}
+
+	if user.ClientID == nil || user.CateringID == nil {
+		s.fail(standingOrder, date, "user doesn't belong to any client")
+		return
+	}
+
+	// user already ordered that day, so standing order isn't needed
+	if _, _, err := orderRepo.GetUserOrder(userID, date.Format(time.RFC3339)); err == nil {
+		if err := standingOrderRepo.AddPlacement(standingOrder.ID, date, nil); err != nil {
+			log.Printf("standing order %s: can't save placement for %s: %v", standingOrder.ID, date.Format("2006-01-02"), err)
+		}
+		return
+	}
+
+	meals, _, err := mealRepo.Get(date, *user.CateringID, *user.ClientID, url.LabelFilterQuery{})
+
+	if err != nil {
+		log.Printf("standing order %s: can't get menu for %s: %v", standingOrder.ID, date.Format("2006-01-02"), err)
+		return
+	}
+
+	// menu isn't published yet, date is retried on the next run
+	if len(meals) == 0 {
+		return
+	}
+
+	items := standingOrderItems(standingOrder.Items, meals[0].Result)
+
+	if len(items) == 0 {
+		s.fail(standingOrder, date, "no dishes of standing order in published menu")
+		return
+	}
+
+	var closedErr *OrderingClosedError
+
+	userOrder, _, err := orderService.AddForUser(userID, date, models.OrderRequest{
+		Items:   items,
+		Comment: standingOrder.Comment,
+	})
+
+	// working hours of client haven't started yet, date is retried on the next run
+	if errors.As(err, &closedErr) {
+		return
+	}
+
+	if err != nil {
+		s.fail(standingOrder, date, err.Error())
+		return
+	}
+
+	if err := standingOrderRepo.AddPlacement(standingOrder.ID, date, &userOrder.OrderID); err != nil {
+		log.Printf("standing order %s: can't save placement for %s: %v", standingOrder.ID, date.Format("2006-01-02"), err)
+	}
+}
+
+// fail logs why order of standing order wasn't placed and records
+// the reason, so the date isn't retried and user can see it
+func (s *StandingOrderService) fail(standingOrder models.StandingOrder, date time.Time, reason string) {
+	log.Printf("standing order %s: can't place order for %s: %s", standingOrder.ID, date.Format("2006-01-02"), reason)
+
+	if err := standingOrderRepo.AddFailedPlacement(standingOrder.ID, date, reason); err != nil {
+		log.Printf("standing order %s: can't save failed placement for %s: %v", standingOrder.ID, date.Format("2006-01-02"), err)
+	}
+}
+
+// standingOrderItems picks dishes of published meal for standing order items,
+// for category the first dish of it which isn't sold out is picked
+func standingOrderItems(items []domain.StandingOrderItem, mealDishes []models.MealDish) []models.Order {
+	var orderItems []models.Order
+	picked := make(map[uuid.UUID]bool)
+
+	for _, item := range items {
+		for _, dish := range mealDishes {
+			if dish.SoldOut || picked[dish.ID] {
+				continue
+			}
+			if (item.DishID != nil && *item.DishID == dish.ID) ||
+				(item.CategoryID != nil && *item.CategoryID == dish.CategoryID) {
+				picked[dish.ID] = true
+				orderItems = append(orderItems, models.Order{
+					DishID: dish.ID,
+					Amount: item.Amount,
+				})
+				break
+			}
+		}
+	}
+
+	return orderItems
+}
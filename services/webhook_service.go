@@ -0,0 +1,256 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"sync"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api/url"
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/Aiscom-LLC/meals-api/repository/models"
+	"github.com/lib/pq"
+)
+
+// WebhookService struct
+type WebhookService struct{}
+
+// NewWebhookService return pointer to webhook struct
+// with all methods
+func NewWebhookService() *WebhookService {
+	return &WebhookService{}
+}
+
+var webhookRepo = repository.NewWebhookRepo()
+
+// webhookClient sends deliveries, slow endpoint
+// doesn't block sending of other deliveries for long
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// maxWebhookAttempts limits number of attempts to send delivery,
+// attempts are made 1, 2, 4, 8 and 16 minutes after the failed one
+const maxWebhookAttempts = 6
+
+// webhookDeliveriesLimit limits number of deliveries sent by one run of cron
+const webhookDeliveriesLimit = 100
+
+// webhookWorkers limits number of deliveries sent at the same time,
+// with timeout of client all deliveries taken by one run
+// are sent in 100 seconds, before their lease is over
+const webhookWorkers = 10
+
+// minWebhookSecret is minimal length of secret of webhook
+const minWebhookSecret = 16
+
+// webhookEvents are events which webhook can be subscribed to
+var webhookEvents = []string{
+	enums.WebhookEventsEnum.OrderCreated,
+	enums.WebhookEventsEnum.OrderUpdated,
+	enums.WebhookEventsEnum.OrderCanceled,
+	enums.WebhookEventsEnum.OrderApproved,
+	enums.WebhookEventsEnum.OrderDelivered,
+	enums.WebhookEventsEnum.MealPublished,
+}
+
+// Add creates webhook of catering or client
+func (w *WebhookService) Add(companyType string, path url.PathID, body models.WebhookRequest) (models.Webhook, int, error) {
+	if body.Secret == "" {
+		return models.Webhook{}, http.StatusBadRequest, errors.New("secret is required")
+	}
+
+	webhook, err := validateWebhook(body)
+
+	if err != nil {
+		return models.Webhook{}, http.StatusBadRequest, err
+	}
+
+	return webhookRepo.Add(companyType, path.ID, webhook)
+}
+
+// Get returns webhooks of catering or client
+func (w *WebhookService) Get(companyType string, path url.PathID) ([]models.Webhook, int, error) {
+	return webhookRepo.Get(companyType, path.ID)
+}
+
+// Update replaces webhook of catering or client
+func (w *WebhookService) Update(companyType string, path url.PathWebhook, body models.WebhookRequest) (models.Webhook, int, error) {
+	webhook, err := validateWebhook(body)
+
+	if err != nil {
+		return models.Webhook{}, http.StatusBadRequest, err
+	}
+
+	return webhookRepo.Update(companyType, path.ID, path.WebhookID, webhook)
+}
+
+// Delete removes webhook of catering or client
+func (w *WebhookService) Delete(companyType string, path url.PathWebhook) (int, error) {
+	return webhookRepo.Delete(companyType, path.ID, path.WebhookID)
+}
+
+// GetDeliveries returns page of deliveries of webhook
+func (w *WebhookService) GetDeliveries(companyType string, path url.PathWebhook, query url.PaginationQuery) (models.WebhookDeliveries, int, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	return webhookRepo.GetDeliveries(companyType, path.ID, path.WebhookID, query)
+}
+
+// Redeliver sends delivery of webhook again right away
+// and returns its result, failed delivery is retried as new one
+func (w *WebhookService) Redeliver(companyType string, path url.PathWebhookDelivery) (models.WebhookDelivery, int, error) {
+	target, code, err := webhookRepo.Redeliver(companyType, path.ID, path.WebhookID, path.DeliveryID)
+
+	if err != nil {
+		return models.WebhookDelivery{}, code, err
+	}
+
+	if err := deliver(target); err != nil {
+		return models.WebhookDelivery{}, http.StatusBadRequest, err
+	}
+
+	return webhookRepo.GetDelivery(path.WebhookID, path.DeliveryID)
+}
+
+// SendDeliveries sends pending deliveries which next attempt time
+// has come, is run by cron every minute
+func (w *WebhookService) SendDeliveries() {
+	targets, err := webhookRepo.TakeDue(webhookDeliveriesLimit)
+
+	if err != nil {
+		log.Printf("webhooks: can't take due deliveries: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, webhookWorkers)
+
+	for _, target := range targets {
+		wg.Add(1)
+		workers <- struct{}{}
+
+		go func(target models.WebhookDeliveryTarget) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			if err := deliver(target); err != nil {
+				log.Printf("webhook delivery %s: can't save attempt: %v", target.ID, err)
+			}
+		}(target)
+	}
+
+	wg.Wait()
+}
+
+// validateWebhook checks webhook request and returns
+// webhook ready to be saved, webhook is active by default
+func validateWebhook(body models.WebhookRequest) (domain.Webhook, error) {
+	parsedURL, err := neturl.Parse(body.URL)
+
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return domain.Webhook{}, errors.New("url must be absolute http or https url")
+	}
+
+	if body.Secret != "" && len(body.Secret) < minWebhookSecret {
+		return domain.Webhook{}, fmt.Errorf("secret must be at least %d characters", minWebhookSecret)
+	}
+
+	if len(body.Events) == 0 {
+		return domain.Webhook{}, errors.New("webhook must contain at least one event")
+	}
+
+	usedEvents := make(map[string]bool)
+
+	for _, event := range body.Events {
+		if !containsString(webhookEvents, event) {
+			return domain.Webhook{}, fmt.Errorf("event %s doesn't exist", event)
+		}
+		if usedEvents[event] {
+			return domain.Webhook{}, errors.New("can't add 2 same events")
+		}
+		usedEvents[event] = true
+	}
+
+	return domain.Webhook{
+		URL:    body.URL,
+		Secret: body.Secret,
+		Events: pq.StringArray(body.Events),
+		Active: body.Active == nil || *body.Active,
+	}, nil
+}
+
+// deliver sends delivery and saves result of attempt,
+// failed delivery is retried with exponential backoff
+func deliver(target models.WebhookDeliveryTarget) error {
+	attempts := target.Attempts + 1
+	responseCode, err := sendDelivery(target)
+
+	if err == nil {
+		return webhookRepo.SaveAttempt(target.ID, enums.WebhookDeliveryStatusTypesEnum.Delivered,
+			attempts, responseCode, nil, nil)
+	}
+
+	errMessage := err.Error()
+
+	if attempts >= maxWebhookAttempts {
+		return webhookRepo.SaveAttempt(target.ID, enums.WebhookDeliveryStatusTypesEnum.Failed,
+			attempts, responseCode, &errMessage, nil)
+	}
+
+	nextAttemptAt := time.Now().UTC().Add(time.Minute << (attempts - 1))
+
+	return webhookRepo.SaveAttempt(target.ID, enums.WebhookDeliveryStatusTypesEnum.Pending,
+		attempts, responseCode, &errMessage, &nextAttemptAt)
+}
+
+// sendDelivery posts signed payload of delivery to webhook,
+// any response other than 2xx is failed attempt
+func sendDelivery(target models.WebhookDeliveryTarget) (*int, error) {
+	payload := []byte(target.Payload)
+	request, err := http.NewRequest(http.MethodPost, target.URL, bytes.NewReader(payload))
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Webhook-Event", target.Event)
+	request.Header.Set("X-Webhook-Delivery", target.ID.String())
+	request.Header.Set("X-Webhook-Signature", WebhookSignature(target.Secret, payload))
+
+	response, err := webhookClient.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return &response.StatusCode, fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return &response.StatusCode, nil
+}
+
+// WebhookSignature returns HMAC-SHA256 signature of payload
+// sent in X-Webhook-Signature header, receiver checks it with its secret
+func WebhookSignature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/go-playground/assert/v2"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestAudit(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	autoApprove := clientResult.AutoApproveOrders
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	userID := user.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	fakeID := uuid.NewV4().String()
+
+	// Trying to change auto approve of client
+	// Should be success
+	r.PUT("/clients/"+clientID+"/auto-approve").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"status": !autoApprove,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to get audit of client filtered by entity and actor
+	// Should return change of auto approve made by super admin
+	r.GET("/clients/"+clientID+"/audit?entity=client&actorId="+userID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			actorID, _ := jsonparser.GetString(data, "items", "[0]", "actorId")
+			entityID, _ := jsonparser.GetString(data, "items", "[0]", "entityId")
+			action, _ := jsonparser.GetString(data, "items", "[0]", "action")
+			before, _ := jsonparser.GetBoolean(data, "items", "[0]", "before", "autoApproveOrders")
+			after, _ := jsonparser.GetBoolean(data, "items", "[0]", "after", "autoApproveOrders")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, userID, actorID)
+			assert.Equal(t, clientID, entityID)
+			assert.Equal(t, "update", action)
+			assert.Equal(t, autoApprove, before)
+			assert.Equal(t, !autoApprove, after)
+		})
+
+	// Trying to get audit of catering
+	// Should return events of its clients too
+	r.GET("/caterings/"+cateringID+"/audit?entity=client&entityId="+clientID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			entityID, _ := jsonparser.GetString(data, "items", "[0]", "entityId")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, clientID, entityID)
+		})
+
+	// Trying to get audit with date range in the past
+	// Should return empty list
+	r.GET("/clients/"+clientID+"/audit?from=2000-01-01T00:00:00Z&to=2000-01-02T00:00:00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			total, _ := jsonparser.GetInt(data, "total")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, int64(0), total)
+		})
+
+	// Trying to get audit with invalid date
+	// Should throw error
+	r.GET("/caterings/"+cateringID+"/audit?from=yesterday").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "can't parse the date", errorValue)
+		})
+
+	// Trying to get audit of non-existing client
+	// Should throw error
+	r.GET("/clients/"+fakeID+"/audit").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "client not found", errorValue)
+		})
+
+	// Restoring auto approve of client
+	r.PUT("/clients/"+clientID+"/auto-approve").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"status": autoApprove,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+}
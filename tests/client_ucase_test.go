@@ -214,3 +214,203 @@ func TestUpdateClient(t *testing.T) {
 			assert.Equal(t, http.StatusNotFound, r.Code)
 		})
 }
+
+func TestUpdateClientSubsidy(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	result, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: result.ID.String()})
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	var clientID string
+
+	// Create new client
+	r.POST("/caterings/"+cateringID+"/clients").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name": "subsidyclient",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			clientID, _ = jsonparser.GetString(data, "id")
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Trying to get subsidy of client without subsidy
+	// Should return full subsidy
+	r.GET("/clients/"+clientID+"/subsidy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			subsidyType, _ := jsonparser.GetString(data, "type")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "full", subsidyType)
+		})
+
+	// Trying to set subsidy with invalid type
+	// Should throw an error
+	r.PUT("/clients/"+clientID+"/subsidy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"type": "qwerty",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "type must be full, fixed, percent or category", errorValue)
+		})
+
+	// Trying to set percent subsidy more than 100
+	// Should throw an error
+	r.PUT("/clients/"+clientID+"/subsidy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"type":   "percent",
+			"amount": 120,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+
+	// Trying to set category subsidy without categories
+	// Should throw an error
+	r.PUT("/clients/"+clientID+"/subsidy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"type": "category",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+
+	// Trying to set fixed subsidy
+	// Should be success
+	r.PUT("/clients/"+clientID+"/subsidy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"type":   "fixed",
+			"amount": 300,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			subsidyType, _ := jsonparser.GetString(data, "type")
+			amount, _ := jsonparser.GetFloat(data, "amount")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "fixed", subsidyType)
+			assert.Equal(t, float64(300), amount)
+		})
+
+	// Trying to set subsidy of non-exist client
+	// Should throw an error
+	r.PUT("/clients/"+uuid.NewV4().String()+"/subsidy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"type": "full",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		})
+}
+
+func TestUpdateClientBudget(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	result, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: result.ID.String()})
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	var clientID string
+
+	// Create new client
+	r.POST("/caterings/"+cateringID+"/clients").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name": "budgetclient",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			clientID, _ = jsonparser.GetString(data, "id")
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Trying to get budget of client without budget
+	// Should return nothing spent
+	r.GET("/clients/"+clientID+"/budget").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			spent, _ := jsonparser.GetFloat(data, "spent")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, float64(0), spent)
+		})
+
+	// Trying to set negative budget
+	// Should throw an error
+	r.PUT("/clients/"+clientID+"/budget").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"monthlyBudget": -100,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "budget can't be negative", errorValue)
+		})
+
+	// Trying to set budget with user daily limit
+	// Should be success
+	r.PUT("/clients/"+clientID+"/budget").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"monthlyBudget":  5000,
+			"userDailyLimit": 200,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			remaining, _ := jsonparser.GetFloat(data, "remaining")
+			dailyLimit, _ := jsonparser.GetFloat(data, "userDailyLimit")
+			_, monthlyLimitType, _, _ := jsonparser.Get(data, "userMonthlyLimit")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, float64(5000), remaining)
+			assert.Equal(t, float64(200), dailyLimit)
+			assert.Equal(t, jsonparser.Null, monthlyLimitType)
+		})
+
+	// Trying to get budget of non-exist client
+	// Should throw an error
+	r.GET("/clients/"+uuid.NewV4().String()+"/budget").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		})
+}
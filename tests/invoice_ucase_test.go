@@ -0,0 +1,245 @@
+package tests
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoice(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	clientUser, _ := userRepo.GetByKey("email", "user1@meals.com")
+	clientUserID := clientUser.ID.String()
+	clientUserJwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: clientUserID})
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	invoicesURL := "/caterings/" + cateringID + "/clients/" + clientID + "/invoices"
+	var invoiceID string
+	var dishID string
+	var orderID string
+	var companyShare float64
+	var employeeShare float64
+
+	// Create dish, publish menu with it and approve order of client user,
+	// so invoice for period has items
+	r.POST("/caterings/"+cateringID+"/dishes").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       "манты",
+			"weight":     250,
+			"price":      150,
+			"categoryId": categoryResult.ID.String(),
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			dishID, _ = jsonparser.GetString(data, "id")
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-12-09T00:00:00Z",
+			"dishes": []string{dishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	r.POST("/users/"+clientUserID+"/orders?date=2121-12-09T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"items": []gofight.D{{"dishId": dishID, "amount": 2}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			orderID, _ = jsonparser.GetString(data, "orderId")
+			companyShare, _ = jsonparser.GetFloat(data, "companyShare")
+			employeeShare, _ = jsonparser.GetFloat(data, "employeeShare")
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	r.PUT("/clients/"+clientID+"/orders/status").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"orderIds": []string{orderID},
+			"status":   "approved",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to create invoice for month
+	// Should be success and contain approved order
+	r.POST(invoicesURL).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"from": "2121-12-01T00:00:00Z",
+			"to":   "2121-12-31T00:00:00Z",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			invoiceID, _ = jsonparser.GetString(data, "id")
+			status, _ := jsonparser.GetString(data, "status")
+			_, numberType, _, _ := jsonparser.Get(data, "number")
+			itemName, _ := jsonparser.GetString(data, "items", "[0]", "name")
+			itemPrice, _ := jsonparser.GetFloat(data, "items", "[0]", "price")
+			itemAmount, _ := jsonparser.GetInt(data, "items", "[0]", "amount")
+			itemTotal, _ := jsonparser.GetFloat(data, "items", "[0]", "total")
+			_, _, _, secondItemErr := jsonparser.Get(data, "items", "[1]")
+			subtotal, _ := jsonparser.GetFloat(data, "subtotal")
+			total, _ := jsonparser.GetFloat(data, "total")
+			invoiceCompanyShare, _ := jsonparser.GetFloat(data, "companyShare")
+			invoiceEmployeeShare, _ := jsonparser.GetFloat(data, "employeeShare")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, "draft", status)
+			assert.Equal(t, jsonparser.Null, numberType)
+			assert.Equal(t, "манты", itemName)
+			assert.Equal(t, float64(150), itemPrice)
+			assert.Equal(t, int64(2), itemAmount)
+			assert.Equal(t, float64(300), itemTotal)
+			assert.NotNil(t, secondItemErr)
+			assert.Equal(t, float64(300), subtotal)
+			assert.Equal(t, float64(300), total)
+			assert.Equal(t, companyShare, invoiceCompanyShare)
+			assert.Equal(t, employeeShare, invoiceEmployeeShare)
+			assert.Equal(t, float64(300), invoiceCompanyShare+invoiceEmployeeShare)
+		})
+
+	// Trying to create invoice for overlapping period
+	// Should throw an error
+	r.POST(invoicesURL).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"from": "2121-12-15T00:00:00Z",
+			"to":   "2122-01-15T00:00:00Z",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "invoice for that period already exists", errorValue)
+		})
+
+	// Trying to pay draft invoice
+	// Should throw an error
+	r.PUT(invoicesURL+"/"+invoiceID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"status": "paid",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "invoice can't be moved from draft to paid", errorValue)
+		})
+
+	// Trying to issue invoice
+	// Should be success and invoice gets number
+	r.PUT(invoicesURL+"/"+invoiceID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"status": "issued",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			status, _ := jsonparser.GetString(data, "status")
+			number, _ := jsonparser.GetInt(data, "number")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "issued", status)
+			assert.NotEqual(t, int64(0), number)
+		})
+
+	// Trying to delete issued invoice
+	// Should throw an error
+	r.DELETE(invoicesURL+"/"+invoiceID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "only draft invoice can be deleted", errorValue)
+		})
+
+	// Trying to get invoice in unknown format
+	// Should throw an error
+	r.GET(invoicesURL+"/"+invoiceID+"/file?format=docx").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+
+	// Trying to get invoice as pdf
+	// Should be success
+	r.GET(invoicesURL+"/"+invoiceID+"/file?format=pdf").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "%PDF", r.Body.String()[:4])
+		})
+
+	// Trying to get invoice as xlsx
+	// Should contain the same item as invoice
+	r.GET(invoicesURL+"/"+invoiceID+"/file?format=xlsx").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+			file, err := excelize.OpenReader(bytes.NewReader(r.Body.Bytes()))
+			assert.Nil(t, err)
+			assert.Equal(t, "Блюдо", file.GetCellValue("Sheet1", "B5"))
+			assert.Equal(t, "манты", file.GetCellValue("Sheet1", "B6"))
+			assert.Equal(t, "300", file.GetCellValue("Sheet1", "E6"))
+		})
+
+	// Restoring dish
+	r.DELETE("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+}
@@ -0,0 +1,291 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestLabels(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	categoryID := categoryResult.ID.String()
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryID)
+	dishID := dishResult.ID.String()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	clientUser, _ := userRepo.GetByKey("email", "user1@meals.com")
+	clientUserID := clientUser.ID.String()
+	clientUserJwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: clientUserID})
+	var glutenID string
+	var veganID string
+	var orderID string
+
+	// Trying to get allergens of catering
+	// Should return default allergens
+	r.GET("/caterings/"+cateringID+"/labels?type=allergen").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			count := 0
+			_, _ = jsonparser.ArrayEach(r.Body.Bytes(), func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+				count++
+				if name, _ := jsonparser.GetString(value, "name"); name == "gluten" {
+					glutenID, _ = jsonparser.GetString(value, "id")
+				}
+			})
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, 14, count)
+		})
+
+	// Trying to get dietary tags of catering
+	// Should return default tags
+	r.GET("/caterings/"+cateringID+"/labels?type=dietary").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			_, _ = jsonparser.ArrayEach(r.Body.Bytes(), func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+				if name, _ := jsonparser.GetString(value, "name"); name == "vegan" {
+					veganID, _ = jsonparser.GetString(value, "id")
+				}
+			})
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.NotEqual(t, "", veganID)
+		})
+
+	// Trying to add label with unknown type
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/labels").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name": "spicy",
+			"type": "taste",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+
+	// Trying to add existing allergen in other case
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/labels").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name": "Gluten",
+			"type": "allergen",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "label with that name already exist", errorValue)
+		})
+
+	// Trying to attach gluten to dish
+	// Should be success
+	r.PUT("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       dishResult.Name,
+			"weight":     dishResult.Weight,
+			"price":      dishResult.Price,
+			"desc":       dishResult.Desc,
+			"categoryId": categoryID,
+			"labelIds":   []string{glutenID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to get dish
+	// Should return dish with gluten
+	r.GET("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			name, _ := jsonparser.GetString(data, "labels", "[0]", "name")
+			labelType, _ := jsonparser.GetString(data, "labels", "[0]", "type")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "gluten", name)
+			assert.Equal(t, "allergen", labelType)
+		})
+
+	// Trying to get dishes without gluten
+	// Should return list without dish
+	r.GET("/caterings/"+cateringID+"/dishes?categoryID="+categoryID+"&exclude_allergens=gluten,milk").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			found := false
+			_, _ = jsonparser.ArrayEach(r.Body.Bytes(), func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+				if id, _ := jsonparser.GetString(value, "id"); id == dishID {
+					found = true
+				}
+			})
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, false, found)
+		})
+
+	// Trying to get vegan dishes
+	// Should return list without dish
+	r.GET("/caterings/"+cateringID+"/dishes?categoryID="+categoryID+"&tags=vegan").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			found := false
+			_, _ = jsonparser.ArrayEach(r.Body.Bytes(), func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+				if id, _ := jsonparser.GetString(value, "id"); id == dishID {
+					found = true
+				}
+			})
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, false, found)
+		})
+
+	// Publish menu, so client user can order
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-09-16T00:00:00Z",
+			"dishes": []string{dishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Trying to get menu without gluten
+	// Should return menu without dishes
+	r.GET("/caterings/"+cateringID+"/clients/"+clientID+"/meals?date=2121-09-16T00%3A00%3A00Z&exclude_allergens=gluten").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			_, dataType, _, _ := jsonparser.Get(data, "[0]", "dishes", "[0]")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, jsonparser.NotExist, dataType)
+		})
+
+	// Trying to save dietary profile with label of other catering
+	// Should throw error
+	r.PUT("/users/"+clientUserID+"/dietary-profile").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"labelIds": []string{clientID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "label not found", errorValue)
+		})
+
+	// Trying to save dietary profile of user
+	// Should return profile
+	r.PUT("/users/"+clientUserID+"/dietary-profile").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"labelIds": []string{glutenID, veganID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			allergen, _ := jsonparser.GetString(data, "labels", "[0]", "name")
+			tag, _ := jsonparser.GetString(data, "labels", "[1]", "name")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "gluten", allergen)
+			assert.Equal(t, "vegan", tag)
+		})
+
+	// Client user creates order with dish conflicting with profile
+	// Should be success with warning
+	r.POST("/users/"+clientUserID+"/orders?date=2121-09-16T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"items": []gofight.D{{"dishId": dishID, "amount": 1}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			orderID, _ = jsonparser.GetString(data, "orderId")
+			warningDishID, _ := jsonparser.GetString(data, "warnings", "[0]", "dishId")
+			allergen, _ := jsonparser.GetString(data, "warnings", "[0]", "allergens", "[0]")
+			missingTag, _ := jsonparser.GetString(data, "warnings", "[0]", "missingTags", "[0]")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, dishID, warningDishID)
+			assert.Equal(t, "gluten", allergen)
+			assert.Equal(t, "vegan", missingTag)
+		})
+
+	// Restoring order, dietary profile and labels of dish
+	r.DELETE("/users/"+clientUserID+"/orders/"+orderID).
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	r.PUT("/users/"+clientUserID+"/dietary-profile").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"labelIds": []string{},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			_, dataType, _, _ := jsonparser.Get(data, "labels", "[0]")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, jsonparser.NotExist, dataType)
+		})
+
+	r.PUT("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       dishResult.Name,
+			"weight":     dishResult.Weight,
+			"price":      dishResult.Price,
+			"desc":       dishResult.Desc,
+			"categoryId": categoryID,
+			"labelIds":   []string{},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+}
@@ -3,9 +3,11 @@ package tests
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Aiscom-LLC/meals-api/api"
 	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/api/url"
 	"github.com/Aiscom-LLC/meals-api/repository"
 	"github.com/appleboy/gofight/v2"
 	"github.com/buger/jsonparser"
@@ -142,3 +144,452 @@ func TestGetMeal(t *testing.T) {
 			assert.Equal(t, "can't parse the date", errorValue)
 		})
 }
+
+func TestUpdateMealPortions(t *testing.T) {
+	r := gofight.New()
+
+	dishRepo := repository.NewDishRepo()
+	userRepo := repository.NewUserRepo()
+	mealRepo := repository.NewMealRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	cateringRepo := repository.NewCateringRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryResult.ID.String())
+	dishID := dishResult.ID.String()
+	userResult, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userResult.ID.String()})
+	mealDate, _ := time.Parse(time.RFC3339, "2120-06-21T00:00:00Z")
+
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2120-06-21T00:00:00Z",
+			"dishes": []string{dishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	meals, _, _ := mealRepo.Get(mealDate, cateringID, clientID, url.LabelFilterQuery{})
+	mealID := meals[0].MealID.String()
+
+	// Trying to limit portions of dish
+	// Should be success
+	r.PUT("/caterings/"+cateringID+"/clients/"+clientID+"/meals/"+mealID+"/dishes/"+dishID+"/portions").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"portions": 10,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	// Trying to get meal with limited dish
+	// Should return remaining portions
+	r.GET("/caterings/"+cateringID+"/clients/"+clientID+"/meals?date=2120-06-21T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			remaining, _ := jsonparser.GetInt(data, "[0]", "dishes", "[0]", "remaining")
+			soldOut, _ := jsonparser.GetBoolean(data, "[0]", "dishes", "[0]", "soldOut")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, int64(10), remaining)
+			assert.Equal(t, false, soldOut)
+		})
+
+	// Trying to set negative portions
+	// Should return an error
+	r.PUT("/caterings/"+cateringID+"/clients/"+clientID+"/meals/"+mealID+"/dishes/"+dishID+"/portions").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"portions": -1,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "portions can't be negative", errorValue)
+		})
+}
+
+func TestCopyMeal(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryResult.ID.String())
+	dishID := dishResult.ID.String()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	var categoryID string
+	var deletedDishID string
+
+	// Create category with dish, which is deleted after meal is published
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/categories").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name": "копия",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			categoryID, _ = jsonparser.GetString(r.Body.Bytes(), "id")
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	r.POST("/caterings/"+cateringID+"/dishes").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       "окрошка",
+			"weight":     300,
+			"price":      90,
+			"categoryId": categoryID,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			deletedDishID, _ = jsonparser.GetString(r.Body.Bytes(), "id")
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":     "2121-10-01T00:00:00Z",
+			"dishes":   []string{dishID, deletedDishID},
+			"portions": gofight.D{dishID: 5},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	r.DELETE("/caterings/"+cateringID+"/clients/"+clientID+"/categories/"+categoryID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to copy meal to the same date and client
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals/copy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":  "2121-10-01T00:00:00Z",
+			"dates": []string{"2121-10-01T00:00:00Z"},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "can't copy meal to the same date and client", errorValue)
+		})
+
+	// Trying to copy meal to non-existing client
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals/copy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":      "2121-10-01T00:00:00Z",
+			"dates":     []string{"2121-10-02T00:00:00Z"},
+			"clientIds": []string{uuid.NewV4().String()},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "client not found", errorValue)
+		})
+
+	// Trying to copy meal from date without meal
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals/copy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":  "2121-10-05T00:00:00Z",
+			"dates": []string{"2121-10-02T00:00:00Z"},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "meal with this date not found", errorValue)
+		})
+
+	// Trying to copy meal to next day
+	// Should be success, dish of deleted category is skipped
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals/copy").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":  "2121-10-01T00:00:00Z",
+			"dates": []string{"2121-10-02T00:00:00Z"},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			version, _ := jsonparser.GetString(data, "[0]", "version")
+			dishes, _ := jsonparser.GetInt(data, "[0]", "dishes")
+			skippedID, _ := jsonparser.GetString(data, "[0]", "skipped", "[0]", "dishId")
+			reason, _ := jsonparser.GetString(data, "[0]", "skipped", "[0]", "reason")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, "V.1", version)
+			assert.Equal(t, int64(1), dishes)
+			assert.Equal(t, deletedDishID, skippedID)
+			assert.Equal(t, "category deleted", reason)
+		})
+
+	// Trying to get copied meal
+	// Should return meal with copied dish and its portion limit
+	r.GET("/caterings/"+cateringID+"/clients/"+clientID+"/meals?date=2121-10-02T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			person, _ := jsonparser.GetString(data, "[0]", "person")
+			copiedID, _ := jsonparser.GetString(data, "[0]", "dishes", "[0]", "id")
+			remaining, _ := jsonparser.GetInt(data, "[0]", "dishes", "[0]", "remaining")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, user.FirstName+" "+user.LastName, person)
+			assert.Equal(t, dishID, copiedID)
+			assert.Equal(t, int64(5), remaining)
+		})
+}
+
+func TestMealVersions(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	categoryID := categoryResult.ID.String()
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryID)
+	dishID := dishResult.ID.String()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	var mealID string
+	var newDishID string
+
+	// Publish V.1 with one dish
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-10-10T00:00:00Z",
+			"dishes": []string{dishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			mealID, _ = jsonparser.GetString(r.Body.Bytes(), "[0]", "mealId")
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Change price of dish and publish V.2 with new dish
+	r.PUT("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       dishResult.Name,
+			"weight":     dishResult.Weight,
+			"price":      dishResult.Price + 10,
+			"desc":       dishResult.Desc,
+			"categoryId": categoryID,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	r.POST("/caterings/"+cateringID+"/dishes").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       "солянка",
+			"weight":     300,
+			"price":      110,
+			"categoryId": categoryID,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			newDishID, _ = jsonparser.GetString(r.Body.Bytes(), "id")
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-10-10T00:00:00Z",
+			"dishes": []string{dishID, newDishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			version, _ := jsonparser.GetString(r.Body.Bytes(), "[0]", "version")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, "V.2", version)
+		})
+
+	// Trying to get diff of V.1 and V.2
+	// Should return added dish and changed price
+	r.GET("/caterings/"+cateringID+"/clients/"+clientID+"/meals/"+mealID+"/versions/V.1/diff/V.2").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			added, _ := jsonparser.GetString(data, "added", "[0]", "dishId")
+			_, removed, _, _ := jsonparser.Get(data, "removed", "[0]")
+			changedID, _ := jsonparser.GetString(data, "priceChanges", "[0]", "dishId")
+			oldPrice, _ := jsonparser.GetFloat(data, "priceChanges", "[0]", "oldPrice")
+			newPrice, _ := jsonparser.GetFloat(data, "priceChanges", "[0]", "newPrice")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, newDishID, added)
+			assert.Equal(t, jsonparser.NotExist, removed)
+			assert.Equal(t, dishID, changedID)
+			assert.Equal(t, float64(dishResult.Price), oldPrice)
+			assert.Equal(t, float64(dishResult.Price+10), newPrice)
+		})
+
+	// Trying to get diff in reverse order with version numbers
+	// Should return removed dish
+	r.GET("/caterings/"+cateringID+"/clients/"+clientID+"/meals/"+mealID+"/versions/2/diff/1").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			removed, _ := jsonparser.GetString(data, "removed", "[0]", "dishId")
+			_, added, _, _ := jsonparser.Get(data, "added", "[0]")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, newDishID, removed)
+			assert.Equal(t, jsonparser.NotExist, added)
+		})
+
+	// Trying to get diff with non-existing version
+	// Should throw error
+	r.GET("/caterings/"+cateringID+"/clients/"+clientID+"/meals/"+mealID+"/versions/V.1/diff/V.9").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "meal version not found", errorValue)
+		})
+
+	// Trying to roll back to the latest version
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals/rollback").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"mealId":  mealID,
+			"version": "V.2",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "version is already the latest one", errorValue)
+		})
+
+	// Trying to roll back to V.1
+	// Should publish V.3 with dishes of V.1
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals/rollback").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"mealId":  mealID,
+			"version": "1",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			version, _ := jsonparser.GetString(data, "[0]", "version")
+			firstDish, _ := jsonparser.GetString(data, "[0]", "dishes", "[0]", "id")
+			_, secondDish, _, _ := jsonparser.Get(data, "[0]", "dishes", "[1]")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, "V.3", version)
+			assert.Equal(t, dishID, firstDish)
+			assert.Equal(t, jsonparser.NotExist, secondDish)
+		})
+
+	// Trying to get diff of V.1 and rolled back V.3
+	// Should return no changes, V.3 keeps prices of V.1
+	r.GET("/caterings/"+cateringID+"/clients/"+clientID+"/meals/"+mealID+"/versions/V.1/diff/V.3").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			_, priceChange, _, _ := jsonparser.Get(data, "priceChanges", "[0]")
+			_, added, _, _ := jsonparser.Get(data, "added", "[0]")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, jsonparser.NotExist, priceChange)
+			assert.Equal(t, jsonparser.NotExist, added)
+		})
+
+	// Restoring price of dish and removing new dish
+	r.PUT("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       dishResult.Name,
+			"weight":     dishResult.Weight,
+			"price":      dishResult.Price,
+			"desc":       dishResult.Desc,
+			"categoryId": categoryID,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	r.DELETE("/caterings/"+cateringID+"/dishes/"+newDishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+}
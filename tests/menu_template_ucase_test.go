@@ -0,0 +1,256 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/go-playground/assert/v2"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestMenuTemplates(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	categoryID := categoryResult.ID.String()
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryID)
+	dishID := dishResult.ID.String()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	var menuTemplateID string
+
+	// Trying to create menu template with day out of cycle
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/menu-templates").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":      "ротация",
+			"startDate": "2121-11-04T00:00:00Z",
+			"length":    7,
+			"items":     []gofight.D{{"day": 7, "clientId": clientID, "dishId": dishID}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "day must be between 0 and length of cycle", errorValue)
+		})
+
+	// Trying to create menu template for non-existing client
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/menu-templates").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":      "ротация",
+			"startDate": "2121-11-04T00:00:00Z",
+			"length":    7,
+			"items":     []gofight.D{{"day": 0, "clientId": uuid.NewV4().String(), "dishId": dishID}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "client not found", errorValue)
+		})
+
+	// Trying to create menu template, which starts on Tuesday
+	// Should be success, template is disabled
+	r.POST("/caterings/"+cateringID+"/menu-templates").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":      "ротация",
+			"startDate": "2121-11-04T00:00:00Z",
+			"length":    7,
+			"items": []gofight.D{
+				{"day": 0, "clientId": clientID, "dishId": dishID},
+				{"day": 1, "clientId": clientID, "categoryId": categoryID},
+				{"day": 5, "clientId": clientID, "dishId": dishID},
+			},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			menuTemplateID, _ = jsonparser.GetString(data, "id")
+			enabled, _ := jsonparser.GetBoolean(data, "enabled")
+			categoryValue, _ := jsonparser.GetString(data, "items", "[1]", "categoryId")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, false, enabled)
+			assert.Equal(t, categoryID, categoryValue)
+		})
+
+	// Trying to skip Wednesday of the first cycle
+	// Should be success
+	r.PUT("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID+"/overrides").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"clientId": clientID,
+			"date":     "2121-11-05T00:00:00Z",
+			"dishes":   []string{},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	// Trying to override previous date
+	// Should throw error
+	r.PUT("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID+"/overrides").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"clientId": clientID,
+			"date":     "2020-11-05T00:00:00Z",
+			"dishes":   []string{dishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "can't override previous date", errorValue)
+		})
+
+	// Trying to preview two cycles
+	// Should return Tuesdays and Wednesdays only, weekend isn't working
+	r.GET("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID+"/preview?from=2121-11-04T00:00:00Z&to=2121-11-12T00:00:00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			count := 0
+			_, _ = jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+				count++
+			})
+			firstDish, _ := jsonparser.GetString(data, "[0]", "dishes", "[0]", "id")
+			override, _ := jsonparser.GetBoolean(data, "[1]", "override")
+			_, skippedDishes, _, _ := jsonparser.Get(data, "[1]", "dishes", "[0]")
+			thirdDate, _ := jsonparser.GetString(data, "[2]", "date")
+			fourthDay, _ := jsonparser.GetInt(data, "[3]", "day")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, 4, count)
+			assert.Equal(t, dishID, firstDish)
+			assert.Equal(t, true, override)
+			assert.Equal(t, jsonparser.NotExist, skippedDishes)
+			assert.Equal(t, "2121-11-11T00:00:00Z", thirdDate)
+			assert.Equal(t, int64(1), fourthDay)
+		})
+
+	// Trying to preview too long range
+	// Should throw error
+	r.GET("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID+"/preview?from=2121-11-01T00:00:00Z&to=2122-11-01T00:00:00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+
+	// Trying to cancel override
+	// Should be success
+	r.DELETE("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID+"/overrides?date=2121-11-05T00:00:00Z&clientId="+clientID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to cancel the same override again
+	// Should throw error
+	r.DELETE("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID+"/overrides?date=2121-11-05T00:00:00Z&clientId="+clientID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "date isn't overridden", errorValue)
+		})
+
+	// Trying to enable menu template
+	// Should be success
+	r.PUT("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":      "ротация",
+			"startDate": "2121-11-04T00:00:00Z",
+			"length":    7,
+			"enabled":   true,
+			"items":     []gofight.D{{"day": 0, "clientId": clientID, "dishId": dishID}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			enabled, _ := jsonparser.GetBoolean(data, "enabled")
+			_, secondItem, _, _ := jsonparser.Get(data, "items", "[1]")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, true, enabled)
+			assert.Equal(t, jsonparser.NotExist, secondItem)
+		})
+
+	// Trying to enable another menu template for the same client
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/menu-templates").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":      "вторая ротация",
+			"startDate": "2121-11-04T00:00:00Z",
+			"length":    14,
+			"enabled":   true,
+			"items":     []gofight.D{{"day": 0, "clientId": clientID, "dishId": dishID}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "another enabled menu template already covers that client", errorValue)
+		})
+
+	// Trying to delete menu template
+	// Should be success
+	r.DELETE("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to get deleted menu template
+	// Should throw error
+	r.GET("/caterings/"+cateringID+"/menu-templates/"+menuTemplateID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "menu template not found", errorValue)
+		})
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestNotifications(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryResult.ID.String())
+	adminUser, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	adminJwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: adminUser.ID.String()})
+	user, _ := userRepo.GetByKey("email", "user1@meals.com")
+	userID := user.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	var orderID string
+
+	// Publish menu, create order and cancel it
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-07-03T00:00:00Z",
+			"dishes": []string{dishResult.ID.String()},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	r.POST("/users/"+userID+"/orders?date=2121-07-03T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"items": []gofight.D{{"dishId": dishResult.ID.String(), "amount": 1}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			orderID, _ = jsonparser.GetString(data, "orderId")
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	r.DELETE("/users/"+userID+"/orders/"+orderID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to get unread notifications of user
+	// Should return notification about canceled order
+	r.GET("/users/"+userID+"/notifications?unread=true").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			itemOrderID, _ := jsonparser.GetString(data, "items", "[0]", "orderId")
+			to, _ := jsonparser.GetString(data, "items", "[0]", "to")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, orderID, itemOrderID)
+			assert.Equal(t, "canceled", to)
+		})
+
+	// Trying to mark all notifications as read
+	// Should be success
+	r.PUT("/users/"+userID+"/notifications").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to get unread notifications after reading
+	// Should return no notifications
+	r.GET("/users/"+userID+"/notifications?unread=true").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			total, _ := jsonparser.GetInt(data, "total")
+			unread, _ := jsonparser.GetInt(data, "unread")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, int64(0), total)
+			assert.Equal(t, int64(0), unread)
+		})
+}
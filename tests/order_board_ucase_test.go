@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestStreamOrders(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryResult.ID.String())
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	clientUser, _ := userRepo.GetByKey("email", "user1@meals.com")
+	clientUserID := clientUser.ID.String()
+	clientUserJwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: clientUserID})
+	var orderID string
+
+	// Publish menu, so client user can order
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-09-17T00:00:00Z",
+			"dishes": []string{dishResult.ID.String()},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Trying to stream orders of non-existing client
+	// Should throw error
+	r.GET("/clients/00000000-0000-0000-0000-000000000000/orders/stream").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		})
+
+	// Open stream of client orders
+	server := httptest.NewServer(api.SetupRouter())
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/clients/"+clientID+"/orders/stream", nil)
+	request.AddCookie(&http.Cookie{Name: "jwt", Value: jwt})
+	response, err := http.DefaultClient.Do(request)
+	assert.Equal(t, nil, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	// events are read until stream is closed
+	received := make(chan [2]string, 10)
+	go func() {
+		var event string
+		scanner := bufio.NewScanner(response.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "event:") {
+				event = line[len("event:"):]
+			}
+			if strings.HasPrefix(line, "data:") {
+				received <- [2]string{event, line[len("data:"):]}
+			}
+		}
+	}()
+
+	nextEvent := func() (string, []byte) {
+		select {
+		case event := <-received:
+			return event[0], []byte(event[1])
+		case <-time.After(5 * time.Second):
+			t.Fatal("event wasn't received")
+			return "", nil
+		}
+	}
+
+	// Client user creates order
+	// Stream should receive created order with amounts of dishes
+	r.POST("/users/"+clientUserID+"/orders?date=2121-09-17T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"items": []gofight.D{{"dishId": dishResult.ID.String(), "amount": 2}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			orderID, _ = jsonparser.GetString(data, "orderId")
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	event, data := nextEvent()
+	eventOrderID, _ := jsonparser.GetString(data, "data", "order", "orderId")
+	dishID, _ := jsonparser.GetString(data, "data", "dishes", "[0]", "dishId")
+	amount, _ := jsonparser.GetInt(data, "data", "dishes", "[0]", "amount")
+	assert.Equal(t, "order.created", event)
+	assert.Equal(t, orderID, eventOrderID)
+	assert.Equal(t, dishResult.ID.String(), dishID)
+	assert.Equal(t, int64(2), amount)
+
+	// Client user cancels order
+	// Stream should receive canceled order without its dishes
+	r.DELETE("/users/"+clientUserID+"/orders/"+orderID).
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	event, data = nextEvent()
+	status, _ := jsonparser.GetString(data, "data", "order", "status")
+	_, _, _, dishesErr := jsonparser.Get(data, "data", "dishes", "[0]")
+	assert.Equal(t, "order.canceled", event)
+	assert.Equal(t, "canceled", status)
+	assert.NotEqual(t, nil, dishesErr)
+
+	// Client user creates order again and catering rejects it
+	// Stream should receive rejected order without its dishes
+	r.POST("/users/"+clientUserID+"/orders?date=2121-09-17T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"items": []gofight.D{{"dishId": dishResult.ID.String(), "amount": 1}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			orderID, _ = jsonparser.GetString(data, "orderId")
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	event, _ = nextEvent()
+	assert.Equal(t, "order.created", event)
+
+	r.PUT("/clients/"+clientID+"/orders/status").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"orderIds": []string{orderID},
+			"status":   "rejected",
+			"reason":   "exceeds the allowance",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	event, data = nextEvent()
+	status, _ = jsonparser.GetString(data, "data", "order", "status")
+	_, _, _, dishesErr = jsonparser.Get(data, "data", "dishes", "[0]")
+	assert.Equal(t, "order.rejected", event)
+	assert.Equal(t, "rejected", status)
+	assert.NotEqual(t, nil, dishesErr)
+}
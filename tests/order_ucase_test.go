@@ -1,11 +1,16 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/360EntSecGroup-Skylar/excelize"
 	"github.com/Aiscom-LLC/meals-api/api"
 	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/domain"
 	"github.com/Aiscom-LLC/meals-api/repository"
 	"github.com/appleboy/gofight/v2"
 	"github.com/buger/jsonparser"
@@ -26,17 +31,27 @@ func TestAddOrder(t *testing.T) {
 
 	userRepo := repository.NewUserRepo()
 	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
 	categoryRepo := repository.NewCategoryRepo()
 	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
 	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
 	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
 	categoryID := categoryResult.ID.String()
 	dishRepo := repository.NewDishRepo()
 	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryID)
 	dishID := dishResult.ID.String()
-	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	adminUser, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	adminUserID := adminUser.ID.String()
+	adminJwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: adminUserID})
+	user, _ := userRepo.GetByKey("email", "user1@meals.com")
 	userID := user.ID.String()
 	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	var cateringSchedules []domain.CateringSchedule
+	var clientSchedules []domain.ClientSchedule
+	var cateringScheduleID string
+	var clientScheduleID string
 	var order Order
 	var dish Dish
 	dish.Amount = 1
@@ -45,6 +60,129 @@ func TestAddOrder(t *testing.T) {
 	order.Items = append(order.Items, dish)
 	var date = "2121-06-20T00%3A00%3A00Z"
 
+	// Publish menu for friday, orders for it are accepted on thursday
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-06-20T00:00:00Z",
+			"dishes": []string{dishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Trying to create new order for user without client
+	// Should return an error
+	r.POST("/users/"+adminUserID+"/orders?date="+date).
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		SetJSONInterface(order).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusForbidden, r.Code)
+			assert.Equal(t, "user doesn't belong to any client", errorValue)
+		})
+
+	// Move start of thursday's working hours to the end of the day
+	r.GET("/caterings/"+cateringID+"/schedules").
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			list, _, _, _ := jsonparser.Get(data, "list")
+			_ = json.Unmarshal(list, &cateringSchedules)
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	r.GET("/clients/"+clientID+"/schedules").
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			list, _, _, _ := jsonparser.Get(data, "list")
+			_ = json.Unmarshal(list, &clientSchedules)
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	for _, schedule := range cateringSchedules {
+		if schedule.Day == 3 {
+			cateringScheduleID = schedule.ID.String()
+		}
+	}
+
+	for _, schedule := range clientSchedules {
+		if schedule.Day == 3 {
+			clientScheduleID = schedule.ID.String()
+		}
+	}
+
+	r.PUT("/caterings/"+cateringID+"/schedules/"+cateringScheduleID).
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		SetJSON(gofight.D{
+			"end": "23:59",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	r.PUT("/clients/"+clientID+"/schedules/"+clientScheduleID).
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		SetJSON(gofight.D{
+			"start": "23:59",
+			"end":   "23:59",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	// Trying to create new order before start of working hours
+	// Should return an error
+	r.POST("/users/"+userID+"/orders?date="+date).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(order).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, true, strings.HasPrefix(errorValue, "orders can't be changed before "))
+		})
+
+	// Restoring working hours of thursday
+	r.PUT("/clients/"+clientID+"/schedules/"+clientScheduleID).
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		SetJSON(gofight.D{
+			"start": "00:00",
+			"end":   "16:45",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	r.PUT("/caterings/"+cateringID+"/schedules/"+cateringScheduleID).
+		SetCookie(gofight.H{
+			"jwt": adminJwt,
+		}).
+		SetJSON(gofight.D{
+			"end": "16:45",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
 	// Trying to create new order
 	// Should be success
 	r.POST("/users/"+userID+"/orders?date="+date).
@@ -86,13 +224,29 @@ func TestAddOrder(t *testing.T) {
 			assert.Equal(t, http.StatusBadRequest, r.Code)
 			assert.Equal(t, "can't add 2 same dishes, please increment amount field instead", errorValue)
 		})
+
+	// Trying to create new order for date without published menu
+	// Should return an error
+	order.Items = order.Items[:1]
+
+	r.POST("/users/"+userID+"/orders?date=2121-06-24T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(order).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "menu for 2121-06-24 isn't published yet", errorValue)
+		})
 }
 
 func TestGetOrder(t *testing.T) {
 	r := gofight.New()
 
 	userRepo := repository.NewUserRepo()
-	userResult, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	userResult, _ := userRepo.GetByKey("email", "user1@meals.com")
 	userID := userResult.ID.String()
 	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
 
@@ -119,3 +273,818 @@ func TestGetOrder(t *testing.T) {
 			assert.Equal(t, "record not found", errorValue)
 		})
 }
+
+func TestUpdateOrder(t *testing.T) {
+	r := gofight.New()
+
+	type Dish struct {
+		Amount int    `json:"amount"`
+		ID     string `json:"dishId"`
+	}
+	type Order struct {
+		Comment string `json:"comment"`
+		Items   []Dish `json:"items"`
+	}
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	orderRepo := repository.NewOrderRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	categoryID := categoryResult.ID.String()
+	dishRepo := repository.NewDishRepo()
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryID)
+	user, _ := userRepo.GetByKey("email", "user1@meals.com")
+	userID := user.ID.String()
+	userOrder, _, _ := orderRepo.GetUserOrder(userID, "2121-06-20T00:00:00Z")
+	orderID := userOrder.OrderID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	order := Order{
+		Comment: "new comment",
+		Items:   []Dish{{Amount: 2, ID: dishResult.ID.String()}},
+	}
+
+	// Trying to update order
+	// Should be success
+	r.PUT("/users/"+userID+"/orders/"+orderID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(order).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			comment, _ := jsonparser.GetString(data, "changes", "[1]", "after")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "new comment", comment)
+		})
+
+	// Trying to update order with 0 amount of dish
+	// Should return an error
+	order.Items[0].Amount = 0
+
+	r.PUT("/users/"+userID+"/orders/"+orderID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(order).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "can't add dish with 0 amount", errorValue)
+		})
+
+	// Trying to update non-existing order
+	// Should return an error
+	order.Items[0].Amount = 1
+
+	r.PUT("/users/"+userID+"/orders/"+userID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(order).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "order not found", errorValue)
+		})
+}
+
+func TestGetOrderStatus(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	clientRepo := repository.NewClientRepo()
+	userResult, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userResult.ID.String()})
+
+	// Trying to get order status for wednesday
+	// Should return cutoff at the end of previous tuesday
+	r.GET("/clients/"+clientID+"/order-status?date=2121-06-25T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			cutoff, _ := jsonparser.GetString(data, "cutoff")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, true, strings.HasPrefix(cutoff, "2121-06-24T"))
+		})
+
+	// Trying to get order status for sunday
+	// Should return empty cutoff
+	r.GET("/clients/"+clientID+"/order-status?date=2121-06-22T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			_, dataType, _, _ := jsonparser.Get(data, "cutoff")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, jsonparser.Null, dataType)
+		})
+}
+
+func TestBulkOrder(t *testing.T) {
+	r := gofight.New()
+
+	type Dish struct {
+		Amount int    `json:"amount"`
+		ID     string `json:"dishId"`
+	}
+	type Order struct {
+		Comment string `json:"comment"`
+		Items   []Dish `json:"items"`
+	}
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryResult.ID.String())
+	adminUser, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	adminJwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: adminUser.ID.String()})
+	user, _ := userRepo.GetByKey("email", "user1@meals.com")
+	userID := user.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	orders := map[string]Order{
+		"2121-07-01T00:00:00Z": {Items: []Dish{{Amount: 1, ID: dishResult.ID.String()}}},
+		"2121-07-02T00:00:00Z": {Items: []Dish{}},
+	}
+
+	// Publish menus for both days
+	for _, date := range []string{"2121-07-01T00:00:00Z", "2121-07-02T00:00:00Z"} {
+		r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+			SetCookie(gofight.H{
+				"jwt": adminJwt,
+			}).
+			SetJSON(gofight.D{
+				"date":   date,
+				"dishes": []string{dishResult.ID.String()},
+			}).
+			Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+				assert.Equal(t, http.StatusCreated, r.Code)
+			})
+	}
+
+	// Trying to create orders with one non-valid day
+	// Should return an error for that day
+	r.POST("/users/"+userID+"/orders-bulk").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(orders).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			success, _ := jsonparser.GetBoolean(data, "results", "[0]", "success")
+			errorValue, _ := jsonparser.GetString(data, "results", "[1]", "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, false, success)
+			assert.Equal(t, "order must contain at least one dish", errorValue)
+		})
+
+	// Trying to create orders with two dates of the same day
+	// Should return an error
+	r.POST("/users/"+userID+"/orders-bulk").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(map[string]Order{
+			"2121-07-01T00:00:00Z":      {Items: []Dish{{Amount: 1, ID: dishResult.ID.String()}}},
+			"2121-07-01T00:00:00+00:00": {Items: []Dish{{Amount: 1, ID: dishResult.ID.String()}}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, true, strings.HasSuffix(errorValue, "are the same day"))
+		})
+
+	// Trying to create orders for two days, one date isn't in UTC format
+	// Should be success
+	delete(orders, "2121-07-02T00:00:00Z")
+	orders["2121-07-02T00:00:00.000+00:00"] = Order{Items: []Dish{{Amount: 2, ID: dishResult.ID.String()}}}
+
+	r.POST("/users/"+userID+"/orders-bulk").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(orders).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			success, _ := jsonparser.GetBoolean(data, "results", "[1]", "success")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, true, success)
+		})
+
+	// Trying to cancel orders for both days
+	// Should be success
+	r.DELETE("/users/"+userID+"/orders-bulk?from=2121-07-01T00%3A00%3A00Z&to=2121-07-02T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			date, _ := jsonparser.GetString(data, "results", "[1]", "date")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "2121-07-02T00:00:00Z", date)
+		})
+}
+
+func TestUpdateOrdersStatus(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	clientRepo := repository.NewClientRepo()
+	userResult, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userResult.ID.String()})
+	fakeOrderID := "441477c2-d17f-47f3-b20c-0b22626385ce"
+
+	// Trying to reject order without reason
+	// Should return an error
+	r.PUT("/clients/"+clientID+"/orders/status").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"orderIds": []string{fakeOrderID},
+			"status":   "rejected",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "reason is required to reject order", errorValue)
+		})
+
+	// Trying to set non-valid status
+	// Should return an error
+	r.PUT("/clients/"+clientID+"/orders/status").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"orderIds": []string{fakeOrderID},
+			"status":   "canceled",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "status must be approved or rejected", errorValue)
+		})
+
+	// Trying to reject non-existing order
+	// Should return an error
+	r.PUT("/clients/"+clientID+"/orders/status").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"orderIds": []string{fakeOrderID},
+			"status":   "rejected",
+			"reason":   "exceeds the allowance",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "orders not found or already canceled", errorValue)
+		})
+}
+
+func TestUpdateDeliveryStatus(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	clientRepo := repository.NewClientRepo()
+	cateringRepo := repository.NewCateringRepo()
+	userResult, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userResult.ID.String()})
+
+	// Trying to set non-delivery status
+	// Should return an error
+	r.PUT("/caterings/"+cateringID+"/clients/"+clientID+"/orders?date=2121-08-01T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"status": "approved",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "status must be preparing, out_for_delivery, delivered or failed", errorValue)
+		})
+
+	// Trying to fail delivery without note
+	// Should return an error
+	r.PUT("/caterings/"+cateringID+"/clients/"+clientID+"/orders?date=2121-08-01T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"status": "failed",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "note is required for failed delivery", errorValue)
+		})
+
+	// Trying to start preparing for day without approved orders
+	// Should return an error
+	r.PUT("/caterings/"+cateringID+"/clients/"+clientID+"/orders?date=2121-08-01T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"status": "preparing",
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "no orders can be moved to preparing for provided day", errorValue)
+		})
+}
+
+func TestOrderHistory(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	orderRepo := repository.NewOrderRepo()
+	user, _ := userRepo.GetByKey("email", "user1@meals.com")
+	userID := user.ID.String()
+	userOrder, _, _ := orderRepo.GetUserOrder(userID, "2121-06-20T00:00:00Z")
+	orderID := userOrder.OrderID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+
+	// Trying to get orders history grouped by day
+	// Should be success
+	r.GET("/users/"+userID+"/orders-history?from=2121-06-01T00%3A00%3A00Z&to=2121-06-30T00%3A00%3A00Z&groupBy=day").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			total, _ := jsonparser.GetInt(data, "total")
+			itemOrderID, _ := jsonparser.GetString(data, "items", "[0]", "orderId")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, int64(1), total)
+			assert.Equal(t, orderID, itemOrderID)
+		})
+
+	// Trying to get orders history grouped by unknown period
+	// Should return an error
+	r.GET("/users/"+userID+"/orders-history?from=2121-06-01T00%3A00%3A00Z&to=2121-06-30T00%3A00%3A00Z&groupBy=year").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "groupBy must be day, week or month", errorValue)
+		})
+
+	// Trying to repeat order for the day which already has order
+	// Should return an error
+	r.POST("/users/"+userID+"/orders/"+orderID+"/repeat?date=2121-06-20T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "order for current day already created", errorValue)
+		})
+
+	// Trying to repeat non-existing order
+	// Should return an error
+	r.POST("/users/"+userID+"/orders/"+userID+"/repeat?date=2121-06-21T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		})
+}
+
+func TestGetProduction(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+
+	// Trying to get production sheet of catering
+	// Should be success
+	r.GET("/caterings/"+cateringID+"/production?date=2121-06-20T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			_, categoriesType, _, _ := jsonparser.Get(data, "categories")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, jsonparser.Array, categoriesType)
+		})
+
+	// Trying to get production sheet with invalid date
+	// Should return an error
+	r.GET("/caterings/"+cateringID+"/production?date=qwerty").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+
+	// Trying to get production sheet of non-existing catering
+	// Should return an error
+	r.GET("/caterings/"+user.ID.String()+"/production?date=2121-06-20T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusNotFound, r.Code)
+			assert.Equal(t, "catering not found", errorValue)
+		})
+}
+
+func TestExportClientOrders(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	clientRepo := repository.NewClientRepo()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+
+	// Trying to export orders of client for range of dates as json
+	// Should be success
+	r.GET("/clients/"+clientID+"/orders-file?from=2121-06-20T00%3A00%3A00Z&to=2121-06-21T00%3A00%3A00Z&format=json").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			sheetName, _ := jsonparser.GetString(data, "[0]", "name")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "Заказы", sheetName)
+			assert.Equal(t, `attachment; filename=orders_2121-06-20_2121-06-21.json`, r.HeaderMap.Get("Content-Disposition"))
+		})
+
+	// Trying to export orders of client for date as csv
+	// Should be success
+	r.GET("/clients/"+clientID+"/orders-file?date=2121-06-20T00%3A00%3A00Z&format=csv").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, true, strings.HasPrefix(r.Body.String(), "Заказы\n"))
+		})
+
+	// Trying to export orders of client in unknown format
+	// Should return an error
+	r.GET("/clients/"+clientID+"/orders-file?date=2121-06-20T00%3A00%3A00Z&format=docx").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "format must be one of csv, json, xlsx", errorValue)
+		})
+
+	// Trying to export orders of client for too long range
+	// Should return an error
+	r.GET("/clients/"+clientID+"/orders-file?from=2121-06-01T00%3A00%3A00Z&to=2121-08-01T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+}
+
+func TestOrderNutrition(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	categoryID := categoryResult.ID.String()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	userID := user.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	clientUser, _ := userRepo.GetByKey("email", "user1@meals.com")
+	clientUserID := clientUser.ID.String()
+	clientUserJwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: clientUserID})
+	var dishID string
+	var orderID string
+
+	// Trying to create dish with negative calories
+	// Should return an error
+	r.POST("/caterings/"+cateringID+"/dishes").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       "гречка",
+			"weight":     200,
+			"price":      50,
+			"kcal":       -1,
+			"categoryId": categoryID,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+
+	// Trying to create dish with nutrition facts
+	// Should be success
+	r.POST("/caterings/"+cateringID+"/dishes").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       "гречка",
+			"weight":     200,
+			"price":      50,
+			"kcal":       250,
+			"protein":    9,
+			"fat":        2.5,
+			"carbs":      50,
+			"categoryId": categoryID,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			dishID, _ = jsonparser.GetString(data, "id")
+			kcal, _ := jsonparser.GetFloat(data, "kcal")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, float64(250), kcal)
+		})
+
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-07-22T00:00:00Z",
+			"dishes": []string{dishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Trying to create order with two portions of dish
+	// Should return total nutrition facts of order
+	r.POST("/users/"+clientUserID+"/orders?date=2121-07-22T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"items": []gofight.D{{"dishId": dishID, "amount": 2}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			orderID, _ = jsonparser.GetString(data, "orderId")
+			itemKcal, _ := jsonparser.GetFloat(data, "items", "[0]", "kcal")
+			kcal, _ := jsonparser.GetFloat(data, "nutrition", "kcal")
+			fat, _ := jsonparser.GetFloat(data, "nutrition", "fat")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, float64(250), itemKcal)
+			assert.Equal(t, float64(500), kcal)
+			assert.Equal(t, float64(5), fat)
+		})
+
+	// Trying to get orders history grouped by week
+	// Should return nutrition facts of week
+	r.GET("/users/"+clientUserID+"/orders-history?from=2121-07-22T00%3A00%3A00Z&to=2121-07-22T00%3A00%3A00Z&groupBy=week").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			itemKcal, _ := jsonparser.GetFloat(data, "items", "[0]", "nutrition", "kcal")
+			periodKcal, _ := jsonparser.GetFloat(data, "periods", "[0]", "nutrition", "kcal")
+			periodCarbs, _ := jsonparser.GetFloat(data, "periods", "[0]", "nutrition", "carbs")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, float64(500), itemKcal)
+			assert.Equal(t, float64(500), periodKcal)
+			assert.Equal(t, float64(100), periodCarbs)
+		})
+
+	// Restoring order and dish
+	r.DELETE("/users/"+clientUserID+"/orders/"+orderID).
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	r.DELETE("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+}
+
+func TestOrderPriceSnapshot(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	categoryID := categoryResult.ID.String()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: user.ID.String()})
+	clientUser, _ := userRepo.GetByKey("email", "user1@meals.com")
+	clientUserID := clientUser.ID.String()
+	clientUserJwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: clientUserID})
+	var dishID string
+	var orderID string
+
+	// Create dish and publish menu with it, so client user can order
+	r.POST("/caterings/"+cateringID+"/dishes").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"name":       "плов",
+			"weight":     300,
+			"price":      100,
+			"categoryId": categoryID,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			dishID, _ = jsonparser.GetString(data, "id")
+			assert.Equal(t, http.StatusOK, r.Code)
+		})
+
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-11-18T00:00:00Z",
+			"dishes": []string{dishID},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Client user creates order with two portions of dish
+	// Should be success
+	r.POST("/users/"+clientUserID+"/orders?date=2121-11-18T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"items": []gofight.D{{"dishId": dishID, "amount": 2}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			orderID, _ = jsonparser.GetString(data, "orderId")
+			total, _ := jsonparser.GetFloat(data, "total")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, float64(200), total)
+		})
+
+	// Catering changes price of ordered dish
+	r.PUT("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"categoryID": categoryID,
+			"name":       "плов",
+			"price":      300,
+			"weight":     300,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to get order after price change
+	// Should return prices of the moment of ordering
+	r.GET("/users/"+clientUserID+"/orders?date=2121-11-18T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			total, _ := jsonparser.GetFloat(data, "total")
+			price, _ := jsonparser.GetInt(data, "items", "[0]", "price")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, float64(200), total)
+			assert.Equal(t, int64(100), price)
+		})
+
+	// Trying to export orders of client after price change
+	// Should contain total of the moment of ordering
+	r.GET("/clients/"+clientID+"/orders-file?date=2121-11-18T00%3A00%3A00Z&format=xlsx").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusOK, r.Code)
+			file, err := excelize.OpenReader(bytes.NewReader(r.Body.Bytes()))
+			assert.Equal(t, nil, err)
+			assert.Equal(t, "Сумма", file.GetCellValue("Заказы", "F1"))
+			assert.Equal(t, "200", file.GetCellValue("Заказы", "F2"))
+			assert.Equal(t, "200", file.GetCellValue("Итого", "B2"))
+		})
+
+	// Trying to update order after price change
+	// Should charge price of published menu
+	r.PUT("/users/"+clientUserID+"/orders/"+orderID).
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		SetJSON(gofight.D{
+			"items": []gofight.D{{"dishId": dishID, "amount": 1}},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			total, _ := jsonparser.GetFloat(data, "total")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, float64(100), total)
+		})
+
+	// Restoring order and dish
+	r.DELETE("/users/"+clientUserID+"/orders/"+orderID).
+		SetCookie(gofight.H{
+			"jwt": clientUserJwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to get audit of canceled order
+	// Should return cancel made by user
+	r.GET("/clients/"+clientID+"/audit?entity=order&entityId="+orderID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			actorID, _ := jsonparser.GetString(data, "items", "[0]", "actorId")
+			status, _ := jsonparser.GetString(data, "items", "[0]", "after", "status")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, clientUserID, actorID)
+			assert.Equal(t, "canceled", status)
+		})
+
+	r.DELETE("/caterings/"+cateringID+"/dishes/"+dishID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestReminders(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	clientRepo := repository.NewClientRepo()
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	userID := user.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+
+	// Trying to get reminder settings of client without settings
+	// Should return disabled reminders with default offset
+	r.GET("/clients/"+clientID+"/reminders").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			enabled, _ := jsonparser.GetBoolean(data, "enabled")
+			offset, _ := jsonparser.GetInt(data, "offset")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, false, enabled)
+			assert.Equal(t, int64(60), offset)
+		})
+
+	// Trying to set offset longer than one day
+	// Should throw an error
+	r.PUT("/clients/"+clientID+"/reminders").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"enabled": true,
+			"offset":  2000,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "offset must be between 1 and 1440 minutes", errorValue)
+		})
+
+	// Trying to enable reminders of client
+	// Should be success
+	r.PUT("/clients/"+clientID+"/reminders").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"enabled": true,
+			"offset":  90,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			enabled, _ := jsonparser.GetBoolean(data, "enabled")
+			offset, _ := jsonparser.GetInt(data, "offset")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, true, enabled)
+			assert.Equal(t, int64(90), offset)
+		})
+
+	// Trying to preview reminders of client
+	// Should be success
+	r.GET("/clients/"+clientID+"/reminders/preview").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			_, recipientsType, _, _ := jsonparser.Get(data, "recipients")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, jsonparser.Array, recipientsType)
+		})
+
+	// Trying to opt user out of reminders
+	// Should be success
+	r.PUT("/users/"+userID+"/reminders").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"enabled": false,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			enabled, _ := jsonparser.GetBoolean(data, "enabled")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, false, enabled)
+		})
+
+	// Trying to get reminder settings of opted out user
+	// Should return disabled reminders
+	r.GET("/users/"+userID+"/reminders").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			enabled, _ := jsonparser.GetBoolean(data, "enabled")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, false, enabled)
+		})
+
+	// Trying to opt user in again
+	// Should be success
+	r.PUT("/users/"+userID+"/reminders").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"enabled": true,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			enabled, _ := jsonparser.GetBoolean(data, "enabled")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, true, enabled)
+		})
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestStandingOrder(t *testing.T) {
+	r := gofight.New()
+
+	type Item struct {
+		Amount int    `json:"amount"`
+		ID     string `json:"dishId"`
+	}
+	type StandingOrder struct {
+		Weekdays []int  `json:"weekdays"`
+		Items    []Item `json:"items"`
+	}
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryResult.ID.String())
+	user, _ := userRepo.GetByKey("email", "user1@meals.com")
+	userID := user.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	standingOrder := StandingOrder{
+		Weekdays: []int{0, 1, 2, 3, 4},
+		Items:    []Item{{Amount: 1, ID: dishResult.ID.String()}},
+	}
+	var standingOrderID string
+
+	// Trying to create standing order
+	// Should be success
+	r.POST("/users/"+userID+"/standing-orders").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(standingOrder).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			standingOrderID, _ = jsonparser.GetString(data, "id")
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Trying to create standing order for the same weekdays
+	// Should return an error
+	r.POST("/users/"+userID+"/standing-orders").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(standingOrder).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "another standing order already covers that weekday", errorValue)
+		})
+
+	// Trying to skip single day
+	// Should be success
+	r.POST("/users/"+userID+"/standing-orders/"+standingOrderID+"/skips?date=2121-06-23T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+
+	// Trying to skip the same day again
+	// Should return an error
+	r.POST("/users/"+userID+"/standing-orders/"+standingOrderID+"/skips?date=2121-06-23T00%3A00%3A00Z").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "date is already skipped", errorValue)
+		})
+
+	// Trying to update standing order with wrong weekday
+	// Should return an error
+	standingOrder.Weekdays = []int{7}
+
+	r.PUT("/users/"+userID+"/standing-orders/"+standingOrderID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSONInterface(standingOrder).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "weekday must be between 0 and 6", errorValue)
+		})
+
+	// Trying to delete standing order
+	// Should be success
+	r.DELETE("/users/"+userID+"/standing-orders/"+standingOrderID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Aiscom-LLC/meals-api/api"
+	"github.com/Aiscom-LLC/meals-api/api/middleware"
+	"github.com/Aiscom-LLC/meals-api/repository"
+	"github.com/Aiscom-LLC/meals-api/services"
+	"github.com/appleboy/gofight/v2"
+	"github.com/buger/jsonparser"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestWebhooks(t *testing.T) {
+	r := gofight.New()
+
+	userRepo := repository.NewUserRepo()
+	cateringRepo := repository.NewCateringRepo()
+	clientRepo := repository.NewClientRepo()
+	categoryRepo := repository.NewCategoryRepo()
+	dishRepo := repository.NewDishRepo()
+	cateringResult, _ := cateringRepo.GetByKey("name", "Twiist")
+	cateringID := cateringResult.ID.String()
+	clientResult, _ := clientRepo.GetByKey("name", "Dymi")
+	clientID := clientResult.ID.String()
+	categoryResult, _ := categoryRepo.GetByKey("name", "гарнир", cateringID)
+	dishResult, _, _ := dishRepo.GetByKey("name", "доширак", cateringID, categoryResult.ID.String())
+	user, _ := userRepo.GetByKey("email", "meals@aisnovations.com")
+	userID := user.ID.String()
+	jwt, _, _ := middleware.Passport().TokenGenerator(&middleware.UserID{ID: userID})
+	secret := "webhook-test-secret"
+	var webhookID string
+	var deliveryID string
+
+	// Receiver checks signature of every request
+	received := make(chan string, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		payload, _ := ioutil.ReadAll(req.Body)
+		if req.Header.Get("X-Webhook-Signature") != services.WebhookSignature(secret, payload) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received <- req.Header.Get("X-Webhook-Event")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	nextEvent := func() string {
+		select {
+		case event := <-received:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("event wasn't received")
+			return ""
+		}
+	}
+
+	// Trying to create webhook with relative url
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/webhooks").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"url":    "/hooks",
+			"secret": secret,
+			"events": []string{"meal.published"},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			errorValue, _ := jsonparser.GetString(data, "error")
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+			assert.Equal(t, "url must be absolute http or https url", errorValue)
+		})
+
+	// Trying to create webhook with unknown event
+	// Should throw error
+	r.POST("/caterings/"+cateringID+"/webhooks").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"url":    receiver.URL,
+			"secret": secret,
+			"events": []string{"meal.eaten"},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusBadRequest, r.Code)
+		})
+
+	// Trying to create webhook of catering
+	// Should be success, secret isn't returned
+	r.POST("/caterings/"+cateringID+"/webhooks").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"url":    receiver.URL,
+			"secret": secret,
+			"events": []string{"meal.published"},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			webhookID, _ = jsonparser.GetString(data, "id")
+			active, _ := jsonparser.GetBoolean(data, "active")
+			_, _, _, secretErr := jsonparser.Get(data, "secret")
+			assert.Equal(t, http.StatusCreated, r.Code)
+			assert.Equal(t, true, active)
+			assert.NotEqual(t, nil, secretErr)
+		})
+
+	// Publish meal of catering
+	r.POST("/caterings/"+cateringID+"/clients/"+clientID+"/meals").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		SetJSON(gofight.D{
+			"date":   "2121-08-20T00:00:00Z",
+			"dishes": []string{dishResult.ID.String()},
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusCreated, r.Code)
+		})
+
+	// Send pending deliveries
+	// Receiver should get signed meal published event
+	services.NewWebhookService().SendDeliveries()
+	assert.Equal(t, "meal.published", nextEvent())
+
+	// Trying to get deliveries of webhook
+	// Should return delivered delivery
+	r.GET("/caterings/"+cateringID+"/webhooks/"+webhookID+"/deliveries").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			deliveryID, _ = jsonparser.GetString(data, "items", "[0]", "id")
+			status, _ := jsonparser.GetString(data, "items", "[0]", "status")
+			responseCode, _ := jsonparser.GetInt(data, "items", "[0]", "responseCode")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "delivered", status)
+			assert.Equal(t, int64(http.StatusOK), responseCode)
+		})
+
+	// Trying to redeliver delivery
+	// Should send it again
+	r.POST("/caterings/"+cateringID+"/webhooks/"+webhookID+"/deliveries/"+deliveryID+"/redeliver").
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			data := r.Body.Bytes()
+			status, _ := jsonparser.GetString(data, "status")
+			assert.Equal(t, http.StatusOK, r.Code)
+			assert.Equal(t, "delivered", status)
+		})
+	assert.Equal(t, "meal.published", nextEvent())
+
+	// Trying to delete webhook of catering from client routes
+	// Should throw error
+	r.DELETE("/clients/"+clientID+"/webhooks/"+webhookID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNotFound, r.Code)
+		})
+
+	// Trying to delete webhook
+	// Should be success
+	r.DELETE("/caterings/"+cateringID+"/webhooks/"+webhookID).
+		SetCookie(gofight.H{
+			"jwt": jwt,
+		}).
+		Run(api.SetupRouter(), func(r gofight.HTTPResponse, rq gofight.HTTPRequest) {
+			assert.Equal(t, http.StatusNoContent, r.Code)
+		})
+}
@@ -1,7 +1,8 @@
 package utils
 
 // CronStringCreator returns a string
-// for cron scheduler
-func CronStringCreator(tz, m, h string) string {
-	return "TZ=" + tz + " " + m + " " + h + " * * *"
+// for cron scheduler, the scheduler runs
+// in UTC and its specs start with seconds
+func CronStringCreator(m, h string) string {
+	return "0 " + m + " " + h + " * * *"
 }
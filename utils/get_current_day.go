@@ -5,11 +5,17 @@ import "time"
 // GetCurrentDay returns number of current day
 // Monday = 0, Tuesday = 1, Wednesday = 2, ...
 func GetCurrentDay() int {
-	currentDay := int(time.Now().Weekday())
-	if currentDay == 0 {
-		currentDay = 6
+	return GetDayOfWeek(time.Now())
+}
+
+// GetDayOfWeek returns number of day for provided date
+// in the same format as GetCurrentDay
+func GetDayOfWeek(date time.Time) int {
+	day := int(date.Weekday())
+	if day == 0 {
+		day = 6
 	} else {
-		currentDay--
+		day--
 	}
-	return currentDay
+	return day
 }
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"github.com/Aiscom-LLC/meals-api/domain"
+	"github.com/Aiscom-LLC/meals-api/repository/enums"
+	"github.com/jinzhu/gorm"
+	uuid "github.com/satori/go.uuid"
+)
+
+// DefaultAllergens are 14 allergens
+// which have to be declared in EU
+var DefaultAllergens = []string{
+	"celery",
+	"gluten",
+	"crustaceans",
+	"eggs",
+	"fish",
+	"lupin",
+	"milk",
+	"molluscs",
+	"mustard",
+	"nuts",
+	"peanuts",
+	"sesame",
+	"soya",
+	"sulphites",
+}
+
+// DefaultDietaryTags are dietary tags
+// which every catering has
+var DefaultDietaryTags = []string{
+	"vegan",
+	"vegetarian",
+	"halal",
+	"gluten-free",
+}
+
+// AddDefaultCateringLabels adds default allergens
+// and dietary tags for provided catering id in provided db
+func AddDefaultCateringLabels(db *gorm.DB, cateringID uuid.UUID) error {
+	for _, name := range DefaultAllergens {
+		if err := db.Create(&domain.Label{
+			Name:       name,
+			Type:       enums.LabelTypesEnum.Allergen,
+			CateringID: cateringID,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, name := range DefaultDietaryTags {
+		if err := db.Create(&domain.Label{
+			Name:       name,
+			Type:       enums.LabelTypesEnum.Dietary,
+			CateringID: cateringID,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
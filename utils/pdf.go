@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PDF page size in points, A4
+const (
+	PDFPageWidth  = 595
+	PDFPageHeight = 842
+)
+
+// PDF is minimal text only pdf document
+// written with standard Helvetica font, so no font files are needed,
+// the font has no cyrillic glyphs and cyrillic text is transliterated
+type PDF struct {
+	pages []*bytes.Buffer
+}
+
+// NewPDF returns pdf document with one empty page
+func NewPDF() *PDF {
+	pdf := &PDF{}
+	pdf.AddPage()
+	return pdf
+}
+
+// AddPage adds empty page, next text is written on it
+func (p *PDF) AddPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+}
+
+// Text writes text on current page, x and y are
+// counted in points from top left corner of page
+func (p *PDF) Text(x, y float64, size int, text string) {
+	page := p.pages[len(p.pages)-1]
+	fmt.Fprintf(page, "BT /F1 %d Tf %.2f %.2f Td (%s) Tj ET\n", size, x, PDFPageHeight-y, pdfString(text))
+}
+
+// Write writes pdf document to provided writer
+func (p *PDF) Write(w io.Writer) error {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// objects 1-3 are catalog, page tree and font,
+	// every page takes two objects: page and its content
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+i*2)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range p.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d]"+
+			" /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", PDFPageWidth, PDFPageHeight, 5+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// cyrillicLatin is used to transliterate cyrillic text for pdf
+var cyrillicLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ў': "u", '№': "No",
+}
+
+// pdfString transliterates and escapes text for pdf string literal
+func pdfString(text string) string {
+	var result strings.Builder
+
+	for _, r := range text {
+		lower := []rune(strings.ToLower(string(r)))[0]
+
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			result.WriteByte('\\')
+			result.WriteRune(r)
+		case r < 0x80:
+			result.WriteRune(r)
+		case r <= 0xFF:
+			result.WriteByte(byte(r))
+		default:
+			latin, ok := cyrillicLatin[lower]
+			if !ok {
+				result.WriteByte('?')
+				continue
+			}
+			if lower != r && latin != "" {
+				latin = strings.ToUpper(latin[:1]) + latin[1:]
+			}
+			result.WriteString(latin)
+		}
+	}
+
+	return result.String()
+}